@@ -54,6 +54,7 @@ const (
 	detailsCacheTTL  = 2 * time.Second
 	debounceDelay    = 200 * time.Millisecond
 	ciCacheTTL       = 30 * time.Second
+	diffStatCacheTTL = 30 * time.Second
 	defaultDirPerms  = utils.DefaultDirPerms
 	defaultFilePerms = 0o600
 
@@ -72,7 +73,7 @@ const (
 	commitMessageMaxLength     = 80
 	filterWorktreesPlaceholder = "Filter worktrees..."
 	placeholderFilterFiles     = "Filter files..."
-	worktreeNoteMaxChars       = 4000
+	worktreeNoteMaxChars       = services.WorktreeNoteMaxChars
 )
 
 type (
@@ -103,13 +104,23 @@ type (
 	cachedWorktreesMsg struct {
 		worktrees []*models.WorktreeInfo
 	}
+	diffStatsLoadedMsg struct {
+		stats map[string]diffStatEntry // keyed by worktree path
+	}
 	detailsCacheEntry struct {
 		statusRaw    string
+		submoduleRaw string
 		logRaw       string
 		unpushedSHAs map[string]bool
 		unmergedSHAs map[string]bool
 		fetchedAt    time.Time
 	}
+	diffStatEntry struct {
+		stat      models.DiffStat
+		ok        bool   // False when the worktree has no base to compare with
+		key       string // See diffStatKey
+		fetchedAt time.Time
+	}
 	pruneResultMsg struct {
 		worktrees      []*models.WorktreeInfo
 		err            error
@@ -118,9 +129,10 @@ type (
 		orphansDeleted int
 	}
 	absorbMergeResultMsg struct {
-		path   string
-		branch string
-		err    error
+		path         string
+		branch       string
+		conflictPath string // Worktree where a failed rebase or merge may have stopped
+		err          error
 	}
 	worktreeDeletedMsg struct {
 		path   string
@@ -146,6 +158,7 @@ type (
 		err    error
 	}
 	syncResultMsg struct {
+		path   string
 		stage  string
 		output string
 		err    error
@@ -168,12 +181,23 @@ type (
 		note        string
 		err         error
 	}
+	repairResultMsg struct {
+		worktrees []*models.WorktreeInfo
+		err       error
+		fixErr    error
+	}
 	renameWorktreeResultMsg struct {
 		oldPath   string
 		newPath   string
 		worktrees []*models.WorktreeInfo
 		err       error
 	}
+	moveWorktreesResultMsg struct {
+		moved     []worktreeMove
+		failures  []string
+		worktrees []*models.WorktreeInfo
+		err       error
+	}
 	createFromChangesReadyMsg struct {
 		worktree      *models.WorktreeInfo
 		currentBranch string
@@ -313,9 +337,14 @@ type Model struct {
 	state                     modelState
 	sortMode                  int // sortModePath, sortModeLastActive, or sortModeLastSwitched
 	prDataLoaded              bool
+	diffStatsLoaded           bool // At least one worktree has a diff stat, so the Diff column is shown
+	diffStatsLoading          bool
+	conflictChecksLoading     bool
 	checkMergedAfterPRRefresh bool // Flag to trigger merged check after PR data refresh
 	repoKey                   string
 	repoKeyOnce               sync.Once
+	remoteURL                 string
+	remoteURLOnce             sync.Once
 	currentDetailsPath        string
 	loading                   bool
 	loadingOperation          string // Tracks what operation is loading (push, sync, etc.)
@@ -333,6 +362,9 @@ type Model struct {
 		ciCache         services.CICheckCache // branch -> CI checks cache
 		detailsCache    map[string]*detailsCacheEntry
 		detailsCacheMu  sync.RWMutex
+		diffStats       map[string]diffStatEntry      // keyed by worktree path
+		conflictChecks  map[string]conflictCheckEntry // keyed by worktree path
+		conflictMu      sync.RWMutex
 	}
 	worktreesLoaded bool
 
@@ -677,17 +709,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.runCommandsWithTrust(initCmds, msg.targetPath, env, after)
 
+	case repairResultMsg:
+		return m.handleRepairResult(msg)
+
 	case renameWorktreeResultMsg:
 		if msg.err != nil {
 			m.showInfo(fmt.Sprintf("Error: %v", msg.err), nil)
 			return m, nil
 		}
-		m.migrateWorktreeNote(msg.oldPath, msg.newPath)
+		m.migrateWorktreeState(msg.oldPath, msg.newPath)
 		return m.handleWorktreesLoaded(worktreesLoadedMsg{
 			worktrees: msg.worktrees,
 			err:       nil,
 		})
 
+	case moveWorktreesResultMsg:
+		return m.handleMoveWorktreesResult(msg)
+
 	case openNoteEditorMsg:
 		return m, m.showWorktreeNoteEditor(msg.worktreePath)
 
@@ -878,9 +916,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			heading := "Synchronise failed."
 			switch msg.stage {
 			case "pull":
+				if m.showConflictsAfterFailure(msg.path, "Pull stopped on conflicts.") {
+					return m, nil
+				}
 				heading = "Pull failed."
 			case "push":
 				heading = "Push failed."
+			case syncStageUpdateFromBase:
+				if m.showConflictsAfterFailure(msg.path, "Update from base stopped on conflicts.") {
+					return m, nil
+				}
+				heading = "Update from base failed."
 			}
 			message := fmt.Sprintf("%s: %v", heading, msg.err)
 			if output != "" {
@@ -923,6 +969,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case cherryPickResultMsg:
 		return m, m.handleCherryPickResult(msg)
 
+	case stashResultMsg:
+		return m, m.handleStashResult(msg)
+
+	case conflictResultMsg:
+		return m, m.handleConflictResult(msg)
+
+	case hunkAppliedMsg:
+		return m, m.handleHunkApplied(msg)
+
+	case worktreeComparisonMsg:
+		return m, m.handleWorktreeComparison(msg)
+
+	case diffStatsLoadedMsg:
+		m.handleDiffStatsLoaded(msg)
+		return m, nil
+
+	case conflictChecksLoadedMsg:
+		m.handleConflictChecksLoaded(msg)
+		return m, nil
+
+	case conflictMatrixLoadedMsg:
+		m.handleConflictMatrixLoaded(msg)
+		return m, nil
+
 	case commitFilesLoadedMsg:
 		if msg.err != nil {
 			m.showInfo(fmt.Sprintf("Failed to load commit files: %v", msg.err), nil)
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/chmouel/lazyworktree/internal/app/handlers"
 	"github.com/chmouel/lazyworktree/internal/models"
@@ -22,6 +24,30 @@ func (m *Model) showDiff() tea.Cmd {
 	})
 }
 
+// showBaseDiff shows everything the selected worktree changes relative to its
+// base branch, like a pull request including the uncommitted changes.
+func (m *Model) showBaseDiff() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	base := m.worktreeBaseBranch(wt)
+	if base == "" {
+		m.showInfo("Could not determine the base branch.", nil)
+		return nil
+	}
+	if base == wt.Branch {
+		m.showInfo(fmt.Sprintf("%s is its own base branch; use Show diff for its changes.", wt.Branch), nil)
+		return nil
+	}
+
+	return m.diffRouter().ShowBaseDiff(handlers.BaseDiffParams{
+		BaseRef:         base,
+		Worktree:        wt,
+		BuildCommandEnv: m.buildCommandEnv,
+	})
+}
+
 // showFileDiff shows the diff for a single file in a pager.
 func (m *Model) showFileDiff(sf StatusFile) tea.Cmd {
 	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
@@ -52,6 +78,14 @@ func (m *Model) showCommitFileDiff(commitSHA, filename, worktreePath string) tea
 	})
 }
 
+func (m *Model) showStashDiff(stashSHA string, wt *models.WorktreeInfo) tea.Cmd {
+	return m.diffRouter().ShowStashDiff(handlers.StashDiffParams{
+		StashSHA:        stashSHA,
+		Worktree:        wt,
+		BuildCommandEnv: m.buildCommandEnv,
+	})
+}
+
 func (m *Model) diffRouter() *handlers.DiffRouter {
 	return &handlers.DiffRouter{
 		Config:                m.config,
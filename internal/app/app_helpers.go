@@ -355,6 +355,36 @@ func (m *Model) getRepoWorktreeDir() string {
 	return filepath.Join(m.getWorktreeDir(), m.getRepoKey())
 }
 
+// worktreeLayout returns the layout new worktrees are created in. The origin
+// remote is only looked up, once, when worktree_path_template is set.
+func (m *Model) worktreeLayout() services.WorktreeLayout {
+	layout := services.WorktreeLayout{
+		Template:    m.config.WorktreePathTemplate,
+		WorktreeDir: m.getWorktreeDir(),
+		RepoKey:     m.getRepoKey(),
+	}
+	if layout.Template == "" {
+		return layout
+	}
+	layout.MainPath = m.getMainWorktreePath()
+	m.remoteURLOnce.Do(func() {
+		m.remoteURL = m.state.services.git.RunGit(m.ctx, []string{"git", "remote", "get-url", "origin"}, "", []int{0}, true, true)
+	})
+	layout.RemoteURL = m.remoteURL
+	return layout
+}
+
+// getWorktreeRootDir returns the directory holding the worktrees of the
+// repository, or an empty string when the layout shares it with other
+// directories, in which case it is not scanned for leftovers.
+func (m *Model) getWorktreeRootDir() string {
+	root, dedicated := m.worktreeLayout().Root()
+	if !dedicated {
+		return ""
+	}
+	return root
+}
+
 // normalizePath returns a canonical path for comparison.
 // Resolves symlinks and cleans the path to prevent false positives
 // when comparing worktree paths.
@@ -399,11 +429,11 @@ func (m *Model) getValidWorktreePaths() map[string]bool {
 // findOrphanedWorktreeDirs returns directories in the worktree dir that exist on disk
 // but are not registered with git worktree.
 func (m *Model) findOrphanedWorktreeDirs() []string {
-	repoWorktreeDir := m.getRepoWorktreeDir()
+	repoWorktreeDir := m.getWorktreeRootDir()
 	validPaths := m.getValidWorktreePaths()
 
 	// If validPaths is nil, git service is unavailable - can't determine orphans
-	if validPaths == nil {
+	if validPaths == nil || repoWorktreeDir == "" {
 		return nil
 	}
 
@@ -423,7 +453,7 @@ func (m *Model) findOrphanedWorktreeDirs() []string {
 		}
 		fullPath := filepath.Join(repoWorktreeDir, entry.Name())
 		normalizedPath := normalizePath(fullPath)
-		if !validPaths[normalizedPath] {
+		if !validPaths[normalizedPath] && !services.ContainsWorktree(fullPath, validPaths) {
 			orphans = append(orphans, fullPath) // Store original for display/deletion
 		}
 	}
@@ -276,6 +276,38 @@ func TestFindOrphanedWorktreeDirs(t *testing.T) {
 	}
 }
 
+func TestFindOrphanedWorktreeDirsWithPathTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "repo")
+	nested := filepath.Join(tempDir, "repo.worktrees", "feature", "login")
+	orphanDir := filepath.Join(tempDir, "repo.worktrees", "stale")
+	for _, dir := range []string{mainPath, nested, orphanDir} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("failed to create dir %s: %v", dir, err)
+		}
+	}
+
+	cfg := &config.AppConfig{
+		WorktreeDir:          tempDir,
+		WorktreePathTemplate: "{repo_parent}/{repo}.worktrees/{branch}",
+	}
+	m := NewModel(cfg, "")
+	m.repoKey = "test-repo"
+	m.state.data.worktrees = []*models.WorktreeInfo{{Path: mainPath, IsMain: true}, {Path: nested, Branch: "feature/login"}}
+	mockGitWorktreeList(t, m, mainPath, nested)
+
+	orphans := m.findOrphanedWorktreeDirs()
+	if len(orphans) != 1 || orphans[0] != orphanDir {
+		t.Fatalf("expected only %q as orphan, got %v", orphanDir, orphans)
+	}
+
+	// A layout sharing its directory with other checkouts is not scanned.
+	m.config.WorktreePathTemplate = "../{repo}-{name}"
+	if orphans := m.findOrphanedWorktreeDirs(); orphans != nil {
+		t.Fatalf("expected no orphans for a shared directory, got %v", orphans)
+	}
+}
+
 func TestFindOrphanedWorktreeDirsNoGitService(t *testing.T) {
 	tempDir := t.TempDir()
 	repoDir := filepath.Join(tempDir, "test-repo")
@@ -195,13 +195,18 @@ func (m *Model) updateTable() {
 				name = string(nameRunes[:m.config.MaxNameLength]) + "..."
 			}
 		}
+		_, conflicts := m.predictedConflicts(wt)
+		name += worktreeStateMarkers(wt, len(conflicts) > 0)
 		statusStr := combinedStatusIndicator(wt.Dirty, wt.HasUpstream, wt.Ahead, wt.Behind, wt.Unpushed, showIcons, m.config.IconSet)
 
 		row := table.Row{
 			name,
 			statusStr,
-			wt.LastActive,
 		}
+		if m.diffStatsLoaded {
+			row = append(row, m.diffStatCell(wt))
+		}
+		row = append(row, wt.LastActive)
 
 		// Only include PR column if PR data has been loaded and PR is not disabled
 		if m.prDataLoaded && !m.config.DisablePR {
@@ -236,6 +241,32 @@ func (m *Model) updateTable() {
 	m.updateWorktreeArrows()
 }
 
+// worktreeStateMarkers returns the suffix shown after the worktree name for
+// states reported by git worktree list, and for a branch predicted to conflict
+// with its base.
+func worktreeStateMarkers(wt *models.WorktreeInfo, conflictsWithBase bool) string {
+	var markers []string
+	if wt.Detached {
+		markers = append(markers, "detached")
+	}
+	if wt.Locked {
+		markers = append(markers, "locked")
+	}
+	if wt.Prunable {
+		markers = append(markers, "prunable")
+	}
+	if wt.Operation != "" {
+		markers = append(markers, wt.Operation+" in progress")
+	}
+	if conflictsWithBase {
+		markers = append(markers, "conflicts with base")
+	}
+	if len(markers) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(markers, ", ") + "]"
+}
+
 func (m *Model) syncSelectedIndexFromCursor() {
 	cursor := m.state.ui.worktreeTable.Cursor()
 	if cursor < 0 || cursor >= len(m.state.data.filteredWts) {
@@ -284,7 +315,7 @@ func (m *Model) updateDetailsView() tea.Cmd {
 		return nil
 	}
 	return func() tea.Msg {
-		statusRaw, logRaw, unpushed, unmerged := m.getCachedDetails(wt)
+		statusRaw, submoduleRaw, logRaw, unpushed, unmerged := m.getCachedDetails(wt)
 
 		// Parse log
 		logEntries := []commitLogEntry{}
@@ -309,7 +340,7 @@ func (m *Model) updateDetailsView() tea.Cmd {
 		}
 		return statusUpdatedMsg{
 			info:        m.buildInfoContent(wt),
-			statusFiles: parseStatusFiles(statusRaw),
+			statusFiles: append(parseStatusFiles(statusRaw), parseUninitialisedSubmodules(submoduleRaw)...),
 			log:         logEntries,
 			path:        wt.Path,
 		}
@@ -137,9 +137,15 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 		Create:            m.showCreateWorktree,
 		Delete:            m.showDeleteWorktree,
 		Rename:            m.showRenameWorktree,
+		Move:              m.showMoveWorktree,
+		Adopt:             m.showAdoptWorktrees,
+		Lock:              m.showLockWorktree,
+		Unlock:            m.unlockWorktree,
+		Sparse:            m.showChangeSparseProfile,
 		Annotate:          m.showAnnotateWorktree,
 		Absorb:            m.showAbsorbWorktree,
 		Prune:             m.showPruneMerged,
+		Repair:            m.showRepairWorktrees,
 		CreateFromCurrent: m.showCreateFromCurrent,
 		CreateFromBranch: func() tea.Cmd {
 			defaultBase := m.state.services.git.GetMainBranch(m.ctx)
@@ -167,12 +173,18 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 	})
 
 	commands.RegisterGitOperations(registry, commands.GitHandlers{
-		ShowDiff:    m.showDiff,
-		Refresh:     m.refreshWorktrees,
-		Fetch:       m.fetchRemotes,
-		Push:        m.pushToUpstream,
-		Sync:        m.syncWithUpstream,
-		FetchPRData: m.fetchPRDataWithState,
+		ShowDiff:       m.showDiff,
+		ShowBaseDiff:   m.showBaseDiff,
+		Refresh:        m.refreshWorktrees,
+		Fetch:          m.fetchRemotes,
+		Push:           m.pushToUpstream,
+		Sync:           m.syncWithUpstream,
+		Stashes:        m.showStashes,
+		MoveChanges:    m.showMoveChanges,
+		Compare:        m.showCompareWorktrees,
+		Conflicts:      m.showConflicts,
+		ConflictMatrix: m.showConflictMatrix,
+		FetchPRData:    m.fetchPRDataWithState,
 		ViewCIChecks: func() tea.Cmd {
 			return m.openCICheckSelection()
 		},
@@ -195,6 +207,7 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 			}
 			return nil
 		},
+		StageHunks:   m.showStatusHunks,
 		CommitStaged: m.commitStagedChanges,
 		CommitAll:    m.commitAllChanges,
 		EditFile: func() tea.Cmd {
@@ -206,7 +219,8 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 			}
 			return nil
 		},
-		DeleteFile: m.showDeleteFile,
+		DeleteFile:       m.showDeleteFile,
+		UpdateSubmodules: m.updateSubmodules,
 	})
 
 	commands.RegisterLogPaneActions(registry, commands.LogHandlers{
@@ -414,6 +428,12 @@ func (m *Model) UpdateTheme(themeName string) {
 			scr.Thm = thm
 		case *appscreen.CommitFilesScreen:
 			scr.Thm = thm
+		case *appscreen.HunkScreen:
+			scr.Thm = thm
+		case *appscreen.ConflictScreen:
+			scr.Thm = thm
+		case *appscreen.ConflictMatrixScreen:
+			scr.Thm = thm
 		case *appscreen.LoadingScreen:
 			scr.SetTheme(thm)
 		}
@@ -506,11 +526,24 @@ func (m *Model) showCherryPick() tea.Cmd {
 	sourceWorktree := m.state.data.filteredWts[m.state.data.selectedIndex]
 	selectedCommit := m.state.data.logEntries[cursor]
 
-	// Build worktree selection items (exclude source worktree)
-	items := make([]selectionItem, 0, len(m.state.data.worktrees)-1)
+	return m.showWorktreePicker(
+		fmt.Sprintf("Cherry-pick %s to worktree", selectedCommit.sha),
+		"No other worktrees available for cherry-pick.",
+		sourceWorktree.Path,
+		"",
+		func(targetWorktree *models.WorktreeInfo) tea.Cmd {
+			return m.executeCherryPick(selectedCommit.sha, targetWorktree)
+		},
+	)
+}
+
+// showWorktreePicker lets the user pick a worktree, other than the one at
+// excludePath, and calls onPick with it. The cursor starts on initialPath.
+func (m *Model) showWorktreePicker(title, emptyMessage, excludePath, initialPath string, onPick func(*models.WorktreeInfo) tea.Cmd) tea.Cmd {
+	items := make([]appscreen.SelectionItem, 0, len(m.state.data.worktrees))
 	for _, wt := range m.state.data.worktrees {
-		if wt.Path == sourceWorktree.Path {
-			continue // Skip source worktree
+		if wt.Path == excludePath {
+			continue
 		}
 
 		name := filepath.Base(wt.Path)
@@ -523,36 +556,26 @@ func (m *Model) showCherryPick() tea.Cmd {
 			desc += " (has changes)"
 		}
 
-		items = append(items, selectionItem{
-			id:          wt.Path,
-			label:       name,
-			description: desc,
+		items = append(items, appscreen.SelectionItem{
+			ID:          wt.Path,
+			Label:       name,
+			Description: desc,
 		})
 	}
 
 	if len(items) == 0 {
-		m.showInfo("No other worktrees available for cherry-pick.", nil)
+		m.showInfo(emptyMessage, nil)
 		return nil
 	}
 
-	screenItems := make([]appscreen.SelectionItem, len(items))
-	for i, item := range items {
-		screenItems[i] = appscreen.SelectionItem{
-			ID:          item.id,
-			Label:       item.label,
-			Description: item.description,
-		}
-	}
-
-	title := fmt.Sprintf("Cherry-pick %s to worktree", selectedCommit.sha)
 	listScreen := appscreen.NewListSelectionScreen(
-		screenItems,
+		items,
 		title,
 		filterWorktreesPlaceholder,
 		"No worktrees found.",
 		m.state.view.WindowWidth,
 		m.state.view.WindowHeight,
-		"",
+		initialPath,
 		m.theme,
 	)
 
@@ -571,7 +594,7 @@ func (m *Model) showCherryPick() tea.Cmd {
 			}
 		}
 
-		return m.executeCherryPick(selectedCommit.sha, targetWorktree)
+		return onPick(targetWorktree)
 	}
 
 	listScreen.OnCancel = func() tea.Cmd {
@@ -363,8 +363,8 @@ func TestShowCommandPaletteHasAllActions(t *testing.T) {
 		"create", "delete", "rename", "annotate", "absorb", "prune",
 		"create-from-current", "create-from-branch", "create-from-commit",
 		"create-from-pr", "create-from-issue", "create-freeform",
-		"diff", "refresh", "fetch", "push", "sync", "fetch-pr-data", "pr", "lazygit", "run-command",
-		"stage-file", "commit-staged", "commit-all", "edit-file", "delete-file",
+		"diff", "diff-base", "refresh", "fetch", "push", "sync", "stashes", "move-changes", "compare", "conflicts", "conflict-matrix", "fetch-pr-data", "pr", "lazygit", "run-command",
+		"stage-file", "stage-hunks", "commit-staged", "commit-all", "edit-file", "delete-file", "update-submodules",
 		"cherry-pick", "commit-view",
 		"zoom-toggle", "filter", "search", "focus-worktrees", "focus-status", "focus-log", "sort-cycle",
 		"theme", "taskboard", "help",
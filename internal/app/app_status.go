@@ -55,7 +55,7 @@ func parseStatusFiles(statusRaw string) []StatusFile {
 			continue
 		}
 
-		var status, filename string
+		var status, filename, sub string
 		var isUntracked bool
 
 		switch fields[0] {
@@ -64,6 +64,7 @@ func parseStatusFiles(statusRaw string) []StatusFile {
 				continue
 			}
 			status = fields[1] // XY status code (e.g., ".M", "M.", "MM")
+			sub = fields[2]
 			filename = fields[8]
 		case "?": // Untracked: ? <path>
 			status = " ?" // Single ? with space for alignment
@@ -74,21 +75,67 @@ func parseStatusFiles(statusRaw string) []StatusFile {
 				continue
 			}
 			status = fields[1]
+			sub = fields[2]
 			filename = fields[9]
 		default:
 			continue // Skip unhandled entry types
 		}
 
+		isSubmodule := strings.HasPrefix(sub, "S")
 		parsedFiles = append(parsedFiles, StatusFile{
-			Filename:    filename,
-			Status:      status,
-			IsUntracked: isUntracked,
+			Filename:       filename,
+			Status:         status,
+			IsUntracked:    isUntracked,
+			IsSubmodule:    isSubmodule,
+			SubmoduleState: submoduleState(sub),
 		})
 	}
 
 	return parsedFiles
 }
 
+// submoduleState describes the <sub> field of a porcelain v2 entry, such as
+// "SC.U", or returns an empty string when nothing changed inside it.
+func submoduleState(sub string) string {
+	if len(sub) != 4 || sub[0] != 'S' {
+		return ""
+	}
+	var states []string
+	if sub[1] == 'C' {
+		states = append(states, "new commits")
+	}
+	if sub[2] == 'M' {
+		states = append(states, "modified content")
+	}
+	if sub[3] == 'U' {
+		states = append(states, "untracked content")
+	}
+	return strings.Join(states, ", ")
+}
+
+// parseUninitialisedSubmodules lists the submodules that git submodule status
+// reports as not initialised, which git status leaves out.
+func parseUninitialisedSubmodules(submoduleRaw string) []StatusFile {
+	var files []StatusFile
+	for line := range strings.SplitSeq(submoduleRaw, "\n") {
+		rest, ok := strings.CutPrefix(line, "-")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			continue
+		}
+		files = append(files, StatusFile{
+			Filename:       fields[1],
+			Status:         "  ",
+			IsSubmodule:    true,
+			SubmoduleState: "uninitialised",
+		})
+	}
+	return files
+}
+
 func statusCounts(files []StatusFile) (staged, modified, untracked int) {
 	for _, file := range files {
 		if file.IsUntracked {
@@ -203,10 +250,7 @@ func (m *Model) applyLogFilter(reset bool) {
 	m.state.data.logEntries = filtered
 	rows := make([]table.Row, 0, len(filtered))
 	for _, entry := range filtered {
-		sha := entry.sha
-		if len(sha) > 7 {
-			sha = sha[:7]
-		}
+		sha := shortSHA(entry.sha)
 		msg := formatCommitMessage(entry.message)
 		initials := authorInitials(entry.authorInitials)
 		if entry.isUnpushed || entry.isUnmerged {
@@ -266,20 +310,34 @@ func (m *Model) resetDetailsCache() {
 	m.cache.detailsCache = make(map[string]*detailsCacheEntry)
 }
 
-func (m *Model) getCachedDetails(wt *models.WorktreeInfo) (string, string, map[string]bool, map[string]bool) {
-	if wt == nil || strings.TrimSpace(wt.Path) == "" {
-		return "", "", nil, nil
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func (m *Model) getCachedDetails(wt *models.WorktreeInfo) (string, string, string, map[string]bool, map[string]bool) {
+	// A prunable worktree has no directory to run git in.
+	if wt == nil || strings.TrimSpace(wt.Path) == "" || wt.Prunable {
+		return "", "", "", nil, nil
 	}
 
 	cacheKey := wt.Path
 	if cached, ok := m.getDetailsCache(cacheKey); ok {
 		if time.Since(cached.fetchedAt) < detailsCacheTTL {
-			return cached.statusRaw, cached.logRaw, cached.unpushedSHAs, cached.unmergedSHAs
+			return cached.statusRaw, cached.submoduleRaw, cached.logRaw, cached.unpushedSHAs, cached.unmergedSHAs
 		}
 	}
 
 	// Get status (using porcelain format for reliable machine parsing)
 	statusRaw := m.state.services.git.RunGit(m.ctx, []string{"git", "status", "--porcelain=v2"}, wt.Path, []int{0}, true, false)
+	// git status does not list uninitialised submodules
+	submoduleRaw := ""
+	if services.HasSubmodules(wt.Path) {
+		submoduleRaw = m.state.services.git.RunGit(m.ctx, []string{"git", "submodule", "status"}, wt.Path, []int{0}, false, true)
+	}
 	// Use %H for full SHA to ensure reliable matching
 	logRaw := m.state.services.git.RunGit(m.ctx, []string{"git", "log", "-50", "--pretty=format:%H%x09%an%x09%s"}, wt.Path, []int{0}, true, false)
 
@@ -292,11 +350,11 @@ func (m *Model) getCachedDetails(wt *models.WorktreeInfo) (string, string, map[s
 		}
 	}
 
-	// Get unmerged SHAs (commits not in main branch)
-	mainBranch := m.state.services.git.GetMainBranch(m.ctx)
+	// Get unmerged SHAs (commits not in the base branch)
+	baseBranch := services.BaseBranch(m.ctx, m.state.services.git, wt)
 	unmergedSHAs := make(map[string]bool)
-	if mainBranch != "" {
-		unmergedRaw := m.state.services.git.RunGit(m.ctx, []string{"git", "rev-list", "-100", "HEAD", "^" + mainBranch}, wt.Path, []int{0}, true, false)
+	if baseBranch != "" {
+		unmergedRaw := m.state.services.git.RunGit(m.ctx, []string{"git", "rev-list", "-100", "HEAD", "^" + baseBranch}, wt.Path, []int{0}, true, false)
 		for sha := range strings.SplitSeq(unmergedRaw, "\n") {
 			if s := strings.TrimSpace(sha); s != "" {
 				unmergedSHAs[s] = true
@@ -306,11 +364,12 @@ func (m *Model) getCachedDetails(wt *models.WorktreeInfo) (string, string, map[s
 
 	m.setDetailsCache(cacheKey, &detailsCacheEntry{
 		statusRaw:    statusRaw,
+		submoduleRaw: submoduleRaw,
 		logRaw:       logRaw,
 		unpushedSHAs: unpushedSHAs,
 		unmergedSHAs: unmergedSHAs,
 		fetchedAt:    time.Now(),
 	})
 
-	return statusRaw, logRaw, unpushedSHAs, unmergedSHAs
+	return statusRaw, submoduleRaw, logRaw, unpushedSHAs, unmergedSHAs
 }
@@ -147,3 +147,45 @@ func TestTruncateToHeightFromEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStatusFilesSubmodules(t *testing.T) {
+	t.Parallel()
+	statusRaw := `1 .M SC.. 160000 160000 160000 abc123 abc123 libs/core
+1 .M S.MU 160000 160000 160000 def456 def456 libs/ui
+1 .M N... 100644 100644 100644 ghi789 ghi789 main.go`
+
+	files := parseStatusFiles(statusRaw)
+	if len(files) != 3 {
+		t.Fatalf("expected 3 status files, got %d", len(files))
+	}
+	if !files[0].IsSubmodule || files[0].SubmoduleState != "new commits" {
+		t.Fatalf("unexpected submodule state for libs/core: %+v", files[0])
+	}
+	if !files[1].IsSubmodule || files[1].SubmoduleState != "modified content, untracked content" {
+		t.Fatalf("unexpected submodule state for libs/ui: %+v", files[1])
+	}
+	if files[2].IsSubmodule || files[2].SubmoduleState != "" {
+		t.Fatalf("expected main.go not to be a submodule: %+v", files[2])
+	}
+}
+
+func TestParseUninitialisedSubmodules(t *testing.T) {
+	t.Parallel()
+	submoduleRaw := ` abc1234 libs/core (heads/main)
+-def5678 libs/docs
++0123456 libs/ui (v1.2.0)`
+
+	files := parseUninitialisedSubmodules(submoduleRaw)
+	if len(files) != 1 {
+		t.Fatalf("expected 1 uninitialised submodule, got %+v", files)
+	}
+	if files[0].Filename != "libs/docs" || !files[0].IsSubmodule || files[0].SubmoduleState != "uninitialised" {
+		t.Fatalf("unexpected uninitialised submodule: %+v", files[0])
+	}
+	if staged, modified, untracked := statusCounts(files); staged+modified+untracked != 0 {
+		t.Fatalf("expected uninitialised submodules not to count as changes, got %d/%d/%d", staged, modified, untracked)
+	}
+	if label := submoduleStatusLabel(files[0].SubmoduleState); !strings.Contains(label, "uninitialised") {
+		t.Fatalf("expected label to mention uninitialised, got %q", label)
+	}
+}
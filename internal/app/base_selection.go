@@ -354,22 +354,25 @@ func (m *Model) showBranchNameInput(baseRef, defaultName string) tea.Cmd {
 			return nil
 		}
 
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), newBranch)
+		targetPath := m.worktreeLayout().Path(newBranch, newBranch)
 		if errMsg := m.validateNewWorktreeTarget(newBranch, targetPath); errMsg != "" {
 			inputScr.ErrorMsg = errMsg
 			return nil
 		}
 
-		// Show loading screen immediately
-		if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
-			return func() tea.Msg { return errMsg{err: err} }
-		}
-		m.loading = true
-		m.statusContent = fmt.Sprintf("Creating worktree from %s...", baseRef)
-		m.state.ui.screenManager.Clear()
-		m.setLoadingScreen(m.statusContent)
+		inputScr.ErrorMsg = ""
+		return m.withSparseProfile(func(sparseDirs []string) tea.Cmd {
+			// Show loading screen immediately
+			if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
+				return func() tea.Msg { return errMsg{err: err} }
+			}
+			m.loading = true
+			m.statusContent = fmt.Sprintf("Creating worktree from %s...", baseRef)
+			m.state.ui.screenManager.Clear()
+			m.setLoadingScreen(m.statusContent)
 
-		return m.createWorktreeFromBaseAsync(newBranch, targetPath, baseRef)
+			return m.createWorktreeFromBaseAsync(newBranch, targetPath, baseRef, sparseDirs)
+		})
 	}
 
 	inputScr.OnCancel = func() tea.Cmd {
@@ -558,22 +561,25 @@ func (m *Model) showWorktreeNameForExistingBranch(branchName string) tea.Cmd {
 			return nil
 		}
 
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), worktreeName)
+		targetPath := m.worktreeLayout().Path(worktreeName, branchName)
 		if errMsg := m.validateNewWorktreeTarget(worktreeName, targetPath); errMsg != "" {
 			inputScr.ErrorMsg = errMsg
 			return nil
 		}
 
-		// Show loading screen immediately
-		if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
-			return func() tea.Msg { return errMsg{err: err} }
-		}
-		m.loading = true
-		m.statusContent = fmt.Sprintf("Checking out %s...", branchName)
-		m.state.ui.screenManager.Clear()
-		m.setLoadingScreen(m.statusContent)
+		inputScr.ErrorMsg = ""
+		return m.withSparseProfile(func(sparseDirs []string) tea.Cmd {
+			// Show loading screen immediately
+			if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
+				return func() tea.Msg { return errMsg{err: err} }
+			}
+			m.loading = true
+			m.statusContent = fmt.Sprintf("Checking out %s...", branchName)
+			m.state.ui.screenManager.Clear()
+			m.setLoadingScreen(m.statusContent)
 
-		return m.checkoutExistingBranchAsync(worktreeName, targetPath, branchName)
+			return m.checkoutExistingBranchAsync(worktreeName, targetPath, branchName, sparseDirs)
+		})
 	}
 
 	inputScr.OnCancel = func() tea.Cmd {
@@ -585,11 +591,16 @@ func (m *Model) showWorktreeNameForExistingBranch(branchName string) tea.Cmd {
 }
 
 // checkoutExistingBranchAsync creates a worktree for an existing local branch
-// without creating a new branch (no -b flag).
-func (m *Model) checkoutExistingBranchAsync(worktreeName, targetPath, branchName string) tea.Cmd {
+// without creating a new branch (no -b flag). Non-empty sparseDirs restrict
+// the checkout to those directories.
+func (m *Model) checkoutExistingBranchAsync(worktreeName, targetPath, branchName string, sparseDirs []string) tea.Cmd {
 	return func() tea.Msg {
 		// Key difference: no "-b" flag when checking out existing branch
-		args := []string{"git", "worktree", "add", targetPath, branchName}
+		args := []string{"git", "worktree", "add"}
+		if len(sparseDirs) > 0 {
+			args = append(args, "--no-checkout")
+		}
+		args = append(args, targetPath, branchName)
 
 		ok := m.state.services.git.RunCommandChecked(
 			m.ctx,
@@ -600,6 +611,10 @@ func (m *Model) checkoutExistingBranchAsync(worktreeName, targetPath, branchName
 		if !ok {
 			return errMsg{err: fmt.Errorf("failed to checkout branch %s", branchName)}
 		}
+		if err := m.checkoutSparse(targetPath, sparseDirs); err != nil {
+			return errMsg{err: err}
+		}
+		m.updateNewWorktreeSubmodules(targetPath)
 
 		m.pendingSelectWorktreePath = targetPath
 
@@ -631,13 +646,17 @@ func sanitizeBranchNameFromTitle(title, fallback string) string {
 }
 
 // createWorktreeFromBaseAsync performs the actual async worktree creation.
-// The LoadingScreen should be set up before calling this.
-func (m *Model) createWorktreeFromBaseAsync(newBranch, targetPath, baseRef string) tea.Cmd {
+// The LoadingScreen should be set up before calling this. Non-empty
+// sparseDirs restrict the checkout to those directories.
+func (m *Model) createWorktreeFromBaseAsync(newBranch, targetPath, baseRef string, sparseDirs []string) tea.Cmd {
 	return func() tea.Msg {
 		args := []string{"git", "worktree", "add", "-b", newBranch}
 		if strings.Contains(baseRef, "/") {
 			args = append(args, "--track")
 		}
+		if len(sparseDirs) > 0 {
+			args = append(args, "--no-checkout")
+		}
 		args = append(args, targetPath, baseRef)
 
 		ok := m.state.services.git.RunCommandChecked(
@@ -649,6 +668,11 @@ func (m *Model) createWorktreeFromBaseAsync(newBranch, targetPath, baseRef strin
 		if !ok {
 			return errMsg{err: fmt.Errorf("failed to create worktree %s", newBranch)}
 		}
+		if err := m.checkoutSparse(targetPath, sparseDirs); err != nil {
+			return errMsg{err: err}
+		}
+		m.updateNewWorktreeSubmodules(targetPath)
+		m.recordBaseRef(targetPath, newBranch, baseRef)
 
 		m.pendingSelectWorktreePath = targetPath
 
@@ -684,7 +708,7 @@ func (m *Model) createWorktreeFromBaseAsync(newBranch, targetPath, baseRef strin
 
 // createWorktreeFromBase is kept for backward compatibility (e.g., custom create menus)
 func (m *Model) createWorktreeFromBase(newBranch, targetPath, baseRef string) tea.Cmd {
-	if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
+	if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
 		return func() tea.Msg { return errMsg{err: err} }
 	}
 
@@ -693,7 +717,7 @@ func (m *Model) createWorktreeFromBase(newBranch, targetPath, baseRef string) te
 	m.statusContent = fmt.Sprintf("Creating worktree from %s...", baseRef)
 	m.setLoadingScreen(m.statusContent)
 
-	return m.createWorktreeFromBaseAsync(newBranch, targetPath, baseRef)
+	return m.createWorktreeFromBaseAsync(newBranch, targetPath, baseRef, nil)
 }
 
 func (m *Model) clearListSelection() {
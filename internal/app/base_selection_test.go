@@ -1304,7 +1304,7 @@ func TestCheckoutExistingBranchAsync(t *testing.T) {
 	worktreeName := "feature-wt"
 	targetPath := filepath.Join(worktreeDir, worktreeName)
 
-	cmd := m.checkoutExistingBranchAsync(worktreeName, targetPath, featureBranch)
+	cmd := m.checkoutExistingBranchAsync(worktreeName, targetPath, featureBranch, nil)
 	if cmd == nil {
 		t.Fatal("expected command to be returned")
 	}
@@ -1337,6 +1337,72 @@ func TestCheckoutExistingBranchAsync(t *testing.T) {
 	}
 }
 
+func TestCreateWorktreeFromBaseAsyncSparse(t *testing.T) {
+	repo := initTestRepo(t)
+	withCwd(t, repo.dir)
+	for _, dir := range []string{"web", "api"} {
+		if err := os.MkdirAll(filepath.Join(repo.dir, dir), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repo.dir, dir, "main.txt"), []byte(dir), 0o600); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	runGit(t, repo.dir, "add", ".")
+	runGit(t, repo.dir, "commit", "-m", "Add web and api")
+
+	worktreeDir := t.TempDir()
+	cfg := &config.AppConfig{WorktreeDir: worktreeDir}
+	m := NewModel(cfg, "")
+	targetPath := filepath.Join(worktreeDir, "sparse")
+
+	msg := m.createWorktreeFromBaseAsync("sparse", targetPath, repo.branch, []string{"web"})()
+	loaded, ok := msg.(worktreesLoadedMsg)
+	if !ok {
+		t.Fatalf("expected worktreesLoadedMsg, got %T", msg)
+	}
+	if loaded.err != nil {
+		t.Fatalf("unexpected error: %v", loaded.err)
+	}
+
+	if _, err := os.Stat(filepath.Join(targetPath, "web", "main.txt")); err != nil {
+		t.Fatalf("expected web to be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(targetPath, "api")); !os.IsNotExist(err) {
+		t.Fatalf("expected api to be left out, got %v", err)
+	}
+	for _, wt := range loaded.worktrees {
+		if filepath.Base(wt.Path) == "sparse" {
+			if !wt.Sparse || strings.Join(wt.SparseDirs, ",") != "web" {
+				t.Fatalf("expected sparse worktree with web, got %v %q", wt.Sparse, wt.SparseDirs)
+			}
+			return
+		}
+	}
+	t.Fatal("expected the new worktree to be listed")
+}
+
+func TestShowBranchNameInputAsksSparseProfile(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir:    t.TempDir(),
+		SparseProfiles: map[string][]string{"web": {"web"}},
+	}
+	m := NewModel(cfg, "")
+	m.repoConfigPath = filepath.Join(t.TempDir(), ".wt")
+
+	m.showBranchNameInput("main", "")
+	inputScr := m.state.ui.screenManager.Current().(*appscreen.InputScreen)
+	inputScr.OnSubmit("feature-sparse", false)
+
+	if m.state.ui.screenManager.Type() != appscreen.TypeListSelect {
+		t.Fatalf("expected the profile list, got %v", m.state.ui.screenManager.Type())
+	}
+	listScreen := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if len(listScreen.Items) != 2 || listScreen.Items[1].ID != "web" {
+		t.Fatalf("expected full checkout and web, got %+v", listScreen.Items)
+	}
+}
+
 func TestBranchSelectionWithLocalBranch(t *testing.T) {
 	repo := initTestRepo(t)
 	withCwd(t, repo.dir)
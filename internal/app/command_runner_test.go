@@ -502,6 +502,107 @@ func TestShowCommitDiffCommandModeUsesOwnDiff(t *testing.T) {
 	}
 }
 
+func TestShowStashDiffUsesStashShow(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir:         t.TempDir(),
+		GitPager:            "tig",
+		GitPagerInteractive: true,
+	}
+	m := NewModel(cfg, "")
+	wt := &models.WorktreeInfo{Path: testWorktreePath, Branch: "feat"}
+
+	capture := &commandCapture{}
+	m.commandRunner = capture.runner
+	m.execProcess = capture.exec
+
+	if cmd := m.showStashDiff("abc123", wt); cmd == nil {
+		t.Fatal("expected diff command")
+	}
+	if len(capture.args) != 2 || capture.args[0] != "-c" {
+		t.Fatalf("expected bash -c args, got %v", capture.args)
+	}
+	if cmdStr := capture.args[1]; !strings.Contains(cmdStr, "git stash show --patch --no-color abc123 | tig") {
+		t.Fatalf("expected git stash show piped to the pager, got %q", cmdStr)
+	}
+
+	cfg.GitPagerInteractive = false
+	cfg.GitPager = "lumen"
+	cfg.GitPagerCommandMode = true
+	if cmd := m.showStashDiff("abc123", wt); cmd == nil {
+		t.Fatal("expected diff command")
+	}
+	if cmdStr := capture.args[1]; !strings.Contains(cmdStr, "lumen diff abc123^..abc123") {
+		t.Fatalf("expected lumen diff with the stash range, got %q", cmdStr)
+	}
+	if capture.dir != testWorktreePath {
+		t.Fatalf("expected worktree dir, got %q", capture.dir)
+	}
+}
+
+func TestShowBaseDiffUsesMergeBase(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir:         t.TempDir(),
+		GitPager:            "tig",
+		GitPagerInteractive: true,
+	}
+	m := NewModel(cfg, "")
+	m.state.data.filteredWts = []*models.WorktreeInfo{{
+		Path:   testWorktreePath,
+		Branch: "feat",
+		PR:     &models.PRInfo{BaseBranch: "develop"},
+	}}
+	m.state.data.selectedIndex = 0
+
+	capture := &commandCapture{}
+	m.commandRunner = capture.runner
+	m.execProcess = capture.exec
+
+	if cmd := m.showBaseDiff(); cmd == nil {
+		t.Fatal("expected diff command")
+	}
+	if len(capture.args) != 2 || capture.args[0] != "-c" {
+		t.Fatalf("expected bash -c args, got %v", capture.args)
+	}
+	cmdStr := capture.args[1]
+	if !strings.HasPrefix(cmdStr, "mb=$(git merge-base 'develop' HEAD) || exit 1; ") {
+		t.Fatalf("expected the merge-base with the PR base first, got %q", cmdStr)
+	}
+	if !strings.Contains(cmdStr, `git diff --patch --no-color "$mb" | tig`) {
+		t.Fatalf("expected git diff from the merge-base piped to the pager, got %q", cmdStr)
+	}
+
+	cfg.GitPagerInteractive = false
+	cfg.GitPager = "lumen"
+	cfg.GitPagerCommandMode = true
+	if cmd := m.showBaseDiff(); cmd == nil {
+		t.Fatal("expected diff command")
+	}
+	if cmdStr := capture.args[1]; !strings.Contains(cmdStr, `lumen diff "$mb"`) {
+		t.Fatalf("expected lumen diff from the merge-base, got %q", cmdStr)
+	}
+	if capture.dir != testWorktreePath {
+		t.Fatalf("expected worktree dir, got %q", capture.dir)
+	}
+}
+
+func TestShowBaseDiffOnBaseBranch(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.filteredWts = []*models.WorktreeInfo{{
+		Path:   testWorktreePath,
+		Branch: "develop",
+		PR:     &models.PRInfo{BaseBranch: "develop"},
+	}}
+	m.state.data.selectedIndex = 0
+
+	if cmd := m.showBaseDiff(); cmd != nil {
+		t.Fatal("expected no diff command on the base branch itself")
+	}
+	if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+		t.Fatalf("expected info screen, got %v", m.state.ui.screenManager.Type())
+	}
+}
+
 func TestShowFileDiffCommandModeUsesFileFlag(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir:         t.TempDir(),
@@ -70,9 +70,15 @@ type WorktreeHandlers struct {
 	Create            func() tea.Cmd
 	Delete            func() tea.Cmd
 	Rename            func() tea.Cmd
+	Move              func() tea.Cmd
+	Adopt             func() tea.Cmd
+	Lock              func() tea.Cmd
+	Unlock            func() tea.Cmd
+	Sparse            func() tea.Cmd
 	Annotate          func() tea.Cmd
 	Absorb            func() tea.Cmd
 	Prune             func() tea.Cmd
+	Repair            func() tea.Cmd
 	CreateFromCurrent func() tea.Cmd
 	CreateFromBranch  func() tea.Cmd
 	CreateFromCommit  func() tea.Cmd
@@ -101,9 +107,15 @@ func RegisterWorktreeActions(r *Registry, h WorktreeHandlers) {
 		CommandAction{ID: "create", Label: "Create worktree", Description: "Add a new worktree from base branch or PR/MR", Section: sectionWorktreeActions, Shortcut: "c", Icon: IconWorktree, Handler: h.Create},
 		CommandAction{ID: "delete", Label: "Delete worktree", Description: "Remove worktree and branch", Section: sectionWorktreeActions, Shortcut: "D", Icon: IconWorktree, Handler: h.Delete},
 		CommandAction{ID: "rename", Label: "Rename worktree", Description: "Rename worktree (and branch when names match)", Section: sectionWorktreeActions, Shortcut: "m", Icon: IconWorktree, Handler: h.Rename},
+		CommandAction{ID: "move", Label: "Move worktree", Description: "Move worktree to another directory, keeping notes and sessions", Section: sectionWorktreeActions, Icon: IconWorktree, Handler: h.Move},
+		CommandAction{ID: "adopt", Label: "Adopt outside worktrees", Description: "Move worktrees created outside the worktree directory into it", Section: sectionWorktreeActions, Icon: IconWorktree, Handler: h.Adopt},
+		CommandAction{ID: "lock", Label: "Lock worktree", Description: "Protect worktree from prune and delete, with a reason", Section: sectionWorktreeActions, Icon: IconWorktree, Handler: h.Lock},
+		CommandAction{ID: "unlock", Label: "Unlock worktree", Description: "Remove the lock from the selected worktree", Section: sectionWorktreeActions, Icon: IconWorktree, Handler: h.Unlock},
+		CommandAction{ID: "sparse", Label: "Change sparse-checkout profile", Description: "Check out only the directories of a profile, or every file", Section: sectionWorktreeActions, Icon: IconWorktree, Handler: h.Sparse},
 		CommandAction{ID: "annotate", Label: "Worktree notes", Description: "View or edit notes for the selected worktree", Section: sectionWorktreeActions, Shortcut: "i", Icon: IconWorktree, Handler: h.Annotate},
 		CommandAction{ID: "absorb", Label: "Absorb worktree", Description: "Merge branch into main and remove worktree", Section: sectionWorktreeActions, Shortcut: "A", Icon: IconWorktree, Handler: h.Absorb},
 		CommandAction{ID: "prune", Label: "Prune merged", Description: "Remove merged PR worktrees", Section: sectionWorktreeActions, Shortcut: "X", Icon: IconWorktree, Handler: h.Prune},
+		CommandAction{ID: "repair", Label: "Repair worktrees", Description: "Fix orphaned directories, broken gitdir links, prunable entries and dangling symlinks", Section: sectionWorktreeActions, Icon: IconWorktree, Handler: h.Repair},
 	)
 
 	r.Register(
@@ -119,10 +131,16 @@ func RegisterWorktreeActions(r *Registry, h WorktreeHandlers) {
 // GitHandlers holds callbacks for git operations.
 type GitHandlers struct {
 	ShowDiff          func() tea.Cmd
+	ShowBaseDiff      func() tea.Cmd
 	Refresh           func() tea.Cmd
 	Fetch             func() tea.Cmd
 	Push              func() tea.Cmd
 	Sync              func() tea.Cmd
+	Stashes           func() tea.Cmd
+	MoveChanges       func() tea.Cmd
+	Compare           func() tea.Cmd
+	Conflicts         func() tea.Cmd
+	ConflictMatrix    func() tea.Cmd
 	FetchPRData       func() tea.Cmd
 	ViewCIChecks      func() tea.Cmd
 	CIChecksAvailable func() bool
@@ -135,10 +153,16 @@ type GitHandlers struct {
 func RegisterGitOperations(r *Registry, h GitHandlers) {
 	r.Register(
 		CommandAction{ID: "diff", Label: "Show diff", Description: "Show diff for current worktree or commit", Section: sectionGitOperations, Shortcut: "d", Icon: IconGit, Handler: h.ShowDiff},
+		CommandAction{ID: "diff-base", Label: "Show diff against base", Description: "Show branch commits and uncommitted changes against the base branch", Section: sectionGitOperations, Shortcut: "B", Icon: IconGit, Handler: h.ShowBaseDiff},
 		CommandAction{ID: "refresh", Label: "Refresh", Description: "Reload worktrees", Section: sectionGitOperations, Shortcut: "r", Icon: IconGit, Handler: h.Refresh},
 		CommandAction{ID: "fetch", Label: "Fetch remotes", Description: "git fetch --all", Section: sectionGitOperations, Shortcut: "R", Icon: IconGit, Handler: h.Fetch},
 		CommandAction{ID: "push", Label: "Push to upstream", Description: "git push (clean worktree only)", Section: sectionGitOperations, Shortcut: "P", Icon: IconGit, Handler: h.Push},
 		CommandAction{ID: "sync", Label: "Synchronise with upstream", Description: "git pull, then git push (clean worktree only)", Section: sectionGitOperations, Shortcut: "S", Icon: IconGit, Handler: h.Sync},
+		CommandAction{ID: "stashes", Label: "Stashes", Description: "Show, apply, pop or drop the stashes shared by all worktrees", Section: sectionGitOperations, Icon: IconGit, Handler: h.Stashes},
+		CommandAction{ID: "move-changes", Label: "Move changes to worktree", Description: "Stash changes here and apply them in another worktree", Section: sectionGitOperations, Icon: IconGit, Handler: h.MoveChanges},
+		CommandAction{ID: "compare", Label: "Compare with worktree", Description: "Show commits and files that differ from another worktree", Section: sectionGitOperations, Icon: IconGit, Handler: h.Compare},
+		CommandAction{ID: "conflicts", Label: "Resolve conflicts", Description: "Resolve, continue, skip or abort a stopped rebase, merge or cherry-pick", Section: sectionGitOperations, Icon: IconGit, Handler: h.Conflicts},
+		CommandAction{ID: "conflict-matrix", Label: "Conflict matrix", Description: "Check every pair of worktrees for conflicting or overlapping changes", Section: sectionGitOperations, Icon: IconGit, Handler: h.ConflictMatrix},
 		CommandAction{ID: "fetch-pr-data", Label: "Fetch PR data", Description: "Fetch PR/MR status from GitHub/GitLab", Section: sectionGitOperations, Shortcut: "p", Icon: IconGit, Handler: h.FetchPRData},
 		CommandAction{ID: "ci-checks", Label: "View CI checks", Description: "View CI check logs for current worktree", Section: sectionGitOperations, Shortcut: "v", Icon: IconGit, Handler: h.ViewCIChecks, Available: h.CIChecksAvailable},
 		CommandAction{ID: "pr", Label: "Open PR", Description: "Open PR in browser", Section: sectionGitOperations, Shortcut: "o", Icon: IconGit, Handler: h.OpenPR},
@@ -149,21 +173,25 @@ func RegisterGitOperations(r *Registry, h GitHandlers) {
 
 // StatusHandlers holds callbacks for status pane actions.
 type StatusHandlers struct {
-	StageFile    func() tea.Cmd
-	CommitStaged func() tea.Cmd
-	CommitAll    func() tea.Cmd
-	EditFile     func() tea.Cmd
-	DeleteFile   func() tea.Cmd
+	StageFile        func() tea.Cmd
+	StageHunks       func() tea.Cmd
+	CommitStaged     func() tea.Cmd
+	CommitAll        func() tea.Cmd
+	EditFile         func() tea.Cmd
+	DeleteFile       func() tea.Cmd
+	UpdateSubmodules func() tea.Cmd
 }
 
 // RegisterStatusPaneActions registers status pane actions.
 func RegisterStatusPaneActions(r *Registry, h StatusHandlers) {
 	r.Register(
 		CommandAction{ID: "stage-file", Label: "Stage/unstage file", Description: "Stage or unstage selected file", Section: sectionStatusPane, Shortcut: "s", Icon: IconStatus, Handler: h.StageFile},
+		CommandAction{ID: "stage-hunks", Label: "Stage hunks", Description: "Stage, unstage or discard hunks and lines of selected file", Section: sectionStatusPane, Shortcut: "a", Icon: IconStatus, Handler: h.StageHunks},
 		CommandAction{ID: "commit-staged", Label: "Commit staged", Description: "Commit staged changes", Section: sectionStatusPane, Shortcut: "c", Icon: IconStatus, Handler: h.CommitStaged},
 		CommandAction{ID: "commit-all", Label: "Stage all and commit", Description: "Stage all changes and commit", Section: sectionStatusPane, Shortcut: "C", Icon: IconStatus, Handler: h.CommitAll},
 		CommandAction{ID: "edit-file", Label: "Edit file", Description: "Open selected file in editor", Section: sectionStatusPane, Shortcut: "e", Icon: IconStatus, Handler: h.EditFile},
 		CommandAction{ID: "delete-file", Label: "Delete selected file or directory", Section: sectionStatusPane, Icon: IconStatus, Handler: h.DeleteFile},
+		CommandAction{ID: "update-submodules", Label: "Update submodules", Description: "git submodule update --init --recursive", Section: sectionStatusPane, Icon: IconStatus, Handler: h.UpdateSubmodules},
 	)
 }
 
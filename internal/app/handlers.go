@@ -489,6 +489,9 @@ func (m *Model) handleBuiltInKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.showDeleteWorktree()
 
+	case "B":
+		return m, m.showBaseDiff()
+
 	case "d":
 		// If in log pane (bottom right), show commit diff
 		if m.state.view.FocusedPane == 2 {
@@ -566,6 +569,13 @@ func (m *Model) handleBuiltInKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "N":
 		return m, m.advanceSearchMatch(false)
 
+	case "a":
+		// In status pane: stage/unstage hunks of selected file
+		if m.state.view.FocusedPane == 1 {
+			return m, m.showStatusHunks()
+		}
+		return m, nil
+
 	case "s":
 		// In status pane: stage/unstage selected file or directory
 		if m.state.view.FocusedPane == 1 && len(m.state.services.statusTree.TreeFlat) > 0 && m.state.services.statusTree.Index >= 0 && m.state.services.statusTree.Index < len(m.state.services.statusTree.TreeFlat) {
@@ -56,6 +56,29 @@ type CommitFileDiffParams struct {
 	WorktreePath string
 }
 
+// StashDiffParams collects dependencies for a stash diff.
+type StashDiffParams struct {
+	StashSHA        string
+	Worktree        *models.WorktreeInfo
+	BuildCommandEnv func(branch, wtPath string) map[string]string
+}
+
+// BaseDiffParams collects dependencies for a diff against the base branch.
+type BaseDiffParams struct {
+	BaseRef         string
+	Worktree        *models.WorktreeInfo
+	BuildCommandEnv func(branch, wtPath string) map[string]string
+}
+
+// RangeDiffParams collects dependencies for a diff between two trees, limited
+// to Filename when set.
+type RangeDiffParams struct {
+	FromRef      string
+	ToRef        string
+	Filename     string
+	WorktreePath string
+}
+
 type diffMode int
 
 const (
@@ -196,6 +219,171 @@ func (r *DiffRouter) ShowCommitFileDiff(params CommitFileDiffParams) tea.Cmd {
 	}
 }
 
+// ShowStashDiff routes a stash diff to the configured viewer. The stash is
+// diffed against the commit it was created on, as git stash show does.
+func (r *DiffRouter) ShowStashDiff(params StashDiffParams) tea.Cmd {
+	if params.Worktree == nil {
+		return nil
+	}
+	env := r.buildCommandEnv(params.BuildCommandEnv, params.Worktree.Branch, params.Worktree.Path)
+
+	gitPagerArgs := ""
+	if len(r.Config.GitPagerArgs) > 0 {
+		gitPagerArgs = " " + strings.Join(r.Config.GitPagerArgs, " ")
+	}
+
+	var (
+		cmdStr  string
+		envVars []string
+	)
+	switch r.mode() {
+	case diffModeVSCode:
+		envVars = r.envVars(env, true)
+		cmdStr = fmt.Sprintf("git difftool %s^..%s --no-prompt --extcmd='code --wait --diff'", params.StashSHA, params.StashSHA)
+	case diffModeCommand:
+		envVars = r.envVars(env, false)
+		cmdStr = fmt.Sprintf("%s diff%s %s^..%s", r.Config.GitPager, gitPagerArgs, params.StashSHA, params.StashSHA)
+	case diffModeInteractive:
+		envVars = r.envVars(env, true)
+		cmdStr = fmt.Sprintf("git stash show --patch --no-color %s | %s%s", params.StashSHA, r.Config.GitPager, gitPagerArgs)
+	default:
+		envVars = r.envVars(env, false)
+		pager := r.pagerCommand()
+		pagerCmd := pager
+		if pagerEnv := r.pagerEnv(pager); pagerEnv != "" {
+			pagerCmd = fmt.Sprintf("%s %s", pagerEnv, pager)
+		}
+		gitCmd := fmt.Sprintf("git stash show --color=always --stat --patch %s", params.StashSHA)
+		if r.UseGitPager {
+			cmdStr = fmt.Sprintf("%s | %s%s | %s", gitCmd, r.Config.GitPager, gitPagerArgs, pagerCmd)
+		} else {
+			cmdStr = fmt.Sprintf("%s | %s", gitCmd, pagerCmd)
+		}
+	}
+
+	// #nosec G204 -- command constructed from config and controlled inputs
+	c := r.CommandRunner(r.Context, "bash", "-c", cmdStr)
+	c.Dir = params.Worktree.Path
+	c.Env = envVars
+
+	return r.ExecProcess(c, func(err error) tea.Msg {
+		return r.handlePagerExit(err)
+	})
+}
+
+// ShowBaseDiff routes the diff of a worktree against the merge-base with its
+// base branch, covering both the branch commits and the working tree.
+func (r *DiffRouter) ShowBaseDiff(params BaseDiffParams) tea.Cmd {
+	if params.Worktree == nil || params.BaseRef == "" {
+		return nil
+	}
+	env := r.buildCommandEnv(params.BuildCommandEnv, params.Worktree.Branch, params.Worktree.Path)
+
+	gitPagerArgs := ""
+	if len(r.Config.GitPagerArgs) > 0 {
+		gitPagerArgs = " " + strings.Join(r.Config.GitPagerArgs, " ")
+	}
+
+	var (
+		cmdStr  string
+		envVars []string
+	)
+	switch r.mode() {
+	case diffModeVSCode:
+		envVars = r.envVars(env, true)
+		cmdStr = `git difftool "$mb" --no-prompt --extcmd='code --wait --diff'`
+	case diffModeCommand:
+		envVars = r.envVars(env, false)
+		cmdStr = fmt.Sprintf(`%s diff%s "$mb"`, r.Config.GitPager, gitPagerArgs)
+	case diffModeInteractive:
+		envVars = r.envVars(env, true)
+		cmdStr = fmt.Sprintf(`git diff --patch --no-color "$mb" | %s%s`, r.Config.GitPager, gitPagerArgs)
+	default:
+		envVars = r.envVars(env, false)
+		pager := r.pagerCommand()
+		pagerCmd := pager
+		if pagerEnv := r.pagerEnv(pager); pagerEnv != "" {
+			pagerCmd = fmt.Sprintf("%s %s", pagerEnv, pager)
+		}
+		gitCmd := `git diff --color=always --stat --patch "$mb"`
+		if r.UseGitPager {
+			cmdStr = fmt.Sprintf("%s | %s%s | %s", gitCmd, r.Config.GitPager, gitPagerArgs, pagerCmd)
+		} else {
+			cmdStr = fmt.Sprintf("%s | %s", gitCmd, pagerCmd)
+		}
+	}
+	// Resolve the merge-base in the shell so the pager starts without waiting
+	// on an extra git call in the UI.
+	cmdStr = fmt.Sprintf("mb=$(git merge-base %s HEAD) || exit 1; %s", r.shellQuote(params.BaseRef), cmdStr)
+
+	// #nosec G204 -- command constructed from config and controlled inputs
+	c := r.CommandRunner(r.Context, "bash", "-c", cmdStr)
+	c.Dir = params.Worktree.Path
+	c.Env = envVars
+
+	return r.ExecProcess(c, func(err error) tea.Msg {
+		return r.handlePagerExit(err)
+	})
+}
+
+// ShowRangeDiff routes the diff between two trees, such as the HEADs of two
+// worktrees, to the configured viewer.
+func (r *DiffRouter) ShowRangeDiff(params RangeDiffParams) tea.Cmd {
+	if params.FromRef == "" || params.ToRef == "" {
+		return nil
+	}
+
+	gitPagerArgs := ""
+	if len(r.Config.GitPagerArgs) > 0 {
+		gitPagerArgs = " " + strings.Join(r.Config.GitPagerArgs, " ")
+	}
+	pathspec := ""
+	if params.Filename != "" {
+		pathspec = " -- " + r.shellQuote(params.Filename)
+	}
+
+	var (
+		cmdStr  string
+		envVars []string
+	)
+	switch r.mode() {
+	case diffModeVSCode:
+		envVars = r.envVars(nil, true)
+		cmdStr = fmt.Sprintf("git difftool %s %s --no-prompt --extcmd='code --wait --diff'%s", params.FromRef, params.ToRef, pathspec)
+	case diffModeCommand:
+		envVars = r.envVars(nil, false)
+		cmdStr = fmt.Sprintf("%s diff%s %s..%s", r.Config.GitPager, gitPagerArgs, params.FromRef, params.ToRef)
+		if params.Filename != "" {
+			cmdStr += " --file " + r.shellQuote(params.Filename)
+		}
+	case diffModeInteractive:
+		envVars = r.envVars(nil, true)
+		cmdStr = fmt.Sprintf("git diff --patch --no-color %s %s%s | %s%s", params.FromRef, params.ToRef, pathspec, r.Config.GitPager, gitPagerArgs)
+	default:
+		envVars = r.envVars(nil, false)
+		pager := r.pagerCommand()
+		pagerCmd := pager
+		if pagerEnv := r.pagerEnv(pager); pagerEnv != "" {
+			pagerCmd = fmt.Sprintf("%s %s", pagerEnv, pager)
+		}
+		gitCmd := fmt.Sprintf("git diff --color=always --stat --patch %s %s%s", params.FromRef, params.ToRef, pathspec)
+		if r.UseGitPager {
+			cmdStr = fmt.Sprintf("%s | %s%s | %s", gitCmd, r.Config.GitPager, gitPagerArgs, pagerCmd)
+		} else {
+			cmdStr = fmt.Sprintf("%s | %s", gitCmd, pagerCmd)
+		}
+	}
+
+	// #nosec G204 -- command constructed from config and controlled inputs
+	c := r.CommandRunner(r.Context, "bash", "-c", cmdStr)
+	c.Dir = params.WorktreePath
+	c.Env = envVars
+
+	return r.ExecProcess(c, func(err error) tea.Msg {
+		return r.handlePagerExit(err)
+	})
+}
+
 func (r *DiffRouter) showDiffInteractive(params WorktreeDiffParams) tea.Cmd {
 	// Build environment variables
 	env := r.buildCommandEnv(params.BuildCommandEnv, params.Worktree.Branch, params.Worktree.Path)
@@ -476,6 +476,35 @@ func TestUpdateTableHidesPRForMainWorktree(t *testing.T) {
 	}
 }
 
+func TestUpdateTableShowsWorktreeStates(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: filepath.Join(cfg.WorktreeDir, "a-locked"), Branch: "feature", Locked: true, LockReason: "usb"},
+		{Path: filepath.Join(cfg.WorktreeDir, "b-gone"), Branch: "(detached)", Detached: true, Prunable: true},
+		{Path: filepath.Join(cfg.WorktreeDir, "c-plain"), Branch: "plain"},
+	}
+	m.sortMode = sortModePath
+
+	m.updateTable()
+
+	rows := m.state.ui.worktreeTable.Rows()
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if !strings.HasSuffix(rows[0][0], "a-locked [locked]") {
+		t.Fatalf("expected locked marker, got %q", rows[0][0])
+	}
+	if !strings.HasSuffix(rows[1][0], "b-gone [detached, prunable]") {
+		t.Fatalf("expected detached and prunable markers, got %q", rows[1][0])
+	}
+	if strings.Contains(rows[2][0], "[") {
+		t.Fatalf("expected no marker, got %q", rows[2][0])
+	}
+}
+
 func TestHandlePRDataLoadedWithWorktreePRs(t *testing.T) {
 	// Set default provider for testing
 	SetIconProvider(&NerdFontV3Provider{})
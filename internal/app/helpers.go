@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -192,8 +191,7 @@ func (m *Model) uniquePRGeneratedName(base string) string {
 		if m.localBranchExists(candidate) {
 			continue
 		}
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), candidate)
-		if m.worktreePathExists(targetPath) {
+		if m.worktreePathExists(m.worktreeLayout().Path(candidate, candidate)) {
 			continue
 		}
 		return candidate
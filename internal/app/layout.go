@@ -318,21 +318,35 @@ func (m *Model) updateTableColumns(totalWidth int) {
 	if showPRColumn {
 		pr = 12
 	}
+	// Only include the Diff column once diff stats against the base are known
+	diff := 0
+	if m.diffStatsLoaded {
+		diff = 18
+	}
 
 	// The table library handles separators internally (3 spaces per separator)
 	// So we need to account for them: (numColumns - 1) * 3
 	numColumns := 3
 	if showPRColumn {
-		numColumns = 4
+		numColumns++
+	}
+	if m.diffStatsLoaded {
+		numColumns++
 	}
 	separatorSpace := (numColumns - 1) * 3
 
-	worktree := maxInt(12, totalWidth-status-last-pr-separatorSpace)
-	excess := worktree + status + pr + last + separatorSpace - totalWidth
+	worktree := maxInt(12, totalWidth-status-last-pr-diff-separatorSpace)
+	excess := worktree + status + pr + diff + last + separatorSpace - totalWidth
 	for excess > 0 && last > 10 {
 		last--
 		excess--
 	}
+	if m.diffStatsLoaded {
+		for excess > 0 && diff > 10 {
+			diff--
+			excess--
+		}
+	}
 	if showPRColumn {
 		for excess > 0 && pr > 8 {
 			pr--
@@ -352,7 +366,7 @@ func (m *Model) updateTableColumns(totalWidth int) {
 	}
 
 	// Final adjustment: ensure column widths + separators sum exactly to totalWidth
-	actualTotal := worktree + status + last + pr + separatorSpace
+	actualTotal := worktree + status + last + pr + diff + separatorSpace
 	if actualTotal < totalWidth {
 		// Distribute remaining space to the worktree column
 		worktree += (totalWidth - actualTotal)
@@ -364,8 +378,11 @@ func (m *Model) updateTableColumns(totalWidth int) {
 	columns := []table.Column{
 		{Title: "Name", Width: worktree},
 		{Title: "Status", Width: status},
-		{Title: "Last Active", Width: last},
 	}
+	if m.diffStatsLoaded {
+		columns = append(columns, table.Column{Title: "Diff", Width: diff})
+	}
+	columns = append(columns, table.Column{Title: "Last Active", Width: last})
 
 	if showPRColumn {
 		columns = append(columns, table.Column{Title: "PR", Width: pr})
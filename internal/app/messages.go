@@ -114,6 +114,12 @@ func (m *Model) handleWorktreesLoaded(msg worktreesLoadedMsg) (tea.Model, tea.Cm
 	if cmd := m.startAutoRefresh(); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
+	if cmd := m.loadDiffStats(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	if cmd := m.loadConflictChecks(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 	if cmd := m.startGitWatcher(); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
@@ -202,6 +208,9 @@ func (m *Model) handlePruneResult(msg pruneResultMsg) (tea.Model, tea.Cmd) {
 // handleAbsorbResult processes absorb merge result message.
 func (m *Model) handleAbsorbResult(msg absorbMergeResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
+		if m.showConflictsAfterFailure(msg.conflictPath, "Absorb stopped on conflicts, resolve them then absorb again.") {
+			return m, nil
+		}
 		m.showInfo(fmt.Sprintf("Absorb failed\n\n%s", msg.err.Error()), nil)
 		return m, nil
 	}
@@ -435,13 +444,13 @@ func (m *Model) handleOpenPRsLoaded(msg openPRsLoadedMsg) tea.Cmd {
 			return nil
 		}
 
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), worktreeName)
+		targetPath := m.worktreeLayout().Path(worktreeName, localBranch)
 		if m.worktreePathExists(targetPath) {
 			m.showInfo(fmt.Sprintf("Path already exists: %s", targetPath), nil)
 			return nil
 		}
 
-		if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
+		if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
 			return func() tea.Msg { return errMsg{err: err} }
 		}
 
@@ -461,6 +470,8 @@ func (m *Model) handleOpenPRsLoaded(msg openPRsLoadedMsg) tea.Cmd {
 					err:        fmt.Errorf("create worktree from PR/MR branch %q", remoteBranch),
 				}
 			}
+			m.updateNewWorktreeSubmodules(targetPath)
+			m.recordBaseRef(targetPath, localBranch, pr.BaseBranch)
 			noteText, err := m.generateWorktreeNote("pr", pr.Number, pr.Title, pr.Body, pr.URL)
 			if err != nil {
 				m.debugf("worktree note script error for PR/MR #%d: %v", pr.Number, err)
@@ -566,14 +577,14 @@ func (m *Model) handleOpenIssuesLoaded(msg openIssuesLoadedMsg) tea.Cmd {
 						return nil
 					}
 
-					targetPath := filepath.Join(m.getRepoWorktreeDir(), newBranch)
+					targetPath := m.worktreeLayout().Path(newBranch, newBranch)
 					if errMsg := m.validateNewWorktreeTarget(newBranch, targetPath); errMsg != "" {
 						inputScr.ErrorMsg = errMsg
 						return nil
 					}
 
 					inputScr.ErrorMsg = ""
-					if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
+					if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
 						return func() tea.Msg { return errMsg{err: err} }
 					}
 
@@ -598,6 +609,8 @@ func (m *Model) handleOpenIssuesLoaded(msg openIssuesLoadedMsg) tea.Cmd {
 								err:         fmt.Errorf("create worktree from issue #%d", issue.Number),
 							}
 						}
+						m.updateNewWorktreeSubmodules(targetPath)
+						m.recordBaseRef(targetPath, newBranch, baseBranch)
 						noteText, err := m.generateWorktreeNote("issue", issue.Number, issue.Title, issue.Body, issue.URL)
 						if err != nil {
 							m.debugf("worktree note script error for issue #%d: %v", issue.Number, err)
@@ -668,6 +681,9 @@ func (m *Model) handleCreateFromChangesReady(msg createFromChangesReadyMsg) tea.
 // handleCherryPickResult handles the result of a cherry-pick operation.
 func (m *Model) handleCherryPickResult(msg cherryPickResultMsg) tea.Cmd {
 	if msg.err != nil {
+		if m.showConflictsAfterFailure(msg.targetWorktree.Path, fmt.Sprintf("Cherry-pick of %s stopped on conflicts.", msg.commitSHA)) {
+			return nil
+		}
 		errorMessage := fmt.Sprintf("Cherry-pick failed\n\nCommit: %s\nTarget: %s (%s)\n\nError: %v",
 			msg.commitSHA,
 			filepath.Base(msg.targetWorktree.Path),
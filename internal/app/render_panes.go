@@ -729,7 +729,51 @@ func (m *Model) buildInfoContent(wt *models.WorktreeInfo) string {
 
 	infoLines := make([]string, 0, 32)
 	infoLines = addField(infoLines, "Path:", valueStyle.Render(wt.Path))
-	infoLines = addField(infoLines, "Branch:", valueStyle.Render(wt.Branch))
+	if wt.Detached && wt.Head != "" {
+		infoLines = addField(infoLines, "Branch:", valueStyle.Render(fmt.Sprintf("(detached at %s)", shortSHA(wt.Head))))
+	} else {
+		infoLines = addField(infoLines, "Branch:", valueStyle.Render(wt.Branch))
+	}
+	if wt.BaseRef != "" {
+		infoLines = addField(infoLines, "Base:", valueStyle.Render(wt.BaseRef))
+	}
+	if wt.Locked {
+		reason := wt.LockReason
+		if reason == "" {
+			reason = "yes"
+		}
+		infoLines = addField(infoLines, "Locked:", valueStyle.Render(reason))
+	}
+	if wt.Prunable {
+		warnStyle := lipgloss.NewStyle().Foreground(m.theme.WarnFg)
+		reason := wt.PrunableReason
+		if reason == "" {
+			reason = "directory is missing"
+		}
+		infoLines = addField(infoLines, "Prunable:", warnStyle.Render(reason))
+	}
+	if wt.Operation != "" {
+		operation := fmt.Sprintf("%s in progress", wt.Operation)
+		if wt.Conflicts > 0 {
+			operation = fmt.Sprintf("%s, %d unmerged file(s)", operation, wt.Conflicts)
+		}
+		infoLines = addField(infoLines, "Operation:", lipgloss.NewStyle().Foreground(m.theme.ErrorFg).Render(operation))
+	}
+	if base, paths := m.predictedConflicts(wt); len(paths) > 0 {
+		conflictStyle := lipgloss.NewStyle().Foreground(m.theme.ErrorFg)
+		infoLines = addField(infoLines, "Conflicts:", conflictStyle.Render(fmt.Sprintf("merging into %s would conflict on %d file(s)", base, len(paths))))
+		for i, path := range paths {
+			if i == maxPredictedConflictPaths {
+				infoLines = append(infoLines, valueStyle.Render(fmt.Sprintf("  ... and %d more", len(paths)-i)))
+				break
+			}
+			infoLines = append(infoLines, conflictStyle.Render("  "+path))
+		}
+	}
+	if wt.Sparse {
+		sparse := fmt.Sprintf("%s (%s)", m.sparseProfileLabel(wt.SparseDirs), strings.Join(wt.SparseDirs, ", "))
+		infoLines = addField(infoLines, "Sparse:", valueStyle.Render(sparse))
+	}
 
 	if wt.LastSwitchedTS > 0 {
 		accessTime := time.Unix(wt.LastSwitchedTS, 0)
@@ -896,6 +940,14 @@ func (m *Model) buildInfoContent(wt *models.WorktreeInfo) string {
 	return strings.Join(infoLines, "\n")
 }
 
+// submoduleStatusLabel describes a submodule entry of the status pane.
+func submoduleStatusLabel(state string) string {
+	if state == "" {
+		return " (submodule)"
+	}
+	return fmt.Sprintf(" (submodule: %s)", state)
+}
+
 // renderStatusFiles renders the status file list with current selection highlighted.
 func (m *Model) renderStatusFiles() string {
 	if len(m.state.services.statusTree.TreeFlat) == 0 {
@@ -930,6 +982,7 @@ func (m *Model) renderStatusFiles() string {
 
 		var lineContent string
 		var fileIcon string
+		var submoduleLabel string
 		if node.IsDir() {
 			// Directory line: "  ▼ dirname" or "  ▶ dirname"
 			expandIcon := disclosureIndicator(m.state.services.statusTree.CollapsedDirs[node.Path], showIcons)
@@ -945,7 +998,10 @@ func (m *Model) renderStatusFiles() string {
 			if showIcons {
 				fileIcon = iconWithSpace(deviconForName(node.Name(), false))
 			}
-			lineContent = fmt.Sprintf("%s  %s %s%s", indent, displayStatus, fileIcon, node.Name())
+			if node.File.IsSubmodule {
+				submoduleLabel = submoduleStatusLabel(node.File.SubmoduleState)
+			}
+			lineContent = fmt.Sprintf("%s  %s %s%s%s", indent, displayStatus, fileIcon, node.Name(), submoduleLabel)
 		}
 
 		// Apply styling based on selection and node type
@@ -1017,7 +1073,7 @@ func (m *Model) renderStatusFiles() string {
 				}
 				statusRendered.WriteString(style.Render(string(char)))
 			}
-			formatted := fmt.Sprintf("%s  %s %s%s", indent, statusRendered.String(), fileIcon, node.Name())
+			formatted := fmt.Sprintf("%s  %s %s%s%s", indent, statusRendered.String(), fileIcon, node.Name(), dirStyle.Render(submoduleLabel))
 			lines = append(lines, formatted)
 		}
 	}
@@ -72,6 +72,21 @@ func (m *Model) View() string {
 				ts.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
 			}
 			return m.overlayPopup(baseView, scr.View(), 2)
+		case screen.TypeHunks:
+			if hs, ok := scr.(*screen.HunkScreen); ok {
+				hs.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			return m.overlayPopup(baseView, scr.View(), 2)
+		case screen.TypeConflicts:
+			if cs, ok := scr.(*screen.ConflictScreen); ok {
+				cs.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			return m.overlayPopup(baseView, scr.View(), 2)
+		case screen.TypeConflictMatrix:
+			if ms, ok := scr.(*screen.ConflictMatrixScreen); ok {
+				ms.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			return m.overlayPopup(baseView, scr.View(), 2)
 		case screen.TypePRSelect:
 			// PR selection screen with 2-margin popup
 			return m.overlayPopup(baseView, scr.View(), 2)
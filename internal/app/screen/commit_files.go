@@ -59,6 +59,11 @@ type CommitFilesScreen struct {
 	// Commit metadata
 	Meta CommitMeta
 
+	// Title replaces "Files in commit <sha>" and Summary the commit metadata
+	// when the files are not those of a single commit, as in a comparison.
+	Title   string
+	Summary []string
+
 	// Filter/search support
 	FilterInput   textinput.Model
 	ShowingFilter bool
@@ -70,6 +75,11 @@ type CommitFilesScreen struct {
 	OnShowFileDiff   func(filename string) tea.Cmd
 	OnShowCommitDiff func() tea.Cmd
 	OnClose          func() tea.Cmd
+
+	// OnToggleUncommitted switches a comparison between the committed and the
+	// uncommitted states; Uncommitted tells which one is shown.
+	OnToggleUncommitted func() tea.Cmd
+	Uncommitted         bool
 }
 
 // NewCommitFilesScreen creates a commit files tree screen.
@@ -428,6 +438,11 @@ func (s *CommitFilesScreen) Update(msg tea.KeyMsg) (Screen, tea.Cmd) {
 			return nil, s.OnShowCommitDiff()
 		}
 		return nil, nil
+	case "w":
+		if s.OnToggleUncommitted != nil {
+			return nil, s.OnToggleUncommitted()
+		}
+		return s, nil
 	case keyEnter:
 		node := s.GetSelectedNode()
 		if node == nil {
@@ -505,6 +520,9 @@ func (s *CommitFilesScreen) View() string {
 		}
 		headerHeight += metaHeight
 	}
+	if len(s.Summary) > 0 {
+		headerHeight += len(s.Summary) + 1 // lines + border
+	}
 	if s.ShowingFilter || s.ShowingSearch {
 		headerHeight++
 	}
@@ -529,7 +547,11 @@ func (s *CommitFilesScreen) View() string {
 	if len(shortSHA) > 8 {
 		shortSHA = shortSHA[:8]
 	}
-	title := titleStyle.Render(fmt.Sprintf("Files in commit %s", shortSHA))
+	titleText := fmt.Sprintf("Files in commit %s", shortSHA)
+	if s.Title != "" {
+		titleText = s.Title
+	}
+	title := titleStyle.Render(titleText)
 
 	// Render commit metadata
 	metaStyle := lipgloss.NewStyle().
@@ -562,6 +584,9 @@ func (s *CommitFilesScreen) View() string {
 		}
 		metaLines = append(metaLines, subjectStyle.Render(s.Meta.Subject))
 	}
+	for _, line := range s.Summary {
+		metaLines = append(metaLines, valueStyle.Render(line))
+	}
 	commitMetaSection := ""
 	if len(metaLines) > 0 {
 		commitMetaSection = metaStyle.Render(strings.Join(metaLines, "\n"))
@@ -663,7 +688,11 @@ func (s *CommitFilesScreen) View() string {
 	}
 
 	if len(s.TreeFlat) == 0 {
-		itemViews = append(itemViews, noFilesStyle.Render("No files in this commit."))
+		noFiles := "No files in this commit."
+		if s.Title != "" {
+			noFiles = "No files differ."
+		}
+		itemViews = append(itemViews, noFilesStyle.Render(noFiles))
 	}
 
 	// Footer
@@ -675,6 +704,13 @@ func (s *CommitFilesScreen) View() string {
 		BorderForeground(s.Thm.BorderDim)
 
 	footerText := "j/k: navigate • Enter: toggle/view diff • d: full diff • f: filter • /: search • q: close"
+	if s.OnToggleUncommitted != nil {
+		toggle := "w: compare uncommitted changes"
+		if s.Uncommitted {
+			toggle = "w: compare HEADs"
+		}
+		footerText = "j/k: navigate • Enter: toggle/view diff • d: full diff • " + toggle + " • f: filter • q: close"
+	}
 	if s.ShowingFilter {
 		footerText = fmt.Sprintf("%s: navigate • Enter: apply filter • Esc: clear filter", arrowPair(s.ShowIcons))
 	} else if s.ShowingSearch {
@@ -0,0 +1,223 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// PairCheck is the result of checking two worktrees against each other.
+type PairCheck struct {
+	Conflicts []string // Paths git merge-tree reports as conflicting
+	Overlaps  []string // Paths changed on both sides since they diverged
+	Err       string
+}
+
+// ConflictMatrixScreen shows, for every pair of worktrees, whether merging
+// them would conflict or whether they change the same files.
+type ConflictMatrixScreen struct {
+	Names  []string
+	Checks [][]PairCheck // Checks[i][j] compares Names[i] and Names[j]
+	Row    int
+	Col    int
+	Width  int
+	Height int
+	Thm    *theme.Theme
+
+	OnClose func() tea.Cmd
+}
+
+// NewConflictMatrixScreen creates the matrix of the worktrees names, with
+// checks indexed like names on both axes.
+func NewConflictMatrixScreen(names []string, checks [][]PairCheck, maxWidth, maxHeight int, thm *theme.Theme) *ConflictMatrixScreen {
+	s := &ConflictMatrixScreen{
+		Names:  names,
+		Checks: checks,
+		Col:    min(1, max(0, len(names)-1)),
+		Thm:    thm,
+	}
+	s.Resize(maxWidth, maxHeight)
+	return s
+}
+
+// Type returns the screen type.
+func (s *ConflictMatrixScreen) Type() Type {
+	return TypeConflictMatrix
+}
+
+// Resize updates the modal dimensions based on terminal size.
+func (s *ConflictMatrixScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 80
+	s.Height = 24
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.8), 60, 140)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.8), 16, 48)
+	}
+}
+
+// Update handles keyboard input.
+func (s *ConflictMatrixScreen) Update(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	last := max(0, len(s.Names)-1)
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "j", keyDown:
+		s.Row = min(s.Row+1, last)
+	case "k", keyUp:
+		s.Row = max(s.Row-1, 0)
+	case "l", "right":
+		s.Col = min(s.Col+1, last)
+	case "h", "left":
+		s.Col = max(s.Col-1, 0)
+	}
+	return s, nil
+}
+
+// Selected returns the check of the pair under the cursor, and false on the
+// diagonal.
+func (s *ConflictMatrixScreen) Selected() (PairCheck, bool) {
+	if s.Row == s.Col || s.Row >= len(s.Checks) || s.Col >= len(s.Checks[s.Row]) {
+		return PairCheck{}, false
+	}
+	return s.Checks[s.Row][s.Col], true
+}
+
+// cellLabel renders a check as "!N" for N conflicting paths, "~N" for N
+// overlapping paths, "ok" when the worktrees are independent and "?" when the
+// check failed.
+func cellLabel(check PairCheck) string {
+	switch {
+	case check.Err != "":
+		return "?"
+	case len(check.Conflicts) > 0:
+		return fmt.Sprintf("!%d", len(check.Conflicts))
+	case len(check.Overlaps) > 0:
+		return fmt.Sprintf("~%d", len(check.Overlaps))
+	default:
+		return "ok"
+	}
+}
+
+// View renders the conflict matrix.
+func (s *ConflictMatrixScreen) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	subtitleStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	conflictStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+	overlapStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+	cleanStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+
+	contentWidth := s.Width - 6
+	const cellWidth = 5
+	labelWidth := clampInt(contentWidth-cellWidth*len(s.Names)-4, 8, 24)
+
+	// Columns are numbered after the row labels to keep them narrow
+	header := strings.Repeat(" ", labelWidth+4)
+	for j := range s.Names {
+		header += fmt.Sprintf("%*d", cellWidth, j+1)
+	}
+	grid := []string{subtitleStyle.Render(ansi.Truncate(header, contentWidth, ""))}
+	for i, name := range s.Names {
+		line := fmt.Sprintf("%2d  %-*s", i+1, labelWidth, ansi.Truncate(name, labelWidth, "…"))
+		for j := range s.Names {
+			label := "-"
+			style := subtitleStyle
+			if i != j {
+				check := s.Checks[i][j]
+				label = cellLabel(check)
+				switch {
+				case check.Err != "":
+				case len(check.Conflicts) > 0:
+					style = conflictStyle
+				case len(check.Overlaps) > 0:
+					style = overlapStyle
+				default:
+					style = cleanStyle
+				}
+			}
+			cell := fmt.Sprintf("%*s", cellWidth, label)
+			if i == s.Row && j == s.Col {
+				cell = selectedStyle.Render(cell)
+			} else {
+				cell = style.Render(cell)
+			}
+			line += cell
+		}
+		grid = append(grid, line)
+	}
+
+	detailsHeight := max(3, s.Height-len(grid)-8)
+	details := s.detailLines(contentWidth, detailsHeight, conflictStyle, overlapStyle, subtitleStyle)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render("Conflict matrix"),
+		subtitleStyle.Render("!N conflicting files • ~N files changed on both sides • ok independent"),
+		"",
+		strings.Join(grid, "\n"),
+		"",
+		strings.Join(details, "\n"),
+		footerStyle.Render("h/j/k/l move • q close"),
+	)
+	return boxStyle.Render(content)
+}
+
+// detailLines describes the pair under the cursor in at most height lines.
+func (s *ConflictMatrixScreen) detailLines(width, height int, conflictStyle, overlapStyle, mutedStyle lipgloss.Style) []string {
+	check, ok := s.Selected()
+	if !ok {
+		return []string{mutedStyle.Render("Select two different worktrees.")}
+	}
+	pair := fmt.Sprintf("%s and %s", s.Names[s.Row], s.Names[s.Col])
+
+	var lines []string
+	paths := check.Conflicts
+	style := conflictStyle
+	switch {
+	case check.Err != "":
+		return []string{conflictStyle.Render(ansi.Truncate(fmt.Sprintf("%s: %s", pair, check.Err), width, "…"))}
+	case len(check.Conflicts) > 0:
+		lines = append(lines, conflictStyle.Render(ansi.Truncate(fmt.Sprintf("%s conflict on %d file(s):", pair, len(check.Conflicts)), width, "…")))
+	case len(check.Overlaps) > 0:
+		lines = append(lines, overlapStyle.Render(ansi.Truncate(fmt.Sprintf("%s both change %d file(s):", pair, len(check.Overlaps)), width, "…")))
+		paths, style = check.Overlaps, overlapStyle
+	default:
+		return []string{mutedStyle.Render(ansi.Truncate(pair+" change different files.", width, "…"))}
+	}
+
+	for i, path := range paths {
+		if len(lines) == height-1 && i < len(paths)-1 {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ... and %d more", len(paths)-i)))
+			break
+		}
+		lines = append(lines, style.Render(ansi.Truncate("  "+path, width, "…")))
+	}
+	return lines
+}
@@ -0,0 +1,53 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func TestConflictMatrixScreenNavigation(t *testing.T) {
+	checks := [][]PairCheck{
+		{{}, {Conflicts: []string{"a.go"}}, {Overlaps: []string{"b.go", "c.go"}}},
+		{{Conflicts: []string{"a.go"}}, {}, {}},
+		{{Overlaps: []string{"b.go", "c.go"}}, {}, {}},
+	}
+	s := NewConflictMatrixScreen([]string{"main", "feature", "fix"}, checks, 120, 40, theme.Dracula())
+
+	check, ok := s.Selected()
+	if !ok || len(check.Conflicts) != 1 {
+		t.Fatalf("expected the first pair selected, got %+v", check)
+	}
+	if !strings.Contains(s.View(), "main and feature conflict on 1 file(s)") {
+		t.Fatal("expected the conflicting files of the selected pair")
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if s.Col != 2 {
+		t.Fatalf("expected column clamped to the last worktree, got %d", s.Col)
+	}
+	if check, _ := s.Selected(); cellLabel(check) != "~2" {
+		t.Fatalf("expected overlapping files, got %q", cellLabel(check))
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if _, ok := s.Selected(); ok {
+		t.Fatal("expected no pair on the diagonal")
+	}
+	if cellLabel(PairCheck{}) != "ok" || cellLabel(PairCheck{Err: "boom"}) != "?" {
+		t.Fatal("unexpected labels for independent and failed checks")
+	}
+
+	closed := false
+	s.OnClose = func() tea.Cmd {
+		closed = true
+		return nil
+	}
+	if next, _ := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); next != nil || !closed {
+		t.Fatal("expected q to close the screen")
+	}
+}
@@ -0,0 +1,224 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// ConflictScreen lists the unmerged files of a rebase, merge or cherry-pick
+// stopped on conflicts, to resolve them and continue, skip or abort it.
+type ConflictScreen struct {
+	Operation    string // "rebase", "merge" or "cherry-pick"
+	WorktreeName string
+	Files        []string
+	Cursor       int
+	ScrollOffset int
+	ErrorMsg     string
+	Width        int
+	Height       int
+	Thm          *theme.Theme
+
+	OnEdit      func(file string) tea.Cmd
+	OnMergetool func(file string) tea.Cmd
+	OnResolve   func(file string) tea.Cmd
+	// OnAction runs "continue", "skip" or "abort" on the operation.
+	OnAction func(action string) tea.Cmd
+	OnClose  func() tea.Cmd
+}
+
+// NewConflictScreen creates the conflict screen of an operation stopped in
+// the worktree worktreeName.
+func NewConflictScreen(operation, worktreeName string, files []string, maxWidth, maxHeight int, thm *theme.Theme) *ConflictScreen {
+	s := &ConflictScreen{
+		Operation:    operation,
+		WorktreeName: worktreeName,
+		Thm:          thm,
+	}
+	s.Resize(maxWidth, maxHeight)
+	s.SetFiles(files)
+	return s
+}
+
+// Type returns the screen type.
+func (s *ConflictScreen) Type() Type {
+	return TypeConflicts
+}
+
+// Resize updates the modal dimensions based on terminal size.
+func (s *ConflictScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 80
+	s.Height = 20
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.7), 60, 110)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.6), 14, 36)
+	}
+}
+
+// SetFiles replaces the unmerged files, keeping the cursor in range.
+func (s *ConflictScreen) SetFiles(files []string) {
+	s.Files = append([]string{}, files...)
+	s.Cursor = clampInt(s.Cursor, 0, max(0, len(s.Files)-1))
+	s.ensureCursorVisible()
+}
+
+// CanSkip reports whether the operation supports --skip.
+func (s *ConflictScreen) CanSkip() bool {
+	return s.Operation != "merge"
+}
+
+// Update handles keyboard input.
+func (s *ConflictScreen) Update(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	s.ErrorMsg = ""
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "j", keyDown, keyCtrlJ:
+		if s.Cursor < len(s.Files)-1 {
+			s.Cursor++
+			s.ensureCursorVisible()
+		}
+	case "k", keyUp, keyCtrlK:
+		if s.Cursor > 0 {
+			s.Cursor--
+			s.ensureCursorVisible()
+		}
+	case keyEnter, "e":
+		if file := s.selectedFile(); file != "" && s.OnEdit != nil {
+			return s, s.OnEdit(file)
+		}
+	case "m":
+		if file := s.selectedFile(); file != "" && s.OnMergetool != nil {
+			return s, s.OnMergetool(file)
+		}
+	case "r", " ":
+		if file := s.selectedFile(); file != "" && s.OnResolve != nil {
+			return s, s.OnResolve(file)
+		}
+	case "c":
+		return s, s.action("continue")
+	case "s":
+		if s.CanSkip() {
+			return s, s.action("skip")
+		}
+	case "A":
+		return s, s.action("abort")
+	}
+	return s, nil
+}
+
+func (s *ConflictScreen) action(action string) tea.Cmd {
+	if s.OnAction == nil {
+		return nil
+	}
+	return s.OnAction(action)
+}
+
+func (s *ConflictScreen) selectedFile() string {
+	if s.Cursor < 0 || s.Cursor >= len(s.Files) {
+		return ""
+	}
+	return s.Files[s.Cursor]
+}
+
+func (s *ConflictScreen) maxVisible() int {
+	return max(3, s.Height-8)
+}
+
+func (s *ConflictScreen) ensureCursorVisible() {
+	maxVisible := s.maxVisible()
+	if s.Cursor < s.ScrollOffset {
+		s.ScrollOffset = s.Cursor
+	}
+	if s.Cursor >= s.ScrollOffset+maxVisible {
+		s.ScrollOffset = s.Cursor - maxVisible + 1
+	}
+	s.ScrollOffset = clampInt(s.ScrollOffset, 0, max(0, len(s.Files)-maxVisible))
+}
+
+// View renders the conflict screen.
+func (s *ConflictScreen) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	subtitleStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	fileStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	resolvedStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	errorStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+
+	contentWidth := s.Width - 6
+	maxVisible := s.maxVisible()
+
+	title := fmt.Sprintf("%s in progress: %s", capitalize(s.Operation), s.WorktreeName)
+	subtitle := fmt.Sprintf("%d unmerged file(s)", len(s.Files))
+
+	lines := make([]string, 0, maxVisible)
+	if len(s.Files) == 0 {
+		lines = append(lines, resolvedStyle.Render(fmt.Sprintf("All conflicts resolved, press c to continue the %s.", s.Operation)))
+	}
+	end := min(len(s.Files), s.ScrollOffset+maxVisible)
+	for i := s.ScrollOffset; i < end; i++ {
+		line := ansi.Truncate("UU "+s.Files[i], contentWidth, "")
+		if i == s.Cursor {
+			lines = append(lines, selectedStyle.Width(contentWidth).Render(line))
+			continue
+		}
+		lines = append(lines, fileStyle.Render(line))
+	}
+	for len(lines) < maxVisible {
+		lines = append(lines, "")
+	}
+
+	status := ""
+	if s.ErrorMsg != "" {
+		status = errorStyle.Render(ansi.Truncate(strings.ReplaceAll(s.ErrorMsg, "\n", " "), contentWidth, "…"))
+	}
+
+	footerHelp := "e edit • m mergetool • r resolved • c continue • s skip • A abort • q close"
+	if !s.CanSkip() {
+		footerHelp = "e edit • m mergetool • r resolved • c continue • A abort • q close"
+	}
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.ErrorFg).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(title),
+		subtitleStyle.Render(subtitle),
+		"",
+		strings.Join(lines, "\n"),
+		status,
+		footerStyle.Render(footerHelp),
+	)
+	return boxStyle.Render(content)
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,66 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func TestConflictScreenActions(t *testing.T) {
+	s := NewConflictScreen("rebase", "feature", []string{"a.go", "b.go"}, 120, 40, theme.Dracula())
+
+	var resolved, action string
+	s.OnResolve = func(file string) tea.Cmd {
+		resolved = file
+		return nil
+	}
+	s.OnAction = func(a string) tea.Cmd {
+		action = a
+		return nil
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if resolved != "b.go" {
+		t.Fatalf("expected b.go resolved, got %q", resolved)
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if action != "skip" {
+		t.Fatalf("expected skip, got %q", action)
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if action != "abort" {
+		t.Fatalf("expected abort, got %q", action)
+	}
+
+	s.SetFiles([]string{"a.go"})
+	if s.Cursor != 0 {
+		t.Fatalf("expected cursor clamped to the remaining file, got %d", s.Cursor)
+	}
+	s.SetFiles(nil)
+	if !strings.Contains(s.View(), "All conflicts resolved") {
+		t.Fatal("expected the resolved message once no file is left")
+	}
+
+	if scr, _ := s.Update(tea.KeyMsg{Type: tea.KeyEsc}); scr != nil {
+		t.Fatal("expected esc to close the screen")
+	}
+}
+
+func TestConflictScreenMergeCannotSkip(t *testing.T) {
+	s := NewConflictScreen("merge", "main", []string{"a.go"}, 120, 40, theme.Dracula())
+	action := ""
+	s.OnAction = func(a string) tea.Cmd {
+		action = a
+		return nil
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if action != "" {
+		t.Fatalf("expected no skip for a merge, got %q", action)
+	}
+	if strings.Contains(s.View(), "s skip") {
+		t.Fatal("expected no skip hint for a merge")
+	}
+}
@@ -72,6 +72,7 @@ func NewHelpScreen(maxWidth, maxHeight int, customCommands map[string]*config.Cu
 - e: Open selected file in editor
 - d: Show full diff (all files) in pager
 - s: Stage/unstage selected file or directory
+- a: Stage, unstage or discard hunks and line ranges of selected file
 - D: Delete selected file or directory (with confirmation)
 - c: Commit staged changes
 - C: Stage all changes and commit
@@ -139,6 +140,7 @@ Supported: Letters (a-z, A-Z), numbers (0-9), and hyphens (-). See help for full
 
 **{{HELP_VIEWING_TOOLS}}Viewing & Tools**
 - d: Show diff in pager (worktree or commit)
+- B: Show diff against the base branch (commits and uncommitted changes)
 - o: Open PR/MR in browser (or root repo in editor if main branch with merged/closed/no PR)
 - g: Open LazyGit (or go to top in diff pane)
 - =: Toggle zoom for focused pane
@@ -155,6 +157,7 @@ Supported: Letters (a-z, A-Z), numbers (0-9), and hyphens (-). See help for full
 - Ctrl+v: View selected CI check logs in pager (within CI check selection screen, or in status pane when CI check is selected)
 - Ctrl+r: Restart selected CI job (GitHub Actions only, within CI check selection screen)
 - s: Cycle sort (Path / Last Active / Last Switched)
+- Resolve conflicts (command palette): Edit, mark resolved, continue, skip or abort a stopped rebase, merge or cherry-pick
 
 **{{HELP_BACKGROUND_REFRESH}}Background Refresh**
 - Configured via auto_refresh and refresh_interval in the configuration file
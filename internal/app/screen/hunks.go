@@ -0,0 +1,304 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// HunkScreen shows the unstaged or staged hunks of a file, to stage, unstage
+// or discard a whole hunk or a range of its lines.
+type HunkScreen struct {
+	Filename string
+	Staged   bool // Showing staged changes, applied back to unstage them
+	Diff     *models.FileDiff
+	Hunk     int // Hunk holding the cursor
+	Line     int // Cursor line within the hunk
+	Anchor   int // Start of the line selection within the hunk, -1 without one
+	ErrorMsg string
+	Width    int
+	Height   int
+	Thm      *theme.Theme
+
+	// OnApply stages the lines from..to of a hunk, or unstages them when
+	// Staged; OnDiscard reverts them in the worktree.
+	OnApply      func(hunk, from, to int) tea.Cmd
+	OnDiscard    func(hunk, from, to int) tea.Cmd
+	OnToggleView func() tea.Cmd
+	OnClose      func() tea.Cmd
+}
+
+// NewHunkScreen creates a hunk view of diff, the changes of filename.
+func NewHunkScreen(filename string, staged bool, diff *models.FileDiff, maxWidth, maxHeight int, thm *theme.Theme) *HunkScreen {
+	s := &HunkScreen{
+		Filename: filename,
+		Staged:   staged,
+		Anchor:   -1,
+		Thm:      thm,
+	}
+	s.Resize(maxWidth, maxHeight)
+	s.SetDiff(diff)
+	return s
+}
+
+// Type returns the screen type.
+func (s *HunkScreen) Type() Type {
+	return TypeHunks
+}
+
+// Resize updates the modal dimensions based on terminal size.
+func (s *HunkScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 100
+	s.Height = 30
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.9), 70, 160)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.85), 18, 50)
+	}
+}
+
+// SetDiff replaces the diff after a hunk was applied, keeping the cursor on
+// the same hunk position so the next one is ready to be staged.
+func (s *HunkScreen) SetDiff(diff *models.FileDiff) {
+	if diff == nil {
+		diff = &models.FileDiff{}
+	}
+	s.Diff = diff
+	s.Anchor = -1
+	if len(diff.Hunks) == 0 {
+		s.Hunk, s.Line = 0, 0
+		return
+	}
+	s.Hunk = clampInt(s.Hunk, 0, len(diff.Hunks)-1)
+	lines := diff.Hunks[s.Hunk].Lines
+	s.Line = clampInt(s.Line, 0, max(0, len(lines)-1))
+	if !diff.Hunks[s.Hunk].IsChange(s.Line) {
+		s.Line = s.firstChange(s.Hunk)
+	}
+}
+
+// Selection returns the current hunk and the range of its lines to apply:
+// the selected lines, or the whole hunk.
+func (s *HunkScreen) Selection() (hunk, from, to int) {
+	if s.Anchor < 0 {
+		return s.Hunk, 0, len(s.Diff.Hunks[s.Hunk].Lines) - 1
+	}
+	return s.Hunk, min(s.Anchor, s.Line), max(s.Anchor, s.Line)
+}
+
+// Update handles navigation, selection and staging keys.
+func (s *HunkScreen) Update(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	s.ErrorMsg = ""
+	key := msg.String()
+	switch key {
+	case keyEsc, keyEscRaw:
+		if s.Anchor >= 0 {
+			s.Anchor = -1
+			return s, nil
+		}
+		return s.close()
+	case keyQ, keyCtrlC:
+		return s.close()
+	case keyTab:
+		if s.OnToggleView != nil {
+			return s, s.OnToggleView()
+		}
+		return s, nil
+	}
+
+	if len(s.Diff.Hunks) == 0 {
+		return s, nil
+	}
+
+	switch key {
+	case "j", keyDown:
+		s.moveLine(1)
+	case "k", keyUp:
+		s.moveLine(-1)
+	case "n":
+		s.moveHunk(1)
+	case "p":
+		s.moveHunk(-1)
+	case "g":
+		s.Hunk = 0
+		s.Line = s.firstChange(0)
+		s.Anchor = -1
+	case "G":
+		s.Hunk = len(s.Diff.Hunks) - 1
+		s.Line = len(s.Diff.Hunks[s.Hunk].Lines) - 1
+		s.Anchor = -1
+	case "v":
+		if s.Anchor >= 0 {
+			s.Anchor = -1
+		} else {
+			s.Anchor = s.Line
+		}
+	case " ", "s":
+		if s.OnApply != nil {
+			return s, s.OnApply(s.Selection())
+		}
+	case "d":
+		if !s.Staged && s.OnDiscard != nil {
+			return s, s.OnDiscard(s.Selection())
+		}
+	}
+	return s, nil
+}
+
+func (s *HunkScreen) close() (Screen, tea.Cmd) {
+	if s.OnClose != nil {
+		return nil, s.OnClose()
+	}
+	return nil, nil
+}
+
+// moveLine moves the cursor by delta lines, crossing into the neighbouring
+// hunks unless a selection, which stays within its hunk, is in progress.
+func (s *HunkScreen) moveLine(delta int) {
+	line := s.Line + delta
+	lines := len(s.Diff.Hunks[s.Hunk].Lines)
+	switch {
+	case line >= 0 && line < lines:
+		s.Line = line
+	case s.Anchor >= 0:
+	case line < 0 && s.Hunk > 0:
+		s.Hunk--
+		s.Line = len(s.Diff.Hunks[s.Hunk].Lines) - 1
+	case line >= lines && s.Hunk < len(s.Diff.Hunks)-1:
+		s.Hunk++
+		s.Line = 0
+	}
+}
+
+func (s *HunkScreen) moveHunk(delta int) {
+	s.Hunk = clampInt(s.Hunk+delta, 0, len(s.Diff.Hunks)-1)
+	s.Line = s.firstChange(s.Hunk)
+	s.Anchor = -1
+}
+
+func (s *HunkScreen) firstChange(hunk int) int {
+	for i := range s.Diff.Hunks[hunk].Lines {
+		if s.Diff.Hunks[hunk].IsChange(i) {
+			return i
+		}
+	}
+	return 0
+}
+
+// View renders the hunk view.
+func (s *HunkScreen) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	headerStyle := lipgloss.NewStyle().Foreground(s.Thm.Cyan)
+	addStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	delStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+	contextStyle := lipgloss.NewStyle().Foreground(s.Thm.TextFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	cursorStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	selectedStyle := lipgloss.NewStyle().Background(s.Thm.AccentDim)
+
+	kind := "Unstaged"
+	if s.Staged {
+		kind = "Staged"
+	}
+	title := fmt.Sprintf("%s changes: %s", kind, s.Filename)
+	if len(s.Diff.Hunks) > 0 {
+		title = fmt.Sprintf("%s (hunk %d/%d)", title, s.Hunk+1, len(s.Diff.Hunks))
+	}
+
+	contentWidth := s.Width - 6
+	maxVisible := max(4, s.Height-7)
+
+	var (
+		rows      []string
+		cursorRow int
+	)
+	from, to := -1, -1
+	if len(s.Diff.Hunks) > 0 {
+		_, from, to = s.Selection()
+	}
+	for h, hunk := range s.Diff.Hunks {
+		header := fmt.Sprintf("@@ -%d,%d +%d,%d @@ %s", hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines, hunk.Section)
+		rows = append(rows, headerStyle.Render(ansi.Truncate(strings.TrimSpace(header), contentWidth, "")))
+		for i, line := range hunk.Lines {
+			text := ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), contentWidth, "")
+			current := h == s.Hunk && i == s.Line
+			selected := h == s.Hunk && s.Anchor >= 0 && i >= from && i <= to
+			if current {
+				cursorRow = len(rows)
+			}
+			style := contextStyle
+			switch {
+			case strings.HasPrefix(line, "+"):
+				style = addStyle
+			case strings.HasPrefix(line, "-"):
+				style = delStyle
+			case strings.HasPrefix(line, "\\"):
+				style = mutedStyle
+			}
+			switch {
+			case current:
+				style = cursorStyle.Width(contentWidth)
+			case selected:
+				style = style.Background(s.Thm.AccentDim).Width(contentWidth)
+			}
+			rows = append(rows, style.Render(text))
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, mutedStyle.Render(fmt.Sprintf("No %s changes.", strings.ToLower(kind))))
+	}
+
+	start := 0
+	if cursorRow >= maxVisible {
+		start = cursorRow - maxVisible + 1
+	}
+	end := min(len(rows), start+maxVisible)
+	lines := append([]string{}, rows[start:end]...)
+	for len(lines) < maxVisible {
+		lines = append(lines, "")
+	}
+
+	status := ""
+	if s.ErrorMsg != "" {
+		status = delStyle.Render(ansi.Truncate(s.ErrorMsg, contentWidth, ""))
+	} else if s.Anchor >= 0 {
+		status = selectedStyle.Render(fmt.Sprintf("Selecting lines %d-%d", from+1, to+1))
+	}
+
+	footerHelp := "Space stage • v select lines • d discard • Tab staged • n/p hunk • j/k move • q close"
+	if s.Staged {
+		footerHelp = "Space unstage • v select lines • Tab unstaged • n/p hunk • j/k move • q close"
+	}
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(title),
+		strings.Join(lines, "\n"),
+		status,
+		footerStyle.Render(footerHelp),
+	)
+	return boxStyle.Render(content)
+}
@@ -0,0 +1,92 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testFileDiff() *models.FileDiff {
+	return &models.FileDiff{
+		Header: []string{"diff --git a/f b/f", "--- a/f", "+++ b/f"},
+		Hunks: []models.DiffHunk{
+			{OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3, Lines: []string{" one", "-two", "+TWO", " three"}},
+			{OldStart: 9, OldLines: 2, NewStart: 9, NewLines: 3, Lines: []string{" nine", "+nine and a half", "+nine and three quarters", " ten"}},
+		},
+	}
+}
+
+func TestHunkScreenNavigationAndSelection(t *testing.T) {
+	s := NewHunkScreen("f", false, testFileDiff(), 120, 40, theme.Dracula())
+	if s.Hunk != 0 || s.Line != 1 {
+		t.Fatalf("expected cursor on the first change, got hunk %d line %d", s.Hunk, s.Line)
+	}
+
+	var applied []int
+	s.OnApply = func(hunk, from, to int) tea.Cmd {
+		applied = []int{hunk, from, to}
+		return nil
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	if len(applied) != 3 || applied[0] != 0 || applied[1] != 0 || applied[2] != 3 {
+		t.Fatalf("expected the whole first hunk, got %v", applied)
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if s.Hunk != 1 || s.Line != 1 {
+		t.Fatalf("expected the first change of the second hunk, got hunk %d line %d", s.Hunk, s.Line)
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if s.Hunk != 1 || s.Line != 3 {
+		t.Fatalf("expected the selection to stay in its hunk, got hunk %d line %d", s.Hunk, s.Line)
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if applied[0] != 1 || applied[1] != 1 || applied[2] != 2 {
+		t.Fatalf("expected lines 1-2 of the second hunk, got %v", applied)
+	}
+	if !strings.Contains(s.View(), "Selecting lines 2-3") {
+		t.Fatal("expected the selection in the view")
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if s.Anchor != -1 {
+		t.Fatal("expected esc to clear the selection first")
+	}
+	if scr, _ := s.Update(tea.KeyMsg{Type: tea.KeyEsc}); scr != nil {
+		t.Fatal("expected esc to close the screen without a selection")
+	}
+}
+
+func TestHunkScreenSetDiffKeepsPosition(t *testing.T) {
+	s := NewHunkScreen("f", true, testFileDiff(), 120, 40, theme.Dracula())
+	discarded := false
+	s.OnDiscard = func(int, int, int) tea.Cmd {
+		discarded = true
+		return nil
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if discarded {
+		t.Fatal("staged changes cannot be discarded")
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	diff := testFileDiff()
+	diff.Hunks = diff.Hunks[:1]
+	s.SetDiff(diff)
+	if s.Hunk != 0 || s.Line != 1 {
+		t.Fatalf("expected the cursor clamped to the remaining change, got hunk %d line %d", s.Hunk, s.Line)
+	}
+
+	s.SetDiff(&models.FileDiff{})
+	if !strings.Contains(s.View(), "No staged changes.") {
+		t.Fatal("expected an empty view")
+	}
+}
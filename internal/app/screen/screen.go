@@ -42,6 +42,9 @@ const (
 	TypeCommitFiles
 	TypeChecklist
 	TypeTaskboard
+	TypeHunks
+	TypeConflicts
+	TypeConflictMatrix
 )
 
 // String returns a human-readable name for the screen type.
@@ -85,6 +88,12 @@ func (t Type) String() string {
 		return "checklist"
 	case TypeTaskboard:
 		return "taskboard"
+	case TypeHunks:
+		return "hunks"
+	case TypeConflicts:
+		return "conflicts"
+	case TypeConflictMatrix:
+		return "conflict-matrix"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// HealthIssueKind identifies a problem found by ScanWorktreeHealth.
+type HealthIssueKind string
+
+// Health issue kinds.
+const (
+	HealthOrphanedDir     HealthIssueKind = "orphaned"
+	HealthBrokenGitdir    HealthIssueKind = "broken-gitdir"
+	HealthPrunable        HealthIssueKind = "prunable"
+	HealthDanglingSymlink HealthIssueKind = "dangling-symlink"
+)
+
+var healthKindOrder = map[HealthIssueKind]int{
+	HealthBrokenGitdir:    0,
+	HealthOrphanedDir:     1,
+	HealthDanglingSymlink: 2,
+	HealthPrunable:        3,
+}
+
+// HealthFix is a remedy for a health issue.
+type HealthFix string
+
+// Health fixes.
+const (
+	HealthFixRepair HealthFix = "repair" // git worktree repair <path>
+	HealthFixPrune  HealthFix = "prune"  // git worktree prune
+	HealthFixAdopt  HealthFix = "adopt"  // register the directory as a worktree, keeping its files
+	HealthFixRemove HealthFix = "remove" // delete the directory or symlink
+)
+
+// HealthIssue is a problem with a worktree or a directory of the worktree dir.
+type HealthIssue struct {
+	Kind   HealthIssueKind
+	Path   string
+	Detail string
+	Fixes  []HealthFix // Fixes[0] is the default
+}
+
+// DefaultFix returns the fix applied when none is chosen.
+func (i HealthIssue) DefaultFix() HealthFix {
+	if len(i.Fixes) == 0 {
+		return ""
+	}
+	return i.Fixes[0]
+}
+
+// HasFix reports whether fix applies to the issue.
+func (i HealthIssue) HasFix(fix HealthFix) bool {
+	for _, f := range i.Fixes {
+		if f == fix {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanWorktreeHealth checks the worktrees reported by git and the directories
+// of repoWorktreeDir, the root of the worktree layout, which is left empty
+// when the layout shares it with other directories. It flags directories git
+// does not know about, .git files whose gitdir does not resolve, prunable entries
+// and dangling top-level symlinks such as those left by link_topsymlinks.
+// Issues are ordered so that applying their fixes in order is safe: repairs
+// come first, since git worktree prune would drop the records they restore.
+func ScanWorktreeHealth(worktrees []*models.WorktreeInfo, repoWorktreeDir string) []HealthIssue {
+	known := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		known[normalizeHealthPath(wt.Path)] = true
+	}
+	issues, movedFrom := scanWorktreeDir(repoWorktreeDir, known)
+
+	var mainPath string
+	for _, wt := range worktrees {
+		if wt.IsMain {
+			mainPath = wt.Path
+		}
+	}
+
+	for _, wt := range worktrees {
+		if wt.Prunable {
+			if movedFrom[filepath.Clean(wt.Path)] {
+				continue
+			}
+			detail := wt.PrunableReason
+			if detail == "" {
+				detail = "worktree directory is missing"
+			}
+			issues = append(issues, HealthIssue{Kind: HealthPrunable, Path: wt.Path, Detail: detail, Fixes: []HealthFix{HealthFixPrune}})
+			continue
+		}
+		if wt.IsMain {
+			continue
+		}
+		if detail := checkWorktreeGitFile(wt.Path); detail != "" {
+			issues = append(issues, HealthIssue{Kind: HealthBrokenGitdir, Path: wt.Path, Detail: detail, Fixes: []HealthFix{HealthFixRepair}})
+		}
+		issues = append(issues, findDanglingSymlinks(wt.Path, mainPath)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return healthKindOrder[issues[i].Kind] < healthKindOrder[issues[j].Kind]
+		}
+		return issues[i].Path < issues[j].Path
+	})
+	return issues
+}
+
+// scanWorktreeDir flags the directories of repoWorktreeDir that are not known
+// worktrees. A directory moved there by hand is repaired rather than adopted,
+// and repairing it also clears the prunable entry of its old location, which
+// is returned in movedFrom.
+func scanWorktreeDir(repoWorktreeDir string, known map[string]bool) ([]HealthIssue, map[string]bool) {
+	var issues []HealthIssue
+	movedFrom := make(map[string]bool)
+	entries, err := os.ReadDir(repoWorktreeDir)
+	if err != nil {
+		return nil, movedFrom
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(repoWorktreeDir, entry.Name())
+		if known[normalizeHealthPath(path)] || ContainsWorktree(path, known) {
+			continue
+		}
+		if recorded := registeredElsewhere(path); recorded != "" {
+			movedFrom[filepath.Clean(recorded)] = true
+			issues = append(issues, HealthIssue{
+				Kind:   HealthBrokenGitdir,
+				Path:   path,
+				Detail: fmt.Sprintf("moved by hand; git still records %s", recorded),
+				Fixes:  []HealthFix{HealthFixRepair},
+			})
+			continue
+		}
+		issues = append(issues, HealthIssue{
+			Kind:   HealthOrphanedDir,
+			Path:   path,
+			Detail: "not in git worktree list",
+			Fixes:  []HealthFix{HealthFixAdopt, HealthFixRemove},
+		})
+	}
+	return issues, movedFrom
+}
+
+// readGitdir returns the gitdir a worktree .git file points to.
+func readGitdir(worktreePath string) (string, error) {
+	// #nosec G304 -- .git file of a worktree
+	data, err := os.ReadFile(filepath.Join(worktreePath, ".git"))
+	if err != nil {
+		return "", err
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("not a gitdir file")
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(worktreePath, gitdir)
+	}
+	return gitdir, nil
+}
+
+// checkWorktreeGitFile describes what is wrong with the .git file of a
+// linked worktree, or returns an empty string.
+func checkWorktreeGitFile(worktreePath string) string {
+	info, err := os.Lstat(filepath.Join(worktreePath, ".git"))
+	if err != nil {
+		return ".git file is missing"
+	}
+	if info.IsDir() {
+		return ""
+	}
+	gitdir, err := readGitdir(worktreePath)
+	if err != nil {
+		return ".git file is not a gitdir pointer"
+	}
+	if _, err := os.Stat(gitdir); err != nil {
+		return fmt.Sprintf("gitdir %s does not exist", gitdir)
+	}
+	return ""
+}
+
+// registeredElsewhere returns the path git records for a directory whose .git
+// file points to a live administrative directory, as happens when a worktree
+// is moved without git worktree move.
+func registeredElsewhere(path string) string {
+	gitdir, err := readGitdir(path)
+	if err != nil {
+		return ""
+	}
+	// #nosec G304 -- gitdir file inside the repository administrative directory
+	data, err := os.ReadFile(filepath.Join(gitdir, "gitdir"))
+	if err != nil {
+		return ""
+	}
+	recorded := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(recorded) {
+		recorded = filepath.Join(gitdir, recorded)
+	}
+	return filepath.Dir(recorded)
+}
+
+// findDanglingSymlinks reports broken symlinks at the top level of a worktree
+// and in .claude that point into the main worktree, as link_topsymlinks
+// creates them. Other links, such as those tracked in the repository, are left
+// alone since removing them would change the worktree.
+func findDanglingSymlinks(worktreePath, mainPath string) []HealthIssue {
+	if mainPath == "" {
+		return nil
+	}
+	mainPaths := []string{filepath.Clean(mainPath), normalizeHealthPath(mainPath)}
+	var issues []HealthIssue
+	for _, dir := range []string{worktreePath, filepath.Join(worktreePath, ".claude")} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+			target, _ := os.Readlink(path)
+			if !filepath.IsAbs(target) || !slices.ContainsFunc(mainPaths, func(main string) bool {
+				rel, ok := relativePathWithin(main, target)
+				return ok && rel != "."
+			}) {
+				continue
+			}
+			issues = append(issues, HealthIssue{
+				Kind:   HealthDanglingSymlink,
+				Path:   path,
+				Detail: fmt.Sprintf("points to missing %s", target),
+				Fixes:  []HealthFix{HealthFixRemove},
+			})
+		}
+	}
+	return issues
+}
+
+func normalizeHealthPath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return filepath.Clean(resolved)
+}
+
+// FixHealthIssue applies fix to issue. Fixes run from the current directory,
+// which must be inside the repository.
+func (s *worktreeService) FixHealthIssue(ctx context.Context, issue HealthIssue, fix HealthFix) error {
+	if !issue.HasFix(fix) {
+		return fmt.Errorf("%s does not apply to %s issues", fix, issue.Kind)
+	}
+
+	switch fix {
+	case HealthFixRepair:
+		if !s.git.RunCommandChecked(ctx, []string{"git", "worktree", "repair", issue.Path}, "", fmt.Sprintf("Failed to repair worktree %s", issue.Path)) {
+			return fmt.Errorf("failed to repair %s", issue.Path)
+		}
+	case HealthFixPrune:
+		if !s.git.RunCommandChecked(ctx, []string{"git", "worktree", "prune"}, "", "Failed to prune worktrees") {
+			return fmt.Errorf("failed to prune worktrees")
+		}
+	case HealthFixRemove:
+		if issue.Kind == HealthDanglingSymlink {
+			return os.Remove(issue.Path)
+		}
+		return os.RemoveAll(issue.Path)
+	case HealthFixAdopt:
+		return s.adoptDirectory(ctx, issue.Path)
+	}
+	return nil
+}
+
+// adoptDirectory registers an orphaned directory as a worktree without
+// touching its files. The branch named after the directory is checked out
+// when it exists and is free, otherwise the worktree is detached at HEAD;
+// either way the files show up as local changes against that commit.
+func (s *worktreeService) adoptDirectory(ctx context.Context, path string) error {
+	aside := path + ".lazyworktree-adopt"
+	if err := os.Rename(path, aside); err != nil {
+		return fmt.Errorf("failed to move %s aside: %w", path, err)
+	}
+
+	branch := filepath.Base(path)
+	if _, err := s.git.RunGitWithCombinedOutput(ctx, []string{"git", "worktree", "add", "--no-checkout", path, branch}, "", nil); err != nil {
+		if out, err := s.git.RunGitWithCombinedOutput(ctx, []string{"git", "worktree", "add", "--no-checkout", "--detach", path, "HEAD"}, "", nil); err != nil {
+			_ = os.RemoveAll(path)
+			if renameErr := os.Rename(aside, path); renameErr != nil {
+				return fmt.Errorf("failed to adopt %s: %s; files left in %s", path, strings.TrimSpace(string(out)), aside)
+			}
+			return fmt.Errorf("failed to adopt %s: %s", path, strings.TrimSpace(string(out)))
+		}
+	}
+
+	entries, err := os.ReadDir(aside)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", aside, err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == ".git" {
+			continue
+		}
+		if err := os.Rename(filepath.Join(aside, entry.Name()), filepath.Join(path, entry.Name())); err != nil {
+			return fmt.Errorf("failed to move %s back: %w; remaining files are in %s", entry.Name(), err, aside)
+		}
+	}
+	if err := os.RemoveAll(aside); err != nil {
+		return err
+	}
+
+	// Populate the index so untouched files do not show as deleted and re-added.
+	if out, err := s.git.RunGitWithCombinedOutput(ctx, []string{"git", "reset", "--quiet"}, path, nil); err != nil {
+		return fmt.Errorf("adopted %s but failed to reset the index: %s", path, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
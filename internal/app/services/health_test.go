@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func runHealthGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// healthRepo creates a repository at <tmp>/repo with worktrees in
+// <tmp>/worktrees/repo and chdirs into it.
+func healthRepo(t *testing.T) (*git.Service, string, string) {
+	t.Helper()
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(root, "repo")
+	wtDir := filepath.Join(root, "worktrees", "repo")
+	if err := os.MkdirAll(wtDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	runHealthGit(t, root, "init", "-q", "-b", "main", repo)
+	if err := os.WriteFile(filepath.Join(repo, "README"), []byte("hello\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	runHealthGit(t, repo, "add", "README")
+	runHealthGit(t, repo, "commit", "-q", "-m", "init")
+	t.Chdir(repo)
+
+	svc := git.NewService(func(string, string) {}, func(string, string, string) {})
+	return svc, repo, wtDir
+}
+
+func healthIssuesByKind(issues []HealthIssue) map[HealthIssueKind][]HealthIssue {
+	byKind := make(map[HealthIssueKind][]HealthIssue)
+	for _, issue := range issues {
+		byKind[issue.Kind] = append(byKind[issue.Kind], issue)
+	}
+	return byKind
+}
+
+func TestScanWorktreeHealth(t *testing.T) {
+	svc, repo, wtDir := healthRepo(t)
+	ctx := context.Background()
+
+	healthy := filepath.Join(wtDir, "healthy")
+	runHealthGit(t, repo, "worktree", "add", "-q", "-b", "healthy", healthy)
+	if err := os.Symlink(filepath.Join(repo, "gone.env"), filepath.Join(healthy, ".env")); err != nil {
+		t.Fatal(err)
+	}
+	// A dangling link tracked in the repository is part of the worktree.
+	if err := os.Symlink("missing-target", filepath.Join(healthy, "tracked-link")); err != nil {
+		t.Fatal(err)
+	}
+	runHealthGit(t, healthy, "add", "tracked-link")
+	runHealthGit(t, healthy, "commit", "-q", "-m", "tracked link")
+
+	// Moved by hand from outside worktree_dir.
+	outside := filepath.Join(filepath.Dir(repo), "outside")
+	runHealthGit(t, repo, "worktree", "add", "-q", "-b", "moved", outside)
+	moved := filepath.Join(wtDir, "moved")
+	if err := os.Rename(outside, moved); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted by hand.
+	deleted := filepath.Join(wtDir, "deleted")
+	runHealthGit(t, repo, "worktree", "add", "-q", "-b", "deleted", deleted)
+	if err := os.RemoveAll(deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	orphan := filepath.Join(wtDir, "orphan")
+	if err := os.MkdirAll(orphan, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(orphan, "notes.txt"), []byte("keep me\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees, err := svc.GetWorktrees(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKind := healthIssuesByKind(ScanWorktreeHealth(worktrees, wtDir))
+
+	if got := byKind[HealthDanglingSymlink]; len(got) != 1 || got[0].Path != filepath.Join(healthy, ".env") {
+		t.Fatalf("expected dangling .env symlink, got %+v", got)
+	}
+	if got := byKind[HealthBrokenGitdir]; len(got) != 1 || got[0].Path != moved || !strings.Contains(got[0].Detail, outside) {
+		t.Fatalf("expected moved worktree to need a repair, got %+v", got)
+	}
+	// The old location of the moved worktree is cleared by the repair.
+	if got := byKind[HealthPrunable]; len(got) != 1 || got[0].Path != deleted {
+		t.Fatalf("expected only the deleted worktree to be prunable, got %+v", got)
+	}
+	if got := byKind[HealthOrphanedDir]; len(got) != 1 || got[0].Path != orphan || got[0].DefaultFix() != HealthFixAdopt {
+		t.Fatalf("expected orphan with adopt as default fix, got %+v", got)
+	}
+
+	wtSvc := NewWorktreeService(svc)
+	for _, issue := range ScanWorktreeHealth(worktrees, wtDir) {
+		if err := wtSvc.FixHealthIssue(ctx, issue, issue.DefaultFix()); err != nil {
+			t.Fatalf("fixing %+v: %v", issue, err)
+		}
+	}
+
+	worktrees, err = svc.GetWorktrees(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := ScanWorktreeHealth(worktrees, wtDir); len(issues) != 0 {
+		t.Fatalf("expected no issues after fixing, got %+v", issues)
+	}
+	if status := runHealthGit(t, healthy, "status", "--porcelain"); status != "" {
+		t.Fatalf("expected the tracked dangling link to be kept, got status %q", status)
+	}
+	paths := make(map[string]*models.WorktreeInfo)
+	for _, wt := range worktrees {
+		paths[wt.Path] = wt
+	}
+	if _, ok := paths[moved]; !ok {
+		t.Fatalf("expected repaired worktree at %s, got %v", moved, paths)
+	}
+	if _, ok := paths[orphan]; !ok {
+		t.Fatalf("expected adopted worktree at %s, got %v", orphan, paths)
+	}
+	data, err := os.ReadFile(filepath.Join(orphan, "notes.txt"))
+	if err != nil || string(data) != "keep me\n" {
+		t.Fatalf("expected adopted files to be kept, got %q, %v", data, err)
+	}
+	// No branch is named after the directory, so it is detached at HEAD and
+	// its content shows as changes against that commit.
+	if !paths[orphan].Detached {
+		t.Fatalf("expected adopted worktree to be detached, got %+v", paths[orphan])
+	}
+	if status := runHealthGit(t, orphan, "status", "--porcelain"); status != "D README\n?? notes.txt" {
+		t.Fatalf("unexpected status of adopted worktree: %q", status)
+	}
+}
+
+func TestFixHealthIssueRejectsUnofferedFix(t *testing.T) {
+	wtSvc := NewWorktreeService(nil)
+	issue := HealthIssue{Kind: HealthPrunable, Path: "/tmp/x", Fixes: []HealthFix{HealthFixPrune}}
+	if err := wtSvc.FixHealthIssue(context.Background(), issue, HealthFixRemove); err == nil {
+		t.Fatal("expected an error for a fix the issue does not offer")
+	}
+}
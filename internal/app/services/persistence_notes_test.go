@@ -390,3 +390,59 @@ func TestMigrateRepoNotesToSharedFileNoSharedPath(t *testing.T) {
 		t.Fatalf("expected 0 migrated notes, got %d", n)
 	}
 }
+
+func TestLoadAndDeleteWorktreeNote(t *testing.T) {
+	worktreeDir := t.TempDir()
+	repoKey := "org/repo"
+	sharedPath := filepath.Join(t.TempDir(), "notes.json")
+	wtPath := filepath.Join(worktreeDir, "org", "repo", "feature")
+
+	if _, ok, err := LoadWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath); err != nil || ok {
+		t.Fatalf("expected no note, got ok=%v err=%v", ok, err)
+	}
+
+	if err := SaveWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath, "shared note"); err != nil {
+		t.Fatalf("save note failed: %v", err)
+	}
+	note, ok, err := LoadWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath)
+	if err != nil || !ok {
+		t.Fatalf("expected note, got ok=%v err=%v", ok, err)
+	}
+	if note.Note != "shared note" {
+		t.Fatalf("unexpected note text: %q", note.Note)
+	}
+
+	if err := DeleteWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath); err != nil {
+		t.Fatalf("delete note failed: %v", err)
+	}
+	if _, ok, _ := LoadWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath); ok {
+		t.Fatal("expected note to be deleted")
+	}
+	if _, err := os.Stat(sharedPath); !os.IsNotExist(err) {
+		t.Fatalf("expected shared notes file to be removed, got err=%v", err)
+	}
+}
+
+func TestLoadWorktreeNoteLegacyAbsoluteKey(t *testing.T) {
+	worktreeDir := t.TempDir()
+	repoKey := "repo"
+	sharedPath := filepath.Join(t.TempDir(), "notes.json")
+	wtPath := filepath.Join(worktreeDir, "repo", "feature")
+
+	legacy := map[string]models.WorktreeNote{wtPath: {Note: "legacy", UpdatedAt: 1}}
+	if err := SaveWorktreeNotes(repoKey, worktreeDir, sharedPath, legacy); err != nil {
+		t.Fatalf("save notes failed: %v", err)
+	}
+
+	note, ok, err := LoadWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath)
+	if err != nil || !ok || note.Note != "legacy" {
+		t.Fatalf("expected legacy note, got %#v ok=%v err=%v", note, ok, err)
+	}
+
+	if err := DeleteWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath); err != nil {
+		t.Fatalf("delete note failed: %v", err)
+	}
+	if _, ok, _ := LoadWorktreeNote(repoKey, worktreeDir, sharedPath, wtPath); ok {
+		t.Fatal("expected legacy note to be deleted")
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/chmouel/lazyworktree/internal/git"
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
@@ -24,6 +26,9 @@ type WorktreeService interface {
 	// Rename moves a worktree and conditionally renames its branch.
 	Rename(ctx context.Context, oldPath, newPath, oldBranch, newBranch string) error
 
+	// Move moves a worktree to another directory, keeping its branch.
+	Move(ctx context.Context, oldPath, newPath string) error
+
 	// Push pushes a worktree's branch to its upstream.
 	Push(ctx context.Context, wt *models.WorktreeInfo, args []string, env map[string]string) (string, error)
 
@@ -33,12 +38,22 @@ type WorktreeService interface {
 	// UpdateFromBase updates a branch from its PR base branch.
 	UpdateFromBase(ctx context.Context, wt *models.WorktreeInfo, mergeMethod string, env map[string]string) (string, error)
 
-	// Absorb merges or rebases a worktree into the main branch.
-	Absorb(ctx context.Context, wt *models.WorktreeInfo, mainWorktree *models.WorktreeInfo, mergeMethod string) error
+	// UpdateFromBaseLocal updates a branch from baseBranch, or its recorded
+	// base branch when empty, with git alone, without a forge CLI.
+	UpdateFromBaseLocal(ctx context.Context, wt *models.WorktreeInfo, baseBranch, mergeMethod string, env map[string]string) (string, error)
+
+	// Absorb merges or rebases a worktree into the branch checked out in
+	// target, usually its base branch.
+	Absorb(ctx context.Context, wt *models.WorktreeInfo, target *models.WorktreeInfo, mergeMethod string) error
 
-	// GetPruneCandidates identifies worktrees that have been merged and are candidates for pruning.
+	// GetPruneCandidates identifies worktrees that have been merged into their
+	// base branch and are candidates for pruning. Locked worktrees are never
+	// candidates.
 	GetPruneCandidates(ctx context.Context, worktrees []*models.WorktreeInfo) ([]PruneCandidate, error)
 
+	// FixHealthIssue applies one of the fixes offered by a health issue.
+	FixHealthIssue(ctx context.Context, issue HealthIssue, fix HealthFix) error
+
 	// ExecuteCommands runs a list of shell commands in the specified directory.
 	ExecuteCommands(ctx context.Context, commands []string, cwd string, env map[string]string) error
 }
@@ -212,32 +227,86 @@ func (s *worktreeService) UpdateFromBase(ctx context.Context, wt *models.Worktre
 	return strings.TrimSpace(string(out)), err
 }
 
-func (s *worktreeService) Absorb(ctx context.Context, wt, mainWorktree *models.WorktreeInfo, mergeMethod string) error {
-	mainBranch := s.git.GetMainBranch(ctx)
-	mainPath := mainWorktree.Path
+func (s *worktreeService) UpdateFromBaseLocal(ctx context.Context, wt *models.WorktreeInfo, baseBranch, mergeMethod string, env map[string]string) (string, error) {
+	baseBranch = strings.TrimSpace(baseBranch)
+	if baseBranch == "" {
+		baseBranch = BaseBranch(ctx, s.git, wt)
+	}
+
+	// Fetch the base so the update is against the remote, not a stale local branch
+	var outputs []string
+	baseRef := baseBranch
+	if remote, branch := s.baseRemote(ctx, wt, baseBranch); remote != "" {
+		out, err := s.git.RunGitWithCombinedOutput(ctx, []string{"git", "fetch", remote, branch}, wt.Path, env)
+		outputs = append(outputs, strings.TrimSpace(string(out)))
+		if err != nil {
+			return strings.TrimSpace(strings.Join(outputs, "\n")), fmt.Errorf("failed to fetch %s from %s: %w", branch, remote, err)
+		}
+		baseRef = remote + "/" + branch
+	}
 
+	args := []string{"git", "merge", "--no-edit", baseRef}
 	if mergeMethod == "rebase" {
-		// Rebase: first rebase the feature branch onto main, then fast-forward main
-		if !s.git.RunCommandChecked(ctx, []string{"git", "-C", wt.Path, "rebase", mainBranch}, "", fmt.Sprintf("Failed to rebase %s onto %s", wt.Branch, mainBranch)) {
+		args = []string{"git", "rebase", baseRef}
+	}
+	out, err := s.git.RunGitWithCombinedOutput(ctx, args, wt.Path, env)
+	outputs = append(outputs, strings.TrimSpace(string(out)))
+	output := strings.TrimSpace(strings.Join(outputs, "\n"))
+	if err != nil {
+		// A conflicting rebase or merge is left in progress to be resolved or aborted
+		if operation := git.InProgressOperation(wt.Path); operation != "" {
+			return output, fmt.Errorf("%s of %s stopped on conflicts in %s; resolve them and continue, or run git %s --abort", operation, baseRef, wt.Path, operation)
+		}
+		return output, fmt.Errorf("%s %s failed: %w", args[1], baseRef, err)
+	}
+	return output, nil
+}
+
+// baseRemote returns the remote and branch to fetch base from. A
+// remote-tracking base such as upstream/main names both; otherwise the remote
+// is origin when it exists, then the remote of the branch upstream, then any
+// remote. The remote is empty for repositories without remotes.
+func (s *worktreeService) baseRemote(ctx context.Context, wt *models.WorktreeInfo, base string) (string, string) {
+	remotes := strings.Fields(s.git.RunGit(ctx, []string{"git", "remote"}, wt.Path, []int{0}, true, true))
+	if remote, branch, ok := strings.Cut(base, "/"); ok && slices.Contains(remotes, remote) {
+		return remote, branch
+	}
+	if slices.Contains(remotes, "origin") {
+		return "origin", base
+	}
+	if remote, _, ok := strings.Cut(wt.UpstreamBranch, "/"); ok && slices.Contains(remotes, remote) {
+		return remote, base
+	}
+	if len(remotes) > 0 {
+		return remotes[0], base
+	}
+	return "", base
+}
+
+func (s *worktreeService) Absorb(ctx context.Context, wt, target *models.WorktreeInfo, mergeMethod string) error {
+	targetBranch := target.Branch
+	targetPath := target.Path
+
+	if mergeMethod == "rebase" {
+		// Rebase: first rebase the feature branch onto the target, then fast-forward the target
+		if !s.git.RunCommandChecked(ctx, []string{"git", "-C", wt.Path, "rebase", targetBranch}, "", fmt.Sprintf("Failed to rebase %s onto %s", wt.Branch, targetBranch)) {
 			return fmt.Errorf("rebase failed; resolve conflicts in %s and retry", wt.Path)
 		}
-		// Fast-forward main to the rebased branch
-		if !s.git.RunCommandChecked(ctx, []string{"git", "-C", mainPath, "merge", "--ff-only", wt.Branch}, "", fmt.Sprintf("Failed to fast-forward %s to %s", mainBranch, wt.Branch)) {
+		// Fast-forward the target to the rebased branch
+		if !s.git.RunCommandChecked(ctx, []string{"git", "-C", targetPath, "merge", "--ff-only", wt.Branch}, "", fmt.Sprintf("Failed to fast-forward %s to %s", targetBranch, wt.Branch)) {
 			return fmt.Errorf("fast-forward failed; the branch may have diverged")
 		}
-	} else if !s.git.RunCommandChecked(ctx, []string{"git", "-C", mainPath, "merge", "--no-edit", wt.Branch}, "", fmt.Sprintf("Failed to merge %s into %s", wt.Branch, mainBranch)) {
-		return fmt.Errorf("merge failed; resolve conflicts in %s and retry", mainPath)
+	} else if !s.git.RunCommandChecked(ctx, []string{"git", "-C", targetPath, "merge", "--no-edit", wt.Branch}, "", fmt.Sprintf("Failed to merge %s into %s", wt.Branch, targetBranch)) {
+		return fmt.Errorf("merge failed; resolve conflicts in %s and retry", targetPath)
 	}
 
 	return nil
 }
 
 func (s *worktreeService) GetPruneCandidates(ctx context.Context, worktrees []*models.WorktreeInfo) ([]PruneCandidate, error) {
-	mainBranch := s.git.GetMainBranch(ctx)
-
 	wtBranches := make(map[string]*models.WorktreeInfo)
 	for _, wt := range worktrees {
-		if !wt.IsMain {
+		if !wt.IsMain && !wt.Locked {
 			wtBranches[wt.Branch] = wt
 		}
 	}
@@ -246,7 +315,7 @@ func (s *worktreeService) GetPruneCandidates(ctx context.Context, worktrees []*m
 
 	// 1. PR-based detection
 	for _, wt := range worktrees {
-		if wt.IsMain {
+		if wt.IsMain || wt.Locked {
 			continue
 		}
 		if wt.PR != nil && strings.EqualFold(wt.PR.State, "MERGED") {
@@ -254,16 +323,13 @@ func (s *worktreeService) GetPruneCandidates(ctx context.Context, worktrees []*m
 		}
 	}
 
-	// 2. Git-based detection
-	mergedBranches := s.git.GetMergedBranches(ctx, mainBranch)
-	for _, branch := range mergedBranches {
-		if wt, exists := wtBranches[branch]; exists {
-			if existing, found := candidateMap[branch]; found {
-				existing.Source = "both"
-				candidateMap[branch] = existing
-			} else {
-				candidateMap[branch] = PruneCandidate{Worktree: wt, Source: "git"}
-			}
+	// 2. Git-based detection, against the base branch of each worktree
+	for _, branch := range MergedIntoBase(ctx, s.git, wtBranches) {
+		if existing, found := candidateMap[branch]; found {
+			existing.Source = "both"
+			candidateMap[branch] = existing
+		} else {
+			candidateMap[branch] = PruneCandidate{Worktree: wtBranches[branch], Source: "git"}
 		}
 	}
 
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// baseGitService is the subset of git operations needed to resolve and check
+// the base branch of worktrees.
+type baseGitService interface {
+	RunGit(ctx context.Context, args []string, cwd string, okReturncodes []int, strip, silent bool) string
+	GetMainBranch(ctx context.Context) string
+	GetMergedBranches(ctx context.Context, baseBranch string) []string
+}
+
+// BaseBranch returns the branch wt is integrated into: the ref it was created
+// from when that is a local or remote-tracking branch, otherwise the main
+// branch. Tags, commits and the upstream of the branch itself are only starting
+// points and fall back to main.
+func BaseBranch(ctx context.Context, git baseGitService, wt *models.WorktreeInfo) string {
+	if base := strings.TrimSpace(wt.BaseRef); base != "" && base != wt.Branch && base != wt.UpstreamBranch {
+		for _, ref := range []string{"refs/heads/" + base, "refs/remotes/" + base} {
+			if git.RunGit(ctx, []string{"git", "rev-parse", "--verify", "--quiet", ref}, "", []int{0, 1}, true, true) != "" {
+				return base
+			}
+		}
+	}
+	return git.GetMainBranch(ctx)
+}
+
+// BaseWorktree returns the worktree absorbing, based on base, is absorbed into:
+// the worktree with base checked out, or tracking it when base is a
+// remote-tracking branch, and the main worktree for the main branch. absorbing
+// itself is never returned, and the result is nil when base is not checked out
+// in another worktree.
+func BaseWorktree(worktrees []*models.WorktreeInfo, absorbing *models.WorktreeInfo, base, mainBranch string) *models.WorktreeInfo {
+	for _, wt := range worktrees {
+		if wt != absorbing && !wt.Detached && wt.Branch == base {
+			return wt
+		}
+	}
+	for _, wt := range worktrees {
+		if wt != absorbing && !wt.Detached && wt.UpstreamBranch == base {
+			return wt
+		}
+	}
+	if base == mainBranch {
+		for _, wt := range worktrees {
+			if wt != absorbing && wt.IsMain {
+				return wt
+			}
+		}
+	}
+	return nil
+}
+
+// MergedIntoBase returns the branches of worktrees, keyed by branch, that are
+// merged into their base branch. Worktrees sharing a base are checked with a
+// single git branch --merged.
+func MergedIntoBase(ctx context.Context, git baseGitService, worktrees map[string]*models.WorktreeInfo) []string {
+	byBase := make(map[string][]string)
+	for branch, wt := range worktrees {
+		base := BaseBranch(ctx, git, wt)
+		byBase[base] = append(byBase[base], branch)
+	}
+
+	var merged []string
+	for base, branches := range byBase {
+		mergedBranches := git.GetMergedBranches(ctx, base)
+		for _, branch := range branches {
+			if slices.Contains(mergedBranches, branch) {
+				merged = append(merged, branch)
+			}
+		}
+	}
+	slices.Sort(merged)
+	return merged
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+type fakeBaseGit struct {
+	refs   map[string]bool
+	merged map[string][]string
+	calls  []string
+}
+
+func (f *fakeBaseGit) RunGit(_ context.Context, args []string, _ string, _ []int, _, _ bool) string {
+	if f.refs[args[len(args)-1]] {
+		return "abc123"
+	}
+	return ""
+}
+
+func (f *fakeBaseGit) GetMainBranch(context.Context) string {
+	return "main"
+}
+
+func (f *fakeBaseGit) GetMergedBranches(_ context.Context, baseBranch string) []string {
+	f.calls = append(f.calls, baseBranch)
+	return f.merged[baseBranch]
+}
+
+func TestBaseBranch(t *testing.T) {
+	git := &fakeBaseGit{refs: map[string]bool{"refs/heads/release": true, "refs/remotes/upstream/next": true}}
+	for _, tc := range []struct {
+		baseRef, want string
+	}{
+		{"", "main"},
+		{"release", "release"},
+		{"upstream/next", "upstream/next"},
+		{"v1.0.0", "main"},
+		{"feature", "main"},
+		{"origin/feature", "main"},
+	} {
+		wt := &models.WorktreeInfo{Branch: "feature", UpstreamBranch: "origin/feature", BaseRef: tc.baseRef}
+		if got := BaseBranch(context.Background(), git, wt); got != tc.want {
+			t.Errorf("BaseBranch(%q) = %q, want %q", tc.baseRef, got, tc.want)
+		}
+	}
+}
+
+func TestBaseWorktree(t *testing.T) {
+	main := &models.WorktreeInfo{Path: "/repo", Branch: "main", IsMain: true}
+	release := &models.WorktreeInfo{Path: "/wt/release", Branch: "release-work", UpstreamBranch: "origin/release"}
+	worktrees := []*models.WorktreeInfo{main, release}
+
+	if got := BaseWorktree(worktrees, release, "main", "main"); got != main {
+		t.Fatalf("expected the main worktree, got %+v", got)
+	}
+	if got := BaseWorktree(worktrees, nil, "origin/release", "main"); got != release {
+		t.Fatalf("expected the worktree tracking origin/release, got %+v", got)
+	}
+	if got := BaseWorktree(worktrees, release, "origin/release", "main"); got != nil {
+		t.Fatalf("expected the absorbed worktree to be skipped although it tracks its base, got %+v", got)
+	}
+	if got := BaseWorktree(worktrees, nil, "next", "main"); got != nil {
+		t.Fatalf("expected no worktree for a base not checked out, got %+v", got)
+	}
+}
+
+func TestMergedIntoBase(t *testing.T) {
+	git := &fakeBaseGit{
+		refs: map[string]bool{"refs/heads/release": true},
+		merged: map[string][]string{
+			"main":    {"main", "done", "fix"},
+			"release": {"release", "backport"},
+		},
+	}
+	worktrees := map[string]*models.WorktreeInfo{
+		"done":     {Branch: "done"},
+		"active":   {Branch: "active"},
+		"fix":      {Branch: "fix", BaseRef: "release"},
+		"backport": {Branch: "backport", BaseRef: "release"},
+	}
+
+	got := MergedIntoBase(context.Background(), git, worktrees)
+	if strings.Join(got, ",") != "backport,done" {
+		t.Fatalf("expected backport and done merged into their base, got %v", got)
+	}
+	if len(git.calls) != 2 {
+		t.Fatalf("expected one git branch --merged per base, got %v", git.calls)
+	}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// layoutMarker stands in for the worktree name when working out the parts of
+// a template that do not depend on it.
+const layoutMarker = "\x00"
+
+// WorktreeLayout resolves the paths of the worktrees of a repository from
+// worktree_path_template. Without a template, worktrees live in
+// worktree_dir/<repoKey>/<name>.
+type WorktreeLayout struct {
+	Template    string // worktree_path_template, empty for the default layout
+	WorktreeDir string
+	RepoKey     string
+	MainPath    string // Path of the main worktree, relative templates start there
+	RemoteURL   string // URL of the origin remote, for {host}, {owner} and {repo}
+}
+
+// layoutGitService is the subset of git operations needed to load a layout.
+type layoutGitService interface {
+	GetMainWorktreePath(ctx context.Context) string
+	RunGit(ctx context.Context, args []string, cwd string, okReturncodes []int, strip, silent bool) string
+}
+
+// LoadWorktreeLayout returns the layout of the current repository. The main
+// worktree and origin remote are only looked up when a template is set.
+func LoadWorktreeLayout(ctx context.Context, git layoutGitService, template, worktreeDir, repoKey string) WorktreeLayout {
+	layout := WorktreeLayout{
+		Template:    strings.TrimSpace(template),
+		WorktreeDir: worktreeDir,
+		RepoKey:     repoKey,
+	}
+	if layout.Template == "" {
+		return layout
+	}
+	layout.MainPath = git.GetMainWorktreePath(ctx)
+	layout.RemoteURL = git.RunGit(ctx, []string{"git", "remote", "get-url", "origin"}, "", []int{0}, true, true)
+	return layout
+}
+
+// Path returns the path of the worktree called name checked out on branch.
+// An empty branch is replaced by name.
+func (l WorktreeLayout) Path(name, branch string) string {
+	if l.Template == "" {
+		return filepath.Join(l.WorktreeDir, l.RepoKey, name)
+	}
+	if branch == "" {
+		branch = name
+	}
+	template, err := utils.ExpandPath(l.Template)
+	if err != nil {
+		template = l.Template
+	}
+	host, owner, repo := parseRemoteURL(l.RemoteURL)
+	if repo == "" {
+		repo = strings.TrimSuffix(filepath.Base(l.MainPath), ".git")
+	}
+	path := strings.NewReplacer(
+		"{worktree_dir}", l.WorktreeDir,
+		"{repo_key}", l.RepoKey,
+		"{repo_parent}", filepath.Dir(l.MainPath),
+		"{repo}", repo,
+		"{host}", host,
+		"{owner}", owner,
+		"{branch}", branch,
+		"{name}", name,
+	).Replace(template)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(l.MainPath, path)
+	}
+	return filepath.Clean(path)
+}
+
+// RenamePath returns the path of the worktree at oldPath once renamed to
+// newName on newBranch. The default layout keeps the worktree in its current
+// directory, whether or not it lives in worktree_dir.
+func (l WorktreeLayout) RenamePath(oldPath, newName, newBranch string) string {
+	if l.Template == "" {
+		return filepath.Join(filepath.Dir(oldPath), newName)
+	}
+	return l.Path(newName, newBranch)
+}
+
+// Root returns the deepest directory shared by every worktree path. dedicated
+// reports whether the directory belongs to the worktrees of this repository
+// alone, each being a direct child of it named after the worktree or branch,
+// so that any other entry of the directory is a leftover.
+func (l WorktreeLayout) Root() (root string, dedicated bool) {
+	if l.Template == "" {
+		return filepath.Join(l.WorktreeDir, l.RepoKey), true
+	}
+	path := l.Path(layoutMarker, layoutMarker)
+	idx := strings.Index(path, layoutMarker)
+	prefix := path[:idx]
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		return filepath.Dir(prefix), false
+	}
+	root = filepath.Clean(prefix)
+
+	// A directory not named after the repository, or holding the main
+	// worktree, is shared with other checkouts.
+	fixed := l.Template
+	for _, placeholder := range []string{"{name}", "{branch}"} {
+		if before, _, found := strings.Cut(fixed, placeholder); found {
+			fixed = before
+		}
+	}
+	if !strings.Contains(fixed, "{repo}") && !strings.Contains(fixed, "{repo_key}") {
+		return root, false
+	}
+	if _, ok := relativePathWithin(root, l.MainPath); ok && l.MainPath != "" {
+		return root, false
+	}
+	return root, path[idx:] == layoutMarker
+}
+
+// Name returns the worktree name encoded in path, the reverse of Path. It
+// reports false when path does not follow the layout.
+func (l WorktreeLayout) Name(path string) (string, bool) {
+	path = filepath.Clean(path)
+	if l.Template == "" {
+		if filepath.Dir(path) != filepath.Join(l.WorktreeDir, l.RepoKey) {
+			return "", false
+		}
+		return filepath.Base(path), true
+	}
+
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(l.Path(layoutMarker, layoutMarker)), layoutMarker, "(.+)")
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return "", false
+	}
+	matches := re.FindStringSubmatch(path)
+	if len(matches) < 2 {
+		return "", false
+	}
+	for _, match := range matches[2:] {
+		if match != matches[1] {
+			return "", false
+		}
+	}
+	return matches[1], true
+}
+
+// Contains reports whether path follows the layout. Any path below
+// worktree_dir/<repoKey> follows the default layout.
+func (l WorktreeLayout) Contains(path string) bool {
+	if l.Template == "" {
+		_, ok := relativePathWithin(filepath.Join(l.WorktreeDir, l.RepoKey), path)
+		return ok
+	}
+	_, ok := l.Name(path)
+	return ok
+}
+
+// ContainsWorktree reports whether dir is a parent directory of one of the
+// known worktree paths, as happens when the layout nests worktrees by branch
+// name (feature/x).
+func ContainsWorktree(dir string, known map[string]bool) bool {
+	dir = normalizeHealthPath(dir)
+	for path := range known {
+		if rel, ok := relativePathWithin(dir, path); ok && rel != "." {
+			return true
+		}
+	}
+	return false
+}
+
+var scpRemoteRe = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// parseRemoteURL extracts the host, owner and repository name of a remote
+// URL, either a URL (https://host/owner/repo.git) or the scp-like form
+// (git@host:owner/repo.git). Local paths only yield a repository name.
+func parseRemoteURL(raw string) (host, owner, repo string) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", ""
+	}
+	var path string
+	if u, err := url.Parse(raw); err == nil && strings.Contains(raw, "://") {
+		host, path = u.Hostname(), u.Path
+	} else if m := scpRemoteRe.FindStringSubmatch(raw); m != nil && !filepath.IsAbs(raw) {
+		host, path = m[1], m[2]
+	} else {
+		path = raw
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	repo = filepath.Base(path)
+	if host != "" {
+		if dir := filepath.Dir(path); dir != "." {
+			owner = dir
+		}
+	}
+	return host, owner, repo
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeLayoutGit struct {
+	mainPath  string
+	remoteURL string
+	calls     int
+}
+
+func (f *fakeLayoutGit) GetMainWorktreePath(context.Context) string {
+	f.calls++
+	return f.mainPath
+}
+
+func (f *fakeLayoutGit) RunGit(_ context.Context, args []string, _ string, _ []int, _, _ bool) string {
+	f.calls++
+	if strings.Join(args, " ") == "git remote get-url origin" {
+		return f.remoteURL
+	}
+	return ""
+}
+
+func TestWorktreeLayoutPath(t *testing.T) {
+	base := WorktreeLayout{
+		WorktreeDir: "/wt",
+		RepoKey:     "owner/repo",
+		MainPath:    "/src/repo",
+		RemoteURL:   "git@github.com:owner/repo.git",
+	}
+	tests := []struct {
+		name     string
+		template string
+		wtName   string
+		branch   string
+		want     string
+	}{
+		{name: "default", wtName: "feat", branch: "feature/x", want: "/wt/owner/repo/feat"},
+		{name: "sibling directory", template: "{repo_parent}/{repo}.worktrees/{branch}", wtName: "feat", branch: "feature/x", want: "/src/repo.worktrees/feature/x"},
+		{name: "relative to the main worktree", template: "../{repo}-{name}", wtName: "feat", branch: "feature/x", want: "/src/repo-feat"},
+		{name: "host and owner", template: "/code/{host}/{owner}/{repo}/{name}", wtName: "feat", want: "/code/github.com/owner/repo/feat"},
+		{name: "repo key", template: "{worktree_dir}/{repo_key}/wt-{name}", wtName: "feat", want: "/wt/owner/repo/wt-feat"},
+		{name: "branch defaults to name", template: "{repo_parent}/{branch}", wtName: "feat", want: "/src/feat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := base
+			layout.Template = tt.template
+			if got := layout.Path(tt.wtName, tt.branch); got != filepath.FromSlash(tt.want) {
+				t.Fatalf("Path(%q, %q) = %q, want %q", tt.wtName, tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorktreeLayoutRepoWithoutRemote(t *testing.T) {
+	layout := WorktreeLayout{Template: "{repo_parent}/{repo}.worktrees/{name}", MainPath: "/src/project.git"}
+	if got := layout.Path("feat", ""); got != "/src/project.worktrees/feat" {
+		t.Fatalf("Path() = %q, want /src/project.worktrees/feat", got)
+	}
+}
+
+func TestWorktreeLayoutRoot(t *testing.T) {
+	tests := []struct {
+		name          string
+		template      string
+		wantRoot      string
+		wantDedicated bool
+	}{
+		{name: "default", wantRoot: "/wt/repo", wantDedicated: true},
+		{name: "named after branch", template: "{repo_parent}/{repo}.worktrees/{branch}", wantRoot: "/src/repo.worktrees", wantDedicated: true},
+		{name: "shared parent", template: "../{repo}-{name}", wantRoot: "/src", wantDedicated: false},
+		{name: "parent of the main worktree", template: "{repo_parent}/{branch}", wantRoot: "/src", wantDedicated: false},
+		{name: "not named after the repository", template: "{worktree_dir}/{name}", wantRoot: "/wt", wantDedicated: false},
+		{name: "suffixed component", template: "{worktree_dir}/{repo_key}/{name}/src", wantRoot: "/wt/repo", wantDedicated: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := WorktreeLayout{Template: tt.template, WorktreeDir: "/wt", RepoKey: "repo", MainPath: "/src/repo"}
+			root, dedicated := layout.Root()
+			if root != tt.wantRoot || dedicated != tt.wantDedicated {
+				t.Fatalf("Root() = %q, %v, want %q, %v", root, dedicated, tt.wantRoot, tt.wantDedicated)
+			}
+		})
+	}
+}
+
+func TestWorktreeLayoutName(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		want     string
+		wantOK   bool
+	}{
+		{name: "default", path: "/wt/repo/feat", want: "feat", wantOK: true},
+		{name: "default outside", path: "/elsewhere/feat", wantOK: false},
+		{name: "default nested", path: "/wt/repo/feat/sub", wantOK: false},
+		{name: "template", template: "../{repo}-{name}", path: "/src/repo-feat", want: "feat", wantOK: true},
+		{name: "nested branch", template: "{repo_parent}/{repo}.worktrees/{branch}", path: "/src/repo.worktrees/feature/x", want: "feature/x", wantOK: true},
+		{name: "template outside", template: "../{repo}-{name}", path: "/src/other-feat", wantOK: false},
+		{name: "repeated placeholder", template: "{repo_parent}/{name}/{branch}", path: "/src/a/b", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := WorktreeLayout{Template: tt.template, WorktreeDir: "/wt", RepoKey: "repo", MainPath: "/src/repo"}
+			got, ok := layout.Name(tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("Name(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWorktreeLayoutRenamePath(t *testing.T) {
+	layout := WorktreeLayout{WorktreeDir: "/wt", RepoKey: "repo", MainPath: "/src/repo"}
+	if got := layout.RenamePath("/elsewhere/old", "new", "new"); got != "/elsewhere/new" {
+		t.Fatalf("RenamePath() = %q, want /elsewhere/new", got)
+	}
+	layout.Template = "{repo_parent}/{repo}.worktrees/{branch}"
+	if got := layout.RenamePath("/elsewhere/old", "new", "feature/new"); got != "/src/repo.worktrees/feature/new" {
+		t.Fatalf("RenamePath() = %q, want /src/repo.worktrees/feature/new", got)
+	}
+}
+
+func TestLoadWorktreeLayout(t *testing.T) {
+	git := &fakeLayoutGit{mainPath: "/src/repo", remoteURL: "https://gitlab.example.com/group/sub/repo.git"}
+
+	layout := LoadWorktreeLayout(context.Background(), git, "", "/wt", "repo")
+	if git.calls != 0 || layout.MainPath != "" {
+		t.Fatalf("expected no git lookups without a template, got %d calls", git.calls)
+	}
+
+	layout = LoadWorktreeLayout(context.Background(), git, " /code/{owner}/{repo}/{name} ", "/wt", "repo")
+	if got := layout.Path("feat", ""); got != "/code/group/sub/repo/feat" {
+		t.Fatalf("Path() = %q, want /code/group/sub/repo/feat", got)
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		raw                   string
+		host, owner, repoName string
+	}{
+		{raw: "https://github.com/owner/repo.git", host: "github.com", owner: "owner", repoName: "repo"},
+		{raw: "ssh://git@host.example.com:2222/owner/repo", host: "host.example.com", owner: "owner", repoName: "repo"},
+		{raw: "git@github.com:owner/repo.git", host: "github.com", owner: "owner", repoName: "repo"},
+		{raw: "/srv/git/repo.git", repoName: "repo"},
+		{raw: "file:///srv/git/repo.git", repoName: "repo"},
+		{raw: ""},
+	}
+	for _, tt := range tests {
+		host, owner, repoName := parseRemoteURL(tt.raw)
+		if host != tt.host || owner != tt.owner || repoName != tt.repoName {
+			t.Fatalf("parseRemoteURL(%q) = %q, %q, %q, want %q, %q, %q", tt.raw, host, owner, repoName, tt.host, tt.owner, tt.repoName)
+		}
+	}
+}
+
+func TestContainsWorktree(t *testing.T) {
+	known := map[string]bool{"/src/repo.worktrees/feature/x": true}
+	if !ContainsWorktree("/src/repo.worktrees/feature", known) {
+		t.Fatal("expected feature to contain a worktree")
+	}
+	if ContainsWorktree("/src/repo.worktrees/feature/x", known) {
+		t.Fatal("a worktree does not contain itself")
+	}
+	if ContainsWorktree("/src/repo.worktrees/other", known) {
+		t.Fatal("expected other to contain no worktree")
+	}
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// DescribeLock describes a locked worktree, with the lock reason when one was
+// given, such as "locked (on a USB drive)".
+func DescribeLock(wt *models.WorktreeInfo) string {
+	if wt.LockReason == "" {
+		return "locked"
+	}
+	return fmt.Sprintf("locked (%s)", wt.LockReason)
+}
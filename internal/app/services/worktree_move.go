@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// Move moves a worktree to newPath with git worktree move, creating the
+// parent directories first. The branch is left untouched.
+func (s *worktreeService) Move(ctx context.Context, oldPath, newPath string) error {
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("destination already exists: %s", newPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o750); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	if !s.git.RunCommandChecked(ctx, []string{"git", "worktree", "move", oldPath, newPath}, "", fmt.Sprintf("Failed to move worktree from %s to %s", oldPath, newPath)) {
+		return fmt.Errorf("failed to move %s to %s", oldPath, newPath)
+	}
+	return nil
+}
+
+// OutsideWorktrees returns the linked worktrees whose path does not follow
+// layout, such as those created with a plain git worktree add. Prunable
+// worktrees are left out since there is nothing to move.
+func OutsideWorktrees(worktrees []*models.WorktreeInfo, layout WorktreeLayout) []*models.WorktreeInfo {
+	var outside []*models.WorktreeInfo
+	for _, wt := range worktrees {
+		if wt.IsMain || wt.Prunable || layout.Contains(wt.Path) {
+			continue
+		}
+		outside = append(outside, wt)
+	}
+	return outside
+}
+
+// AdoptionPath returns the path wt is moved to when adopted into layout. It
+// keeps the directory name, and the branch unless the worktree is detached.
+func AdoptionPath(wt *models.WorktreeInfo, layout WorktreeLayout) string {
+	branch := wt.Branch
+	if wt.Detached {
+		branch = ""
+	}
+	return layout.Path(filepath.Base(wt.Path), branch)
+}
+
+// MigrateWorktreeState moves the note and access history entry of a worktree
+// from oldPath to newPath after it has been moved or renamed.
+func MigrateWorktreeState(repoKey, worktreeDir, worktreeNotesPath, oldPath, newPath string) error {
+	if err := migrateWorktreeNoteFile(repoKey, worktreeDir, worktreeNotesPath, oldPath, newPath); err != nil {
+		return fmt.Errorf("failed to migrate worktree note: %w", err)
+	}
+
+	history, err := LoadAccessHistory(repoKey, worktreeDir)
+	if err != nil {
+		return fmt.Errorf("failed to load access history: %w", err)
+	}
+	if ts, ok := history[oldPath]; ok {
+		delete(history, oldPath)
+		history[newPath] = ts
+		if err := SaveAccessHistory(repoKey, worktreeDir, history); err != nil {
+			return fmt.Errorf("failed to save access history: %w", err)
+		}
+	}
+	return nil
+}
+
+func migrateWorktreeNoteFile(repoKey, worktreeDir, worktreeNotesPath, oldPath, newPath string) error {
+	if strings.TrimSpace(oldPath) == "" || strings.TrimSpace(newPath) == "" {
+		return nil
+	}
+	notes, err := LoadWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath)
+	if err != nil {
+		return err
+	}
+
+	oldKey := WorktreeNoteKey(repoKey, worktreeDir, worktreeNotesPath, oldPath)
+	note, ok := notes[oldKey]
+	if !ok && strings.TrimSpace(worktreeNotesPath) != "" {
+		oldKey = filepath.Clean(oldPath)
+		note, ok = notes[oldKey]
+	}
+	if !ok {
+		return nil
+	}
+
+	delete(notes, oldKey)
+	note.UpdatedAt = time.Now().Unix()
+	notes[WorktreeNoteKey(repoKey, worktreeDir, worktreeNotesPath, newPath)] = note
+	return SaveWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath, notes)
+}
+
+// MoveDestination returns the path a worktree at path ends up at when moved
+// to destination: inside destination when it is an existing directory, as
+// git worktree move does, otherwise destination itself.
+func MoveDestination(path, destination string) string {
+	destination = filepath.Clean(destination)
+	if info, err := os.Stat(destination); err == nil && info.IsDir() {
+		return filepath.Join(destination, filepath.Base(path))
+	}
+	return destination
+}
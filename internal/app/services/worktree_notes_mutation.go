@@ -1,12 +1,16 @@
 package services
 
 import (
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
+// WorktreeNoteMaxChars is the maximum length of a worktree note, in runes.
+const WorktreeNoteMaxChars = 4000
+
 // SaveWorktreeNote stores a single note for a worktree path.
 func SaveWorktreeNote(repoKey, worktreeDir, worktreeNotesPath, worktreePath, noteText string) error {
 	trimmedPath := strings.TrimSpace(worktreePath)
@@ -37,3 +41,50 @@ func SaveWorktreeNote(repoKey, worktreeDir, worktreeNotesPath, worktreePath, not
 	}
 	return SaveWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath, notes)
 }
+
+// LoadWorktreeNote returns the note stored for a worktree path. In shared-file
+// mode, older absolute-path keys are used as a fallback.
+func LoadWorktreeNote(repoKey, worktreeDir, worktreeNotesPath, worktreePath string) (models.WorktreeNote, bool, error) {
+	trimmedPath := strings.TrimSpace(worktreePath)
+	if trimmedPath == "" {
+		return models.WorktreeNote{}, false, nil
+	}
+
+	notes, err := LoadWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath)
+	if err != nil {
+		return models.WorktreeNote{}, false, err
+	}
+
+	note, ok := notes[WorktreeNoteKey(repoKey, worktreeDir, worktreeNotesPath, trimmedPath)]
+	if !ok && strings.TrimSpace(worktreeNotesPath) != "" {
+		note, ok = notes[filepath.Clean(trimmedPath)]
+	}
+	if !ok || strings.TrimSpace(note.Note) == "" {
+		return models.WorktreeNote{}, false, nil
+	}
+	return note, true, nil
+}
+
+// DeleteWorktreeNote removes the note stored for a worktree path, including
+// any older absolute-path key in shared-file mode.
+func DeleteWorktreeNote(repoKey, worktreeDir, worktreeNotesPath, worktreePath string) error {
+	trimmedPath := strings.TrimSpace(worktreePath)
+	if trimmedPath == "" {
+		return nil
+	}
+
+	notes, err := LoadWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath)
+	if err != nil {
+		return err
+	}
+
+	key := WorktreeNoteKey(repoKey, worktreeDir, worktreeNotesPath, trimmedPath)
+	_, hasKey := notes[key]
+	_, hasLegacy := notes[filepath.Clean(trimmedPath)]
+	if !hasKey && !hasLegacy {
+		return nil
+	}
+	delete(notes, key)
+	delete(notes, filepath.Clean(trimmedPath))
+	return SaveWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath, notes)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sparseGitService is the subset of git operations needed to set up
+// sparse-checkout in a worktree.
+type sparseGitService interface {
+	RunCommandChecked(ctx context.Context, args []string, cwd, errorPrefix string) bool
+}
+
+// CheckoutSparse restricts the worktree at path, added with --no-checkout,
+// to the cone directories dirs and checks it out.
+func CheckoutSparse(ctx context.Context, git sparseGitService, path string, dirs []string) error {
+	if err := SetSparseCheckout(ctx, git, path, dirs); err != nil {
+		return err
+	}
+	if !git.RunCommandChecked(ctx, []string{"git", "checkout"}, path, fmt.Sprintf("Failed to check out %s", path)) {
+		return fmt.Errorf("failed to check out %s", path)
+	}
+	return nil
+}
+
+// SetSparseCheckout restricts the worktree at path to the cone directories
+// dirs, or restores a full checkout when dirs is empty. Directories starting
+// with "-" are refused since git would read them as options.
+func SetSparseCheckout(ctx context.Context, git sparseGitService, path string, dirs []string) error {
+	for _, dir := range dirs {
+		if strings.HasPrefix(dir, "-") {
+			return fmt.Errorf("invalid sparse-checkout directory %q", dir)
+		}
+	}
+	if len(dirs) == 0 {
+		if !git.RunCommandChecked(ctx, []string{"git", "sparse-checkout", "disable"}, path, "Failed to disable sparse-checkout") {
+			return fmt.Errorf("failed to disable sparse-checkout in %s", path)
+		}
+		return nil
+	}
+
+	args := append([]string{"git", "sparse-checkout", "set", "--cone"}, dirs...)
+	if !git.RunCommandChecked(ctx, args, path, "Failed to set sparse-checkout") {
+		return fmt.Errorf("failed to set sparse-checkout in %s", path)
+	}
+	return nil
+}
+
+// SparseProfileName returns the name of the profile checking out exactly
+// dirs, in any order, or an empty string when none does.
+func SparseProfileName(profiles map[string][]string, dirs []string) string {
+	want := normalizeSparseDirs(dirs)
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if slices.Equal(normalizeSparseDirs(profiles[name]), want) {
+			return name
+		}
+	}
+	return ""
+}
+
+func normalizeSparseDirs(dirs []string) []string {
+	normalized := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir = strings.Trim(dir, "/"); dir != "" {
+			normalized = append(normalized, dir)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
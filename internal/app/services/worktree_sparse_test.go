@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type fakeSparseGit struct {
+	commands []string
+	fail     string
+}
+
+func (f *fakeSparseGit) RunCommandChecked(_ context.Context, args []string, cwd, _ string) bool {
+	command := strings.Join(args, " ")
+	f.commands = append(f.commands, cwd+": "+command)
+	return command != f.fail
+}
+
+func TestCheckoutSparse(t *testing.T) {
+	git := &fakeSparseGit{}
+	if err := CheckoutSparse(context.Background(), git, "/wt/feat", []string{"web", "shared/ui"}); err != nil {
+		t.Fatalf("CheckoutSparse() error = %v", err)
+	}
+	want := []string{
+		"/wt/feat: git sparse-checkout set --cone web shared/ui",
+		"/wt/feat: git checkout",
+	}
+	if strings.Join(git.commands, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("commands = %q, want %q", git.commands, want)
+	}
+
+	git = &fakeSparseGit{fail: "git sparse-checkout set --cone web"}
+	if err := CheckoutSparse(context.Background(), git, "/wt/feat", []string{"web"}); err == nil {
+		t.Fatal("expected an error when sparse-checkout set fails")
+	}
+	if len(git.commands) != 1 {
+		t.Fatalf("expected no checkout after a failure, got %q", git.commands)
+	}
+
+	git = &fakeSparseGit{}
+	if err := CheckoutSparse(context.Background(), git, "/wt/feat", []string{"web", "--no-cone"}); err == nil {
+		t.Fatal("expected an error for a directory read as an option")
+	}
+	if len(git.commands) != 0 {
+		t.Fatalf("expected no git command for an option-like directory, got %q", git.commands)
+	}
+}
+
+func TestSetSparseCheckoutDisable(t *testing.T) {
+	git := &fakeSparseGit{}
+	if err := SetSparseCheckout(context.Background(), git, "/wt/feat", nil); err != nil {
+		t.Fatalf("SetSparseCheckout() error = %v", err)
+	}
+	if len(git.commands) != 1 || git.commands[0] != "/wt/feat: git sparse-checkout disable" {
+		t.Fatalf("commands = %q, want sparse-checkout disable", git.commands)
+	}
+}
+
+func TestSparseProfileName(t *testing.T) {
+	profiles := map[string][]string{
+		"web":  {"web", "shared"},
+		"docs": {"docs"},
+	}
+	tests := []struct {
+		dirs []string
+		want string
+	}{
+		{dirs: []string{"shared", "web/"}, want: "web"},
+		{dirs: []string{"docs"}, want: "docs"},
+		{dirs: []string{"web"}, want: ""},
+		{dirs: nil, want: ""},
+	}
+	for _, tt := range tests {
+		if got := SparseProfileName(profiles, tt.dirs); got != tt.want {
+			t.Fatalf("SparseProfileName(%q) = %q, want %q", tt.dirs, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// submoduleGitService is the subset of git operations needed to update the
+// submodules of a worktree.
+type submoduleGitService interface {
+	RunGit(ctx context.Context, args []string, cwd string, okReturncodes []int, strip, silent bool) string
+	RunCommandChecked(ctx context.Context, args []string, cwd, errorPrefix string) bool
+}
+
+// HasSubmodules reports whether the worktree at path declares submodules.
+func HasSubmodules(path string) bool {
+	info, err := os.Stat(filepath.Join(path, ".gitmodules"))
+	return err == nil && !info.IsDir()
+}
+
+// UpdateSubmodules initialises and updates the submodules of the worktree at
+// path recursively. With reference, each submodule already cloned in the main
+// worktree is used as an alternate so its objects are not copied again.
+// Worktrees without submodules are left alone.
+func UpdateSubmodules(ctx context.Context, git submoduleGitService, path string, reference bool) error {
+	if !HasSubmodules(path) {
+		return nil
+	}
+
+	update := []string{"git", "submodule", "update", "--init", "--recursive"}
+	if !reference {
+		if !git.RunCommandChecked(ctx, update, path, "Failed to update submodules") {
+			return fmt.Errorf("failed to update submodules in %s", path)
+		}
+		return nil
+	}
+
+	modulesDir := submoduleModulesDir(ctx, git, path)
+	var failed []string
+	for _, sub := range listSubmodules(ctx, git, path) {
+		args := append([]string{}, update...)
+		if modulesDir != "" {
+			if info, err := os.Stat(filepath.Join(modulesDir, sub.name)); err == nil && info.IsDir() {
+				args = append(args, "--reference", filepath.Join(modulesDir, sub.name))
+			}
+		}
+		args = append(args, "--", sub.path)
+		if !git.RunCommandChecked(ctx, args, path, fmt.Sprintf("Failed to update submodule %s", sub.path)) {
+			failed = append(failed, sub.path)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update submodules in %s: %s", path, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+type submodule struct {
+	name string
+	path string
+}
+
+// listSubmodules reads the submodules declared in the .gitmodules file of the
+// worktree at path.
+func listSubmodules(ctx context.Context, git submoduleGitService, path string) []submodule {
+	raw := git.RunGit(ctx, []string{"git", "config", "--file", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`}, path, []int{0, 1}, true, true)
+	var subs []submodule
+	for line := range strings.SplitSeq(raw, "\n") {
+		key, subPath, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "submodule."), ".path")
+		if name == "" || subPath == "" {
+			continue
+		}
+		subs = append(subs, submodule{name: name, path: subPath})
+	}
+	return subs
+}
+
+// submoduleModulesDir returns the directory holding the submodule
+// repositories shared by every worktree of the repository at path.
+func submoduleModulesDir(ctx context.Context, git submoduleGitService, path string) string {
+	commonDir := git.RunGit(ctx, []string{"git", "rev-parse", "--git-common-dir"}, path, []int{0}, true, true)
+	if commonDir == "" {
+		return ""
+	}
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(path, commonDir)
+	}
+	return filepath.Join(commonDir, "modules")
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeSubmoduleGit struct {
+	fakeSparseGit
+	outputs map[string]string
+}
+
+func (f *fakeSubmoduleGit) RunGit(_ context.Context, args []string, _ string, _ []int, _, _ bool) string {
+	return f.outputs[strings.Join(args, " ")]
+}
+
+func TestUpdateSubmodules(t *testing.T) {
+	wtPath := t.TempDir()
+	if err := UpdateSubmodules(context.Background(), &fakeSubmoduleGit{}, wtPath, true); err != nil {
+		t.Fatalf("UpdateSubmodules() without submodules error = %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(wtPath, ".gitmodules"), []byte("[submodule \"lib\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	git := &fakeSubmoduleGit{}
+	if err := UpdateSubmodules(context.Background(), git, wtPath, false); err != nil {
+		t.Fatalf("UpdateSubmodules() error = %v", err)
+	}
+	want := wtPath + ": git submodule update --init --recursive"
+	if len(git.commands) != 1 || git.commands[0] != want {
+		t.Fatalf("commands = %q, want %q", git.commands, want)
+	}
+}
+
+func TestUpdateSubmodulesReference(t *testing.T) {
+	wtPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(wtPath, ".gitmodules"), []byte("[submodule \"lib\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	commonDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(commonDir, "modules", "lib"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	git := &fakeSubmoduleGit{outputs: map[string]string{
+		`git config --file .gitmodules --get-regexp ^submodule\..*\.path$`: "submodule.lib.path vendor/lib\nsubmodule.docs.path docs",
+		"git rev-parse --git-common-dir":                                   commonDir,
+	}}
+	if err := UpdateSubmodules(context.Background(), git, wtPath, true); err != nil {
+		t.Fatalf("UpdateSubmodules() error = %v", err)
+	}
+	want := []string{
+		wtPath + ": git submodule update --init --recursive --reference " + filepath.Join(commonDir, "modules", "lib") + " -- vendor/lib",
+		wtPath + ": git submodule update --init --recursive -- docs",
+	}
+	if strings.Join(git.commands, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("commands = %q, want %q", git.commands, want)
+	}
+
+	git.commands = nil
+	git.fail = "git submodule update --init --recursive -- docs"
+	err := UpdateSubmodules(context.Background(), git, wtPath, true)
+	if err == nil || !strings.Contains(err.Error(), "docs") {
+		t.Fatalf("expected an error naming the failed submodule, got %v", err)
+	}
+}
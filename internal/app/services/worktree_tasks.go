@@ -0,0 +1,185 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownTaskLineRE = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s*)(.*)$`)
+	todoKeywordLineRE  = regexp.MustCompile(`^(\s*)(TODO|DONE)(:?\s*)(.*)$`)
+)
+
+// worktreeTaskIDLength is the number of hex characters kept in a task ID.
+const worktreeTaskIDLength = 7
+
+// WorktreeTask is a checkbox or TODO/DONE line found in a worktree note.
+type WorktreeTask struct {
+	ID           string
+	WorktreePath string
+	LineIndex    int
+	Checked      bool
+	Text         string
+	IsKeyword    bool
+}
+
+// ExtractWorktreeTasks parses the tasks of a worktree note in line order.
+func ExtractWorktreeTasks(worktreePath, noteText string) []WorktreeTask {
+	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	tasks := make([]WorktreeTask, 0, len(lines))
+	seen := make(map[string]int)
+
+	for i, line := range lines {
+		checked, text, ok := ParseMarkdownTaskLine(line)
+		isKeyword := false
+		if !ok {
+			checked, text, ok = ParseTodoKeywordLine(line)
+			if ok {
+				isKeyword = true
+			}
+		}
+		if !ok {
+			continue
+		}
+		seen[text]++
+		tasks = append(tasks, WorktreeTask{
+			ID:           WorktreeTaskID(worktreePath, text, seen[text]),
+			WorktreePath: worktreePath,
+			LineIndex:    i,
+			Checked:      checked,
+			Text:         text,
+			IsKeyword:    isKeyword,
+		})
+	}
+
+	return tasks
+}
+
+// WorktreeTaskID returns a short ID derived from the worktree name and task
+// text, so it survives toggling the task, editing other lines of the note and
+// moving the worktree to another directory. occurrence distinguishes tasks
+// with identical text in the same note.
+func WorktreeTaskID(worktreePath, text string, occurrence int) string {
+	sum := sha256.Sum256([]byte(filepath.Base(filepath.Clean(worktreePath)) + "\n" + text))
+	id := hex.EncodeToString(sum[:])[:worktreeTaskIDLength]
+	if occurrence > 1 {
+		id = fmt.Sprintf("%s-%d", id, occurrence)
+	}
+	return id
+}
+
+// ParseMarkdownTaskLine parses a "- [ ] text" style checkbox line.
+func ParseMarkdownTaskLine(line string) (checked bool, text string, ok bool) {
+	parts := markdownTaskLineRE.FindStringSubmatch(line)
+	if len(parts) != 5 {
+		return false, "", false
+	}
+
+	checked = strings.EqualFold(parts[2], "x")
+	text = strings.TrimSpace(parts[4])
+	if text == "" {
+		text = "(untitled task)"
+	}
+	return checked, text, true
+}
+
+// ParseTodoKeywordLine parses a line starting with TODO or DONE.
+func ParseTodoKeywordLine(line string) (checked bool, text string, ok bool) {
+	parts := todoKeywordLineRE.FindStringSubmatch(line)
+	if len(parts) != 5 {
+		return false, "", false
+	}
+	checked = parts[2] == "DONE"
+	text = strings.TrimSpace(parts[4])
+	if text == "" {
+		text = "(untitled task)"
+	}
+	return checked, text, true
+}
+
+// ToggleWorktreeTask flips the state of task in noteText, preserving the
+// rest of the line.
+func ToggleWorktreeTask(noteText string, task WorktreeTask) (string, bool) {
+	if task.IsKeyword {
+		return ToggleTodoKeywordLine(noteText, task.LineIndex)
+	}
+	return ToggleMarkdownTaskLine(noteText, task.LineIndex)
+}
+
+// SetWorktreeTaskChecked marks task as done or open. It reports false when
+// the task already has the requested state.
+func SetWorktreeTaskChecked(noteText string, task WorktreeTask, checked bool) (string, bool) {
+	if task.Checked == checked {
+		return noteText, false
+	}
+	return ToggleWorktreeTask(noteText, task)
+}
+
+// AppendWorktreeTask adds an open checkbox task on a new line of noteText.
+func AppendWorktreeTask(noteText, text string) string {
+	if strings.TrimSpace(noteText) == "" {
+		return "- [ ] " + text
+	}
+	return strings.TrimRight(noteText, "\n") + "\n- [ ] " + text
+}
+
+// ToggleMarkdownTaskLine flips the checkbox on the given line.
+func ToggleMarkdownTaskLine(noteText string, lineIndex int) (string, bool) {
+	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return noteText, false
+	}
+
+	line := lines[lineIndex]
+	idx := markdownTaskLineRE.FindStringSubmatchIndex(line)
+	if len(idx) < 6 {
+		return noteText, false
+	}
+
+	checkStart := idx[4]
+	checkEnd := idx[5]
+	if checkStart < 0 || checkEnd <= checkStart || checkEnd > len(line) {
+		return noteText, false
+	}
+
+	replacement := "x"
+	if strings.EqualFold(line[checkStart:checkEnd], "x") {
+		replacement = " "
+	}
+	lines[lineIndex] = line[:checkStart] + replacement + line[checkEnd:]
+	return strings.Join(lines, "\n"), true
+}
+
+// ToggleTodoKeywordLine swaps TODO and DONE on the given line.
+func ToggleTodoKeywordLine(noteText string, lineIndex int) (string, bool) {
+	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return noteText, false
+	}
+
+	line := lines[lineIndex]
+	idx := todoKeywordLineRE.FindStringSubmatchIndex(line)
+	if len(idx) < 6 {
+		return noteText, false
+	}
+
+	kwStart := idx[4]
+	kwEnd := idx[5]
+	keyword := line[kwStart:kwEnd]
+
+	var replacement string
+	if keyword == "TODO" {
+		replacement = "DONE"
+	} else {
+		replacement = "TODO"
+	}
+	lines[lineIndex] = line[:kwStart] + replacement + line[kwEnd:]
+	return strings.Join(lines, "\n"), true
+}
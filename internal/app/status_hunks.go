@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// hunkAppliedMsg reports a hunk or line range staged, unstaged or discarded
+// from the hunk view.
+type hunkAppliedMsg struct {
+	worktreePath string
+	err          error
+}
+
+// showStatusHunks opens the hunk view of the file selected in the status
+// pane, on its unstaged changes when it has some and its staged ones
+// otherwise.
+func (m *Model) showStatusHunks() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+	if len(m.state.services.statusTree.TreeFlat) == 0 || m.state.services.statusTree.Index < 0 || m.state.services.statusTree.Index >= len(m.state.services.statusTree.TreeFlat) {
+		return nil
+	}
+	node := m.state.services.statusTree.TreeFlat[m.state.services.statusTree.Index]
+	if node.IsDir() {
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	sf := *node.File
+	if sf.IsUntracked {
+		m.showInfo(fmt.Sprintf("%s is untracked.\n\nStage the whole file with s first, then pick its hunks.", sf.Filename), nil)
+		return nil
+	}
+	if sf.IsSubmodule {
+		m.showInfo(fmt.Sprintf("%s is a submodule and has no hunks.", sf.Filename), nil)
+		return nil
+	}
+
+	staged := len(sf.Status) > 1 && (sf.Status[1] == '.' || sf.Status[1] == ' ')
+	diff := m.loadFileDiff(wt.Path, sf.Filename, staged)
+	if len(diff.Hunks) == 0 {
+		m.showInfo(fmt.Sprintf("No hunks to show for %s.\n\nBinary files and mode changes are staged whole with s.", sf.Filename), nil)
+		return nil
+	}
+
+	scr := appscreen.NewHunkScreen(sf.Filename, staged, diff, m.state.view.WindowWidth, m.state.view.WindowHeight, m.theme)
+	scr.OnApply = func(hunk, from, to int) tea.Cmd {
+		// Unstaging reverts the staged diff in the index
+		return m.applyHunkCmd(scr, wt.Path, hunk, from, to, true, scr.Staged)
+	}
+	scr.OnDiscard = func(hunk, from, to int) tea.Cmd {
+		confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Discard the selected changes?\n\nFile: %s\n\nThis cannot be undone.", scr.Filename), m.theme)
+		confirmScreen.OnConfirm = func() tea.Cmd {
+			return m.applyHunkCmd(scr, wt.Path, hunk, from, to, false, true)
+		}
+		m.state.ui.screenManager.Push(confirmScreen)
+		return nil
+	}
+	scr.OnToggleView = func() tea.Cmd {
+		scr.Staged = !scr.Staged
+		scr.Hunk, scr.Line = 0, 0
+		scr.SetDiff(m.loadFileDiff(wt.Path, scr.Filename, scr.Staged))
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(scr)
+	return nil
+}
+
+// loadFileDiff returns the unstaged, or staged, diff of filename.
+func (m *Model) loadFileDiff(worktreePath, filename string, staged bool) *models.FileDiff {
+	return git.ParseFileDiff(m.state.services.git.FileDiff(m.ctx, worktreePath, filename, staged))
+}
+
+// applyHunkCmd applies lines from..to of a hunk shown by scr to the index
+// with cached, or to the worktree files otherwise, reverted with reverse.
+func (m *Model) applyHunkCmd(scr *appscreen.HunkScreen, worktreePath string, hunk, from, to int, cached, reverse bool) tea.Cmd {
+	patch, err := git.BuildHunkPatch(scr.Diff, hunk, from, to, reverse)
+	if err != nil {
+		scr.ErrorMsg = err.Error()
+		return nil
+	}
+	return func() tea.Msg {
+		return hunkAppliedMsg{
+			worktreePath: worktreePath,
+			err:          m.state.services.git.ApplyPatch(m.ctx, worktreePath, patch, cached, reverse),
+		}
+	}
+}
+
+// handleHunkApplied reloads the hunk view and the status pane, which keeps
+// the file selected through its selection restore.
+func (m *Model) handleHunkApplied(msg hunkAppliedMsg) tea.Cmd {
+	m.deleteDetailsCache(msg.worktreePath)
+	if scr, ok := m.state.ui.screenManager.Current().(*appscreen.HunkScreen); ok {
+		scr.SetDiff(m.loadFileDiff(msg.worktreePath, scr.Filename, scr.Staged))
+		if msg.err != nil {
+			scr.ErrorMsg = msg.err.Error()
+		}
+	} else if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Failed to apply the hunk\n\nError: %v", msg.err), nil)
+	}
+	return m.updateDetailsView()
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowStatusHunksUntrackedFile(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.view.FocusedPane = 1
+	m.state.data.filteredWts = []*models.WorktreeInfo{{Path: cfg.WorktreeDir, Branch: featureBranch}}
+	m.state.data.selectedIndex = 0
+	m.setStatusFiles([]StatusFile{{Filename: "new.go", Status: "??", IsUntracked: true}})
+	m.state.services.statusTree.Index = 0
+
+	m.handleBuiltInKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "untracked") {
+		t.Fatalf("expected info screen for an untracked file, got %v", m.state.ui.screenManager.Type())
+	}
+}
+
+func TestStatusHunksStageHunk(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "config", "commit.gpgsign", "false")
+	file := filepath.Join(repo, "file.txt")
+	if err := os.WriteFile(file, []byte("one\ntwo\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo, "add", "file.txt")
+	runGit(t, repo, "commit", "-m", "Initial commit")
+	if err := os.WriteFile(file, []byte("one\nTWO\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.view.FocusedPane = 1
+	m.state.data.filteredWts = []*models.WorktreeInfo{{Path: repo, Branch: featureBranch}}
+	m.state.data.selectedIndex = 0
+	m.setStatusFiles([]StatusFile{{Filename: "file.txt", Status: ".M"}})
+	m.state.services.statusTree.Index = 0
+
+	m.showStatusHunks()
+	hunkScreen, ok := m.state.ui.screenManager.Current().(*appscreen.HunkScreen)
+	if !ok {
+		t.Fatalf("expected hunk screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if hunkScreen.Staged || len(hunkScreen.Diff.Hunks) != 1 {
+		t.Fatalf("expected one unstaged hunk, got staged=%v hunks=%d", hunkScreen.Staged, len(hunkScreen.Diff.Hunks))
+	}
+
+	_, cmd := hunkScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	if cmd == nil {
+		t.Fatal("expected a command staging the hunk")
+	}
+	msg, ok := cmd().(hunkAppliedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("expected the hunk to be staged, got %+v", msg)
+	}
+	m.handleHunkApplied(msg)
+
+	if got := runGit(t, repo, "diff", "--cached", "--name-only"); got != "file.txt" {
+		t.Fatalf("expected file.txt staged, got %q", got)
+	}
+	if len(hunkScreen.Diff.Hunks) != 0 {
+		t.Fatalf("expected the hunk view to be reloaded, got %d hunks", len(hunkScreen.Diff.Hunks))
+	}
+
+	hunkScreen.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !hunkScreen.Staged || len(hunkScreen.Diff.Hunks) != 1 {
+		t.Fatalf("expected the staged hunk after tab, got staged=%v hunks=%d", hunkScreen.Staged, len(hunkScreen.Diff.Hunks))
+	}
+}
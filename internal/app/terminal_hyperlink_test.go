@@ -127,6 +127,31 @@ func TestBuildInfoContentFeatureBranchShowsFetchHint(t *testing.T) {
 	}
 }
 
+func TestBuildInfoContentWorktreeStates(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	m := NewModel(cfg, "")
+
+	wt := &models.WorktreeInfo{
+		Path:           "/tmp/gone",
+		Branch:         "(detached)",
+		Head:           "0123456789abcdef0123456789abcdef01234567",
+		Detached:       true,
+		Locked:         true,
+		LockReason:     "on a usb drive",
+		Prunable:       true,
+		PrunableReason: "gitdir file points to non-existent location",
+	}
+	m.state.data.worktrees = []*models.WorktreeInfo{wt}
+
+	info := m.buildInfoContent(wt)
+	for _, want := range []string{"(detached at 0123456)", "on a usb drive", "gitdir file points to non-existent location"} {
+		if !strings.Contains(info, want) {
+			t.Fatalf("expected %q in info, got %q", want, info)
+		}
+	}
+}
+
 func TestBuildInfoContentNoUpstreamHidesPRSection(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.WorktreeDir = t.TempDir()
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// recordBaseRef remembers base as the ref the branch of the worktree just
+// created at targetPath was started from, so absorb, update from base and
+// merged detection target it rather than the main branch.
+func (m *Model) recordBaseRef(targetPath, branch, base string) {
+	if err := m.state.services.git.SetBaseRef(m.ctx, targetPath, branch, base); err != nil {
+		m.debugf("%v", err)
+	}
+}
+
+// worktreeBaseBranch returns the branch wt is compared with and updated from:
+// its PR base branch, or the base branch it was created from, main by default.
+func (m *Model) worktreeBaseBranch(wt *models.WorktreeInfo) string {
+	if wt.PR != nil && wt.PR.BaseBranch != "" {
+		return wt.PR.BaseBranch
+	}
+	return services.BaseBranch(m.ctx, m.state.services.git, wt)
+}
+
+// baseBranchSnapshot copies the fields the base branch of wt is resolved from,
+// so worktreeBaseBranch can run in a command while Update changes wt.
+func baseBranchSnapshot(wt *models.WorktreeInfo) *models.WorktreeInfo {
+	snapshot := &models.WorktreeInfo{Branch: wt.Branch, BaseRef: wt.BaseRef, UpstreamBranch: wt.UpstreamBranch}
+	if wt.PR != nil {
+		snapshot.PR = &models.PRInfo{BaseBranch: wt.PR.BaseBranch}
+	}
+	return snapshot
+}
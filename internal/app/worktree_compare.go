@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chmouel/lazyworktree/internal/app/handlers"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// compareCommitsShown caps the commits listed for each side of a comparison.
+const compareCommitsShown = 5
+
+// worktreeComparisonMsg carries the differences between two worktrees, either
+// between their HEADs or between their uncommitted states.
+type worktreeComparisonMsg struct {
+	left, right  *models.WorktreeInfo
+	uncommitted  bool
+	fromRef      string
+	toRef        string
+	files        []models.CommitFile
+	leftCommits  []models.CommitSummary
+	rightCommits []models.CommitSummary
+	err          error
+}
+
+// showCompareWorktrees picks another worktree to compare the selected one with.
+func (m *Model) showCompareWorktrees() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	left := m.state.data.filteredWts[m.state.data.selectedIndex]
+
+	return m.showWorktreePicker(
+		fmt.Sprintf("Compare %s with worktree", compareName(left)),
+		"No other worktrees to compare with.",
+		left.Path,
+		"",
+		func(right *models.WorktreeInfo) tea.Cmd {
+			return m.loadWorktreeComparison(left, right, false)
+		},
+	)
+}
+
+// loadWorktreeComparison collects, in the background, the commits unique to
+// each worktree and the files differing between their HEADs, or between their
+// uncommitted states when uncommitted is set.
+func (m *Model) loadWorktreeComparison(left, right *models.WorktreeInfo, uncommitted bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := worktreeComparisonMsg{left: left, right: right, uncommitted: uncommitted}
+		gitSvc := m.state.services.git
+		leftHead := gitSvc.RunGit(m.ctx, []string{"git", "rev-parse", "HEAD"}, left.Path, []int{0}, true, false)
+		rightHead := gitSvc.RunGit(m.ctx, []string{"git", "rev-parse", "HEAD"}, right.Path, []int{0}, true, false)
+		if leftHead == "" || rightHead == "" {
+			msg.err = fmt.Errorf("failed to resolve the HEADs of %s and %s", compareName(left), compareName(right))
+			return msg
+		}
+
+		msg.fromRef, msg.toRef = leftHead, rightHead
+		if uncommitted {
+			if msg.fromRef, msg.err = gitSvc.WorkingTreeRef(m.ctx, left.Path); msg.err != nil {
+				return msg
+			}
+			if msg.toRef, msg.err = gitSvc.WorkingTreeRef(m.ctx, right.Path); msg.err != nil {
+				return msg
+			}
+		}
+		msg.leftCommits, msg.rightCommits = gitSvc.UniqueCommits(m.ctx, left.Path, leftHead, rightHead)
+		msg.files = gitSvc.DiffFiles(m.ctx, left.Path, msg.fromRef, msg.toRef)
+		return msg
+	}
+}
+
+// handleWorktreeComparison shows the differing files of two worktrees in the
+// commit files tree, with the commits unique to each side above them.
+func (m *Model) handleWorktreeComparison(msg worktreeComparisonMsg) tea.Cmd {
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Failed to compare worktrees: %v", msg.err), nil)
+		return nil
+	}
+
+	state := "HEADs"
+	if msg.uncommitted {
+		state = "uncommitted changes"
+	}
+	filesScr := appscreen.NewCommitFilesScreen(
+		msg.toRef,
+		msg.left.Path,
+		msg.files,
+		appscreen.CommitMeta{},
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	filesScr.Title = fmt.Sprintf("Compare %s with %s (%s)", compareName(msg.left), compareName(msg.right), state)
+	filesScr.Summary = append(
+		compareSummary(compareName(msg.left), msg.leftCommits),
+		compareSummary(compareName(msg.right), msg.rightCommits)...,
+	)
+	filesScr.Uncommitted = msg.uncommitted
+
+	filesScr.OnShowFileDiff = func(filename string) tea.Cmd {
+		return m.diffRouter().ShowRangeDiff(handlers.RangeDiffParams{
+			FromRef:      msg.fromRef,
+			ToRef:        msg.toRef,
+			Filename:     filename,
+			WorktreePath: msg.left.Path,
+		})
+	}
+	filesScr.OnShowCommitDiff = func() tea.Cmd {
+		return m.diffRouter().ShowRangeDiff(handlers.RangeDiffParams{
+			FromRef:      msg.fromRef,
+			ToRef:        msg.toRef,
+			WorktreePath: msg.left.Path,
+		})
+	}
+	filesScr.OnToggleUncommitted = func() tea.Cmd {
+		m.state.ui.screenManager.Pop()
+		return m.loadWorktreeComparison(msg.left, msg.right, !msg.uncommitted)
+	}
+	filesScr.OnClose = func() tea.Cmd {
+		m.state.ui.screenManager.Pop()
+		return nil
+	}
+	m.state.ui.screenManager.Push(filesScr)
+	return nil
+}
+
+// compareSummary lists the first commits only found in the worktree name.
+func compareSummary(name string, commits []models.CommitSummary) []string {
+	if len(commits) == 0 {
+		return []string{fmt.Sprintf("No commits only in %s", name)}
+	}
+	lines := []string{fmt.Sprintf("%d commit(s) only in %s:", len(commits), name)}
+	for i, commit := range commits {
+		if i == compareCommitsShown {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(commits)-compareCommitsShown))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", commit.SHA, commit.Subject))
+	}
+	return lines
+}
+
+// compareName is the name a worktree is shown with in a comparison.
+func compareName(wt *models.WorktreeInfo) string {
+	if wt.IsMain {
+		return mainWorktreeName
+	}
+	return filepath.Base(wt.Path)
+}
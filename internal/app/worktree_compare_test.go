@@ -0,0 +1,100 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestCompareWorktrees(t *testing.T) {
+	repo := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), featureBranch)
+	runGit(t, repo.dir, "worktree", "add", wtPath, featureBranch)
+	if err := os.WriteFile(filepath.Join(wtPath, "feature.txt"), []byte("feature\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, wtPath, "add", "feature.txt")
+	runGit(t, wtPath, "commit", "-m", "Feature change")
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: repo.dir, Branch: repo.branch, IsMain: true},
+		{Path: wtPath, Branch: featureBranch},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+
+	if cmd := m.showCompareWorktrees(); cmd == nil {
+		t.Fatal("expected blink command for worktree picker")
+	}
+	listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if !ok {
+		t.Fatalf("expected list selection screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if len(listScreen.Items) != 1 || listScreen.Items[0].ID != wtPath {
+		t.Fatalf("expected only the other worktree, got %+v", listScreen.Items)
+	}
+
+	msg, ok := listScreen.OnSelect(listScreen.Items[0])().(worktreeComparisonMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("expected a comparison, got %+v", msg)
+	}
+	m.state.ui.screenManager.Pop()
+	m.Update(msg)
+	filesScreen, ok := m.state.ui.screenManager.Current().(*appscreen.CommitFilesScreen)
+	if !ok {
+		t.Fatalf("expected commit files screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(filesScreen.Title, "(HEADs)") {
+		t.Fatalf("unexpected title %q", filesScreen.Title)
+	}
+	if len(filesScreen.Files) != 1 || filesScreen.Files[0].Filename != "feature.txt" || filesScreen.Files[0].ChangeType != "A" {
+		t.Fatalf("expected the file added by the feature branch, got %+v", filesScreen.Files)
+	}
+	summary := strings.Join(filesScreen.Summary, "\n")
+	if !strings.Contains(summary, "No commits only in main") || !strings.Contains(summary, "Feature change") {
+		t.Fatalf("expected the commits unique to each side, got %q", summary)
+	}
+
+	capture := &commandCapture{}
+	m.commandRunner = capture.runner
+	m.execProcess = capture.exec
+	_, cmd := filesScreen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected file diff command")
+	}
+	if cmdStr := capture.args[1]; !strings.Contains(cmdStr, "git diff --color=always --stat --patch "+msg.fromRef+" "+msg.toRef+" -- 'feature.txt'") {
+		t.Fatalf("expected git diff between the HEADs for the file, got %q", cmdStr)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo.dir, "file.txt"), []byte("uncommitted\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	_, cmd = filesScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if m.state.ui.screenManager.IsActive() {
+		t.Fatalf("expected the comparison closed while reloading, got %v", m.state.ui.screenManager.Type())
+	}
+	msg, ok = cmd().(worktreeComparisonMsg)
+	if !ok || msg.err != nil || !msg.uncommitted {
+		t.Fatalf("expected an uncommitted comparison, got %+v", msg)
+	}
+	m.Update(msg)
+	filesScreen, ok = m.state.ui.screenManager.Current().(*appscreen.CommitFilesScreen)
+	if !ok || !filesScreen.Uncommitted {
+		t.Fatalf("expected uncommitted commit files screen, got %v", m.state.ui.screenManager.Type())
+	}
+	filenames := make([]string, 0, len(filesScreen.Files))
+	for _, file := range filesScreen.Files {
+		filenames = append(filenames, file.Filename)
+	}
+	if strings.Join(filenames, ",") != "feature.txt,file.txt" {
+		t.Fatalf("expected the uncommitted change to be compared too, got %v", filenames)
+	}
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// conflictCheckCacheTTL bounds how long a prediction is trusted while the base
+// branch may move.
+const conflictCheckCacheTTL = time.Minute
+
+// maxPredictedConflictPaths caps the conflicting paths listed in the info pane.
+const maxPredictedConflictPaths = 10
+
+// conflictCheckEntry is the predicted result of merging a worktree branch into
+// its base branch.
+type conflictCheckEntry struct {
+	base      string
+	paths     []string
+	key       string // HEAD the prediction was made for
+	fetchedAt time.Time
+}
+
+// conflictCheckTarget is a worktree to predict conflicts for, copied before
+// the check runs in the background.
+type conflictCheckTarget struct {
+	path, head string
+	wt         *models.WorktreeInfo // snapshot the base branch is resolved from
+}
+
+// conflictChecksLoadedMsg carries predictions keyed by worktree path.
+type conflictChecksLoadedMsg struct {
+	checks map[string]conflictCheckEntry
+}
+
+// conflictMatrixLoadedMsg carries the checks of every pair of worktrees.
+type conflictMatrixLoadedMsg struct {
+	names  []string
+	checks [][]appscreen.PairCheck
+}
+
+// loadConflictChecks predicts in the background, with git merge-tree, whether
+// each worktree whose prediction is missing or stale merges cleanly into its
+// base branch.
+func (m *Model) loadConflictChecks() tea.Cmd {
+	if m.conflictChecksLoading || m.state.services.git == nil {
+		return nil
+	}
+	var stale []conflictCheckTarget
+	m.cache.conflictMu.RLock()
+	for _, wt := range m.state.data.worktrees {
+		if wt.Prunable || wt.Path == "" || wt.Head == "" {
+			continue
+		}
+		cached, ok := m.cache.conflictChecks[wt.Path]
+		if ok && cached.key == wt.Head && time.Since(cached.fetchedAt) < conflictCheckCacheTTL {
+			continue
+		}
+		stale = append(stale, conflictCheckTarget{path: wt.Path, head: wt.Head, wt: baseBranchSnapshot(wt)})
+	}
+	m.cache.conflictMu.RUnlock()
+	if len(stale) == 0 {
+		return nil
+	}
+
+	m.conflictChecksLoading = true
+	return func() tea.Msg {
+		checks := make(map[string]conflictCheckEntry, len(stale))
+		for _, target := range stale {
+			entry := conflictCheckEntry{key: target.head, fetchedAt: time.Now()}
+			if base := m.worktreeBaseBranch(target.wt); base != "" && base != target.wt.Branch {
+				paths, err := m.state.services.git.MergeConflicts(m.ctx, target.path, base, target.head)
+				if err != nil {
+					m.debugf("conflict check of %s: %v", target.path, err)
+				}
+				entry.base, entry.paths = base, paths
+			}
+			checks[target.path] = entry
+		}
+		return conflictChecksLoadedMsg{checks: checks}
+	}
+}
+
+// handleConflictChecksLoaded caches the predictions and refreshes the table
+// markers and the info pane.
+func (m *Model) handleConflictChecksLoaded(msg conflictChecksLoadedMsg) {
+	m.conflictChecksLoading = false
+	m.cache.conflictMu.Lock()
+	if m.cache.conflictChecks == nil {
+		m.cache.conflictChecks = make(map[string]conflictCheckEntry)
+	}
+	for path, entry := range msg.checks {
+		m.cache.conflictChecks[path] = entry
+	}
+	m.cache.conflictMu.Unlock()
+	m.updateTable()
+	if m.state.data.selectedIndex >= 0 && m.state.data.selectedIndex < len(m.state.data.filteredWts) {
+		m.infoContent = m.buildInfoContent(m.state.data.filteredWts[m.state.data.selectedIndex])
+	}
+}
+
+// predictedConflicts returns the base branch of wt and the paths merging wt
+// into it is predicted to conflict on. It is safe to call from the commands
+// rendering the info pane.
+func (m *Model) predictedConflicts(wt *models.WorktreeInfo) (string, []string) {
+	m.cache.conflictMu.RLock()
+	defer m.cache.conflictMu.RUnlock()
+	cached, ok := m.cache.conflictChecks[wt.Path]
+	if !ok || cached.key != wt.Head {
+		return "", nil
+	}
+	return cached.base, cached.paths
+}
+
+// showConflictMatrix checks every pair of worktrees for conflicting and
+// overlapping changes and shows the result as a matrix.
+func (m *Model) showConflictMatrix() tea.Cmd {
+	var worktrees []*models.WorktreeInfo
+	for _, wt := range m.state.data.worktrees {
+		if !wt.Prunable && wt.Head != "" {
+			worktrees = append(worktrees, wt)
+		}
+	}
+	if len(worktrees) < 2 {
+		m.showInfo("At least two worktrees are needed to compare their changes.", nil)
+		return nil
+	}
+
+	m.loading = true
+	m.setLoadingScreen(fmt.Sprintf("Checking %d pairs of worktrees...", len(worktrees)*(len(worktrees)-1)/2))
+	return func() tea.Msg {
+		names := make([]string, len(worktrees))
+		checks := make([][]appscreen.PairCheck, len(worktrees))
+		for i, wt := range worktrees {
+			names[i] = compareName(wt)
+			checks[i] = make([]appscreen.PairCheck, len(worktrees))
+		}
+		for i := range worktrees {
+			for j := i + 1; j < len(worktrees); j++ {
+				check := m.checkWorktreePair(worktrees[i], worktrees[j])
+				checks[i][j], checks[j][i] = check, check
+			}
+		}
+		return conflictMatrixLoadedMsg{names: names, checks: checks}
+	}
+}
+
+// checkWorktreePair predicts whether merging the branches of a and b would
+// conflict, and which files both change since they diverged.
+func (m *Model) checkWorktreePair(a, b *models.WorktreeInfo) appscreen.PairCheck {
+	gitSvc := m.state.services.git
+	conflicts, err := gitSvc.MergeConflicts(m.ctx, a.Path, a.Head, b.Head)
+	if err != nil {
+		return appscreen.PairCheck{Err: err.Error()}
+	}
+	var overlaps []string
+	changedInB := gitSvc.ChangedFiles(m.ctx, a.Path, a.Head, b.Head)
+	for _, path := range gitSvc.ChangedFiles(m.ctx, a.Path, b.Head, a.Head) {
+		if slices.Contains(changedInB, path) {
+			overlaps = append(overlaps, path)
+		}
+	}
+	return appscreen.PairCheck{Conflicts: conflicts, Overlaps: overlaps}
+}
+
+// handleConflictMatrixLoaded shows the checked pairs of worktrees.
+func (m *Model) handleConflictMatrixLoaded(msg conflictMatrixLoadedMsg) {
+	m.loading = false
+	m.clearLoadingScreen()
+	scr := appscreen.NewConflictMatrixScreen(msg.names, msg.checks, m.state.view.WindowWidth, m.state.view.WindowHeight, m.theme)
+	scr.OnClose = func() tea.Cmd {
+		m.state.ui.screenManager.Pop()
+		return nil
+	}
+	m.state.ui.screenManager.Push(scr)
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestConflictChecksAndMatrix(t *testing.T) {
+	repo := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), featureBranch)
+	runGit(t, repo.dir, "worktree", "add", wtPath, featureBranch)
+	if err := os.WriteFile(filepath.Join(wtPath, "file.txt"), []byte("feature\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, wtPath, "commit", "-am", "Feature change")
+	if err := os.WriteFile(filepath.Join(repo.dir, "file.txt"), []byte("main\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo.dir, "commit", "-am", "Main change")
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	feature := &models.WorktreeInfo{
+		Path:   wtPath,
+		Branch: featureBranch,
+		Head:   runGit(t, wtPath, "rev-parse", "HEAD"),
+		PR:     &models.PRInfo{BaseBranch: repo.branch},
+	}
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: repo.dir, Branch: repo.branch, IsMain: true, Head: runGit(t, repo.dir, "rev-parse", "HEAD"), PR: &models.PRInfo{BaseBranch: repo.branch}},
+		feature,
+	}
+
+	cmd := m.loadConflictChecks()
+	if cmd == nil {
+		t.Fatal("expected conflict checks to be loaded")
+	}
+	msg, ok := cmd().(conflictChecksLoadedMsg)
+	if !ok {
+		t.Fatal("expected conflictChecksLoadedMsg")
+	}
+	m.Update(msg)
+	if base, paths := m.predictedConflicts(feature); base != repo.branch || len(paths) != 1 || paths[0] != "file.txt" {
+		t.Fatalf("expected file.txt to conflict with %s, got %q %v", repo.branch, base, paths)
+	}
+	if !strings.Contains(worktreeStateMarkers(feature, true), "conflicts with base") {
+		t.Fatal("expected a conflict marker")
+	}
+	if info := m.buildInfoContent(feature); !strings.Contains(info, "would conflict on 1 file(s)") || !strings.Contains(info, "file.txt") {
+		t.Fatalf("expected the conflicting paths in the info pane, got %q", info)
+	}
+	if m.loadConflictChecks() != nil {
+		t.Fatal("expected fresh predictions to be reused")
+	}
+
+	cmd = m.showConflictMatrix()
+	if cmd == nil {
+		t.Fatal("expected the matrix to be loaded")
+	}
+	m.Update(cmd())
+	scr, ok := m.state.ui.screenManager.Current().(*appscreen.ConflictMatrixScreen)
+	if !ok {
+		t.Fatalf("expected conflict matrix screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if check, ok := scr.Selected(); !ok || len(check.Conflicts) != 1 || len(check.Overlaps) != 1 {
+		t.Fatalf("expected the worktrees to conflict and overlap on file.txt, got %+v", check)
+	}
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+)
+
+const conflictActionResolve = "resolve"
+
+// conflictResultMsg reports a step of resolving the operation stopped on
+// conflicts in the worktree at path: a file edited or marked as resolved, or
+// the operation continued, skipped or aborted.
+type conflictResultMsg struct {
+	path      string
+	operation string
+	action    string
+	err       error
+}
+
+// showConflicts opens the conflict screen of the selected worktree.
+func (m *Model) showConflicts() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if m.openConflictScreen(wt.Path) == nil {
+		m.showInfo(fmt.Sprintf("No rebase, merge or cherry-pick in progress in %s.", filepath.Base(wt.Path)), nil)
+	}
+	return nil
+}
+
+// openConflictScreen pushes the conflict screen of the operation stopped in
+// the worktree at path, or returns nil when none is in progress.
+func (m *Model) openConflictScreen(path string) *appscreen.ConflictScreen {
+	operation := git.InProgressOperation(path)
+	if operation == "" {
+		return nil
+	}
+
+	scr := appscreen.NewConflictScreen(
+		operation,
+		filepath.Base(path),
+		m.state.services.git.UnmergedFiles(m.ctx, path),
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+	)
+	scr.OnEdit = func(file string) tea.Cmd {
+		return m.editConflictFile(path, scr.Operation, file)
+	}
+	scr.OnMergetool = func(file string) tea.Cmd {
+		c := m.commandRunner(m.ctx, "git", "mergetool", "--", file)
+		c.Dir = path
+		return m.execProcess(c, func(err error) tea.Msg {
+			return conflictResultMsg{path: path, operation: scr.Operation, action: "mergetool", err: err}
+		})
+	}
+	scr.OnResolve = func(file string) tea.Cmd {
+		return func() tea.Msg {
+			err := m.state.services.git.MarkResolved(m.ctx, path, file)
+			return conflictResultMsg{path: path, operation: scr.Operation, action: conflictActionResolve, err: err}
+		}
+	}
+	scr.OnAction = func(action string) tea.Cmd {
+		operation := scr.Operation
+		run := func() tea.Msg {
+			err := m.state.services.git.RunOperation(m.ctx, path, operation, action)
+			return conflictResultMsg{path: path, operation: operation, action: action, err: err}
+		}
+		if action != git.OperationAbort {
+			return run
+		}
+		confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Abort the %s?\n\nWorktree: %s\n\nConflict resolutions made so far are lost.", operation, path), m.theme)
+		confirmScreen.OnConfirm = func() tea.Cmd {
+			return run
+		}
+		m.state.ui.screenManager.Push(confirmScreen)
+		return nil
+	}
+	scr.OnClose = func() tea.Cmd {
+		m.deleteDetailsCache(path)
+		return m.refreshWorktrees()
+	}
+
+	m.state.ui.screenManager.Push(scr)
+	return scr
+}
+
+// editConflictFile opens file of the worktree at path in the editor.
+func (m *Model) editConflictFile(path, operation, file string) tea.Cmd {
+	editor := m.editorCommand()
+	if strings.TrimSpace(editor) == "" {
+		m.showInfo("No editor configured. Set editor in config or $EDITOR.", nil)
+		return nil
+	}
+
+	branch := ""
+	for _, wt := range m.state.data.worktrees {
+		if wt.Path == path {
+			branch = wt.Branch
+			break
+		}
+	}
+	env := m.buildCommandEnv(branch, path)
+	envVars := os.Environ()
+	for k, v := range env {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	cmdStr := fmt.Sprintf("%s %s", editor, shellQuote(file))
+	// #nosec G204 -- command is constructed from user config and controlled inputs
+	c := m.commandRunner(m.ctx, "bash", "-c", cmdStr)
+	c.Dir = path
+	c.Env = envVars
+
+	return m.execProcess(c, func(err error) tea.Msg {
+		return conflictResultMsg{path: path, operation: operation, action: "edit", err: err}
+	})
+}
+
+// handleConflictResult refreshes the conflict screen after a step, or closes
+// it once the operation is no longer in progress.
+func (m *Model) handleConflictResult(msg conflictResultMsg) tea.Cmd {
+	m.deleteDetailsCache(msg.path)
+	scr, onScreen := m.state.ui.screenManager.Current().(*appscreen.ConflictScreen)
+
+	operation := git.InProgressOperation(msg.path)
+	if operation == "" {
+		if onScreen {
+			m.state.ui.screenManager.Pop()
+		}
+		label := strings.ToUpper(msg.operation[:1]) + msg.operation[1:]
+		switch {
+		case msg.err != nil:
+			m.showInfo(fmt.Sprintf("%s %s failed\n\nError: %v", label, msg.action, msg.err), m.refreshWorktrees())
+		case msg.action == git.OperationAbort:
+			m.showInfo(fmt.Sprintf("%s aborted in %s.", label, filepath.Base(msg.path)), m.refreshWorktrees())
+		default:
+			m.showInfo(fmt.Sprintf("%s completed in %s.", label, filepath.Base(msg.path)), m.refreshWorktrees())
+		}
+		return nil
+	}
+
+	if onScreen {
+		scr.Operation = operation
+		scr.SetFiles(m.state.services.git.UnmergedFiles(m.ctx, msg.path))
+		if msg.err != nil {
+			scr.ErrorMsg = msg.err.Error()
+		}
+	} else if msg.err != nil {
+		m.showInfo(fmt.Sprintf("%s failed\n\nError: %v", msg.action, msg.err), nil)
+	}
+	return m.updateDetailsView()
+}
+
+// showConflictsAfterFailure opens the conflict screen when the operation that
+// failed with heading stopped on conflicts in the worktree at path, and
+// reports whether it did.
+func (m *Model) showConflictsAfterFailure(path, heading string) bool {
+	if path == "" {
+		return false
+	}
+	scr := m.openConflictScreen(path)
+	if scr == nil {
+		return false
+	}
+	scr.ErrorMsg = heading
+	m.deleteDetailsCache(path)
+	return true
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowConflictsWithoutOperation(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.filteredWts = []*models.WorktreeInfo{{Path: cfg.WorktreeDir, Branch: featureBranch}}
+	m.state.data.selectedIndex = 0
+
+	m.showConflicts()
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "No rebase, merge or cherry-pick") {
+		t.Fatalf("expected info screen, got %v", m.state.ui.screenManager.Type())
+	}
+}
+
+func TestCherryPickConflictOpensConflictScreen(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "config", "commit.gpgsign", "false")
+	file := filepath.Join(repo, "shared.txt")
+	if err := os.WriteFile(file, []byte("base\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo, "add", "shared.txt")
+	runGit(t, repo, "commit", "-m", "base")
+	runGit(t, repo, "checkout", "-b", "other")
+	if err := os.WriteFile(file, []byte("other\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo, "commit", "-am", "other")
+	sha := runGit(t, repo, "rev-parse", "HEAD")
+	runGit(t, repo, "checkout", "-")
+	if err := os.WriteFile(file, []byte("main\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo, "commit", "-am", "main")
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	target := &models.WorktreeInfo{Path: repo, Branch: mainWorktreeName, IsMain: true}
+
+	msg, ok := m.executeCherryPick(sha, target)().(cherryPickResultMsg)
+	if !ok || msg.err == nil {
+		t.Fatalf("expected the cherry-pick to stop on conflicts, got %+v", msg)
+	}
+	m.handleCherryPickResult(msg)
+	conflictScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConflictScreen)
+	if !ok {
+		t.Fatalf("expected conflict screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if conflictScreen.Operation != models.OperationCherryPick || len(conflictScreen.Files) != 1 || conflictScreen.Files[0] != "shared.txt" {
+		t.Fatalf("unexpected conflict screen state: %+v", conflictScreen)
+	}
+
+	if err := os.WriteFile(file, []byte("both\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	_, cmd := conflictScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m.handleConflictResult(cmd().(conflictResultMsg))
+	if len(conflictScreen.Files) != 0 {
+		t.Fatalf("expected no unmerged file left, got %v", conflictScreen.Files)
+	}
+
+	_, cmd = conflictScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m.handleConflictResult(cmd().(conflictResultMsg))
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "Cherry-pick completed") {
+		t.Fatalf("expected completion info, got %v", m.state.ui.screenManager.Type())
+	}
+	if got := runGit(t, repo, "log", "-1", "--format=%s"); got != "other" {
+		t.Fatalf("expected the cherry-picked commit, got %q", got)
+	}
+}
+
+func TestUpdateFromBaseLocalConflictOpensConflictScreen(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "config", "commit.gpgsign", "false")
+	file := filepath.Join(repo, "shared.txt")
+	if err := os.WriteFile(file, []byte("base\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo, "add", "shared.txt")
+	runGit(t, repo, "commit", "-m", "base")
+	wtPath := filepath.Join(t.TempDir(), featureBranch)
+	runGit(t, repo, "worktree", "add", "-b", featureBranch, wtPath)
+	if err := os.WriteFile(filepath.Join(wtPath, "shared.txt"), []byte("feature\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, wtPath, "commit", "-am", "feature")
+	if err := os.WriteFile(file, []byte("main\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, repo, "commit", "-am", "main")
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir(), MergeMethod: mergeMethodRebase}
+	m := NewModel(cfg, "")
+	wt := &models.WorktreeInfo{Path: wtPath, Branch: featureBranch}
+
+	msg, ok := m.updateFromBaseLocal(wt, "main")().(syncResultMsg)
+	if !ok || msg.err == nil || msg.stage != syncStageUpdateFromBase {
+		t.Fatalf("expected the rebase to stop on conflicts, got %+v", msg)
+	}
+	m.Update(msg)
+	conflictScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConflictScreen)
+	if !ok {
+		t.Fatalf("expected conflict screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if conflictScreen.Operation != models.OperationRebase || len(conflictScreen.Files) != 1 {
+		t.Fatalf("unexpected conflict screen state: %+v", conflictScreen)
+	}
+
+	_, cmd := conflictScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if cmd != nil {
+		t.Fatal("expected abort to ask for confirmation first")
+	}
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	if !ok {
+		t.Fatalf("expected confirm screen, got %v", m.state.ui.screenManager.Type())
+	}
+	m.handleConflictResult(confirmScreen.OnConfirm()().(conflictResultMsg))
+	if got := runGit(t, wtPath, "log", "-1", "--format=%s"); got != featureBranch {
+		t.Fatalf("expected the branch restored after abort, got %q", got)
+	}
+}
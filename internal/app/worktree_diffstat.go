@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// diffStatKey identifies the state a diff stat was computed for. A new commit
+// or a change in the counts of uncommitted files makes the cached stat stale.
+func diffStatKey(wt *models.WorktreeInfo) string {
+	return fmt.Sprintf("%s:%d:%d:%d", wt.Head, wt.Staged, wt.Modified, wt.Untracked)
+}
+
+// diffStatTarget is a worktree to compute the diff stat of, copied before the
+// stat is computed in the background.
+type diffStatTarget struct {
+	path, key string
+	wt        *models.WorktreeInfo // snapshot the base branch is resolved from
+}
+
+// loadDiffStats computes in the background the diff stat against the base
+// branch of every worktree whose cached stat is missing or stale.
+func (m *Model) loadDiffStats() tea.Cmd {
+	if m.diffStatsLoading || m.state.services.git == nil {
+		return nil
+	}
+	var stale []diffStatTarget
+	for _, wt := range m.state.data.worktrees {
+		if wt.Prunable || wt.Path == "" {
+			continue
+		}
+		cached, ok := m.cache.diffStats[wt.Path]
+		if ok && cached.key == diffStatKey(wt) && time.Since(cached.fetchedAt) < diffStatCacheTTL {
+			continue
+		}
+		stale = append(stale, diffStatTarget{path: wt.Path, key: diffStatKey(wt), wt: baseBranchSnapshot(wt)})
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	m.diffStatsLoading = true
+	return func() tea.Msg {
+		stats := make(map[string]diffStatEntry, len(stale))
+		for _, target := range stale {
+			entry := diffStatEntry{key: target.key, fetchedAt: time.Now()}
+			if base := m.worktreeBaseBranch(target.wt); base != "" && base != target.wt.Branch {
+				stat, err := m.state.services.git.DiffStat(m.ctx, target.path, base)
+				if err != nil {
+					m.debugf("diff stat of %s: %v", target.path, err)
+				}
+				entry.stat, entry.ok = stat, err == nil
+			}
+			stats[target.path] = entry
+		}
+		return diffStatsLoadedMsg{stats: stats}
+	}
+}
+
+// handleDiffStatsLoaded caches the computed diff stats and shows the Diff
+// column once a worktree has one.
+func (m *Model) handleDiffStatsLoaded(msg diffStatsLoadedMsg) {
+	m.diffStatsLoading = false
+	if m.cache.diffStats == nil {
+		m.cache.diffStats = make(map[string]diffStatEntry)
+	}
+	for path, entry := range msg.stats {
+		m.cache.diffStats[path] = entry
+		if entry.ok {
+			m.diffStatsLoaded = true
+		}
+	}
+	// Columns first: the table renders every cell of a row against a column.
+	m.updateTableColumns(m.state.ui.worktreeTable.Width())
+	m.updateTable()
+}
+
+// diffStatCell renders the Diff column of wt, such as "+12/-3, 2 files".
+func (m *Model) diffStatCell(wt *models.WorktreeInfo) string {
+	cached, ok := m.cache.diffStats[wt.Path]
+	if !ok {
+		return ""
+	}
+	if !cached.ok || cached.stat.Files == 0 {
+		return "-"
+	}
+	files := "files"
+	if cached.stat.Files == 1 {
+		files = "file"
+	}
+	return fmt.Sprintf("+%d/-%d, %d %s", cached.stat.Added, cached.stat.Removed, cached.stat.Files, files)
+}
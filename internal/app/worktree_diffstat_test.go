@@ -0,0 +1,66 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestLoadDiffStatsAddsDiffColumn(t *testing.T) {
+	repo := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), featureBranch)
+	runGit(t, repo.dir, "worktree", "add", wtPath, featureBranch)
+	if err := os.WriteFile(filepath.Join(wtPath, "new.txt"), []byte("one\ntwo\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runGit(t, wtPath, "add", "new.txt")
+	runGit(t, wtPath, "commit", "-m", "feature")
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: repo.dir, Branch: repo.branch, IsMain: true, PR: &models.PRInfo{BaseBranch: repo.branch}},
+		{Path: wtPath, Branch: featureBranch, Head: "abc", PR: &models.PRInfo{BaseBranch: repo.branch}},
+	}
+	m.updateTable()
+	m.updateTableColumns(120)
+
+	cmd := m.loadDiffStats()
+	if cmd == nil {
+		t.Fatal("expected diff stats to be loaded")
+	}
+	if m.loadDiffStats() != nil {
+		t.Fatal("expected a single load at a time")
+	}
+	msg, ok := cmd().(diffStatsLoadedMsg)
+	if !ok {
+		t.Fatal("expected diffStatsLoadedMsg")
+	}
+	m.Update(msg)
+
+	columns := m.state.ui.worktreeTable.Columns()
+	if len(columns) != 4 || columns[2].Title != "Diff" {
+		t.Fatalf("expected a Diff column after Status, got %+v", columns)
+	}
+	cells := map[string]string{}
+	for i, wt := range m.state.data.filteredWts {
+		cells[wt.Branch] = m.state.ui.worktreeTable.Rows()[i][2]
+	}
+	if cells[featureBranch] != "+2/-0, 1 file" {
+		t.Fatalf("unexpected diff stat for the feature worktree: %q", cells[featureBranch])
+	}
+	if cells[repo.branch] != "-" {
+		t.Fatalf("expected no diff stat for the base worktree, got %q", cells[repo.branch])
+	}
+
+	if m.loadDiffStats() != nil {
+		t.Fatal("expected cached diff stats to be reused")
+	}
+	m.state.data.worktrees[1].Head = "def"
+	if m.loadDiffStats() == nil {
+		t.Fatal("expected a new commit to invalidate the cached diff stat")
+	}
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/multiplexer"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// worktreeMove is a move of a worktree from oldPath to newPath.
+type worktreeMove struct {
+	oldPath string
+	newPath string
+}
+
+// showMoveWorktree shows an input screen for moving the selected worktree to
+// another directory. Relative destinations are resolved from the directory
+// holding the worktrees of the repository.
+func (m *Model) showMoveWorktree() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if wt.IsMain {
+		m.showInfo("Cannot move the main worktree.", nil)
+		return nil
+	}
+	if wt.Locked {
+		m.showInfo(fmt.Sprintf("Worktree %s is %s.\n\nUnlock it before moving it.", filepath.Base(wt.Path), services.DescribeLock(wt)), nil)
+		return nil
+	}
+
+	prompt := fmt.Sprintf("Move '%s' to", filepath.Base(wt.Path))
+	inputScr := appscreen.NewInputScreen(prompt, "Destination directory", wt.Path, m.theme, m.config.IconsEnabled())
+
+	inputScr.OnSubmit = func(value string, _ bool) tea.Cmd {
+		destination, err := utils.ExpandPath(strings.TrimSpace(value))
+		if err != nil || destination == "" {
+			inputScr.ErrorMsg = "Destination cannot be empty."
+			return nil
+		}
+		if !filepath.IsAbs(destination) {
+			root, _ := m.worktreeLayout().Root()
+			destination = filepath.Join(root, destination)
+		}
+
+		newPath := services.MoveDestination(wt.Path, destination)
+		if filepath.Clean(newPath) == filepath.Clean(wt.Path) {
+			inputScr.ErrorMsg = "Destination must be different from the current path."
+			return nil
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			inputScr.ErrorMsg = fmt.Sprintf("Destination already exists: %s", newPath)
+			return nil
+		}
+
+		inputScr.ErrorMsg = ""
+		return m.moveWorktreesCmd([]worktreeMove{{oldPath: wt.Path, newPath: newPath}})
+	}
+
+	inputScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(inputScr)
+	return textinput.Blink
+}
+
+// showAdoptWorktrees offers to move every worktree whose path does not follow
+// the worktree layout, such as those created with a plain git worktree add,
+// into it.
+func (m *Model) showAdoptWorktrees() tea.Cmd {
+	layout := m.worktreeLayout()
+	repoWorktreeDir, _ := layout.Root()
+	var (
+		moves   []worktreeMove
+		skipped []string
+	)
+	for _, wt := range services.OutsideWorktrees(m.state.data.worktrees, layout) {
+		newPath := services.AdoptionPath(wt, layout)
+		switch {
+		case wt.Locked:
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", wt.Path, services.DescribeLock(wt)))
+		case pathExists(newPath):
+			skipped = append(skipped, fmt.Sprintf("%s (%s already exists)", wt.Path, newPath))
+		default:
+			moves = append(moves, worktreeMove{oldPath: wt.Path, newPath: newPath})
+		}
+	}
+
+	if len(moves) == 0 {
+		msg := fmt.Sprintf("All worktrees are already in %s.", repoWorktreeDir)
+		if len(skipped) > 0 {
+			msg = fmt.Sprintf("No worktree can be moved into %s:\n\n%s", repoWorktreeDir, strings.Join(skipped, "\n"))
+		}
+		m.showInfo(msg, nil)
+		return nil
+	}
+
+	lines := make([]string, 0, len(moves))
+	for _, move := range moves {
+		lines = append(lines, fmt.Sprintf("%s\n  → %s", move.oldPath, move.newPath))
+	}
+	msg := fmt.Sprintf("Move %d worktree(s) into %s?\n\n%s", len(moves), repoWorktreeDir, strings.Join(lines, "\n"))
+	if len(skipped) > 0 {
+		msg += fmt.Sprintf("\n\nSkipped:\n%s", strings.Join(skipped, "\n"))
+	}
+
+	confirmScreen := appscreen.NewConfirmScreen(msg, m.theme)
+	confirmScreen.OnConfirm = func() tea.Cmd {
+		return m.moveWorktreesCmd(moves)
+	}
+	m.state.ui.screenManager.Push(confirmScreen)
+	return nil
+}
+
+// moveWorktreesCmd moves each worktree, renames the tmux/zellij sessions
+// named after it and reloads the worktrees. Notes and access history are
+// migrated when the result is handled.
+func (m *Model) moveWorktreesCmd(moves []worktreeMove) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			moved    []worktreeMove
+			failures []string
+		)
+		for _, move := range moves {
+			if err := m.state.services.worktree.Move(m.ctx, move.oldPath, move.newPath); err != nil {
+				failures = append(failures, err.Error())
+				continue
+			}
+			multiplexer.RenameWorktreeSessions(m.ctx, m.config.SessionPrefix, move.oldPath, move.newPath)
+			moved = append(moved, move)
+		}
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return moveWorktreesResultMsg{
+			moved:     moved,
+			failures:  failures,
+			worktrees: worktrees,
+			err:       err,
+		}
+	}
+}
+
+func (m *Model) handleMoveWorktreesResult(msg moveWorktreesResultMsg) (tea.Model, tea.Cmd) {
+	for _, move := range msg.moved {
+		m.migrateWorktreeState(move.oldPath, move.newPath)
+	}
+	model, cmd := m.handleWorktreesLoaded(worktreesLoadedMsg{
+		worktrees: msg.worktrees,
+		err:       msg.err,
+	})
+	if len(msg.failures) > 0 {
+		m.showInfo(fmt.Sprintf("Move failed:\n\n%s", strings.Join(msg.failures, "\n")), nil)
+	}
+	return model, cmd
+}
+
+// migrateWorktreeState carries the note and access history of a worktree
+// over to its new path after a move or rename.
+func (m *Model) migrateWorktreeState(oldPath, newPath string) {
+	m.migrateWorktreeNote(oldPath, newPath)
+	if ts, ok := m.state.data.accessHistory[oldPath]; ok {
+		delete(m.state.data.accessHistory, oldPath)
+		m.state.data.accessHistory[newPath] = ts
+		m.saveAccessHistory()
+	}
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowMoveWorktree(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.repoKey = "repo"
+	existing := filepath.Join(cfg.WorktreeDir, "repo", "taken", "feat")
+	if err := os.MkdirAll(existing, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	m.state.data.filteredWts = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/tmp/locked", Branch: "locked", Locked: true},
+		{Path: "/tmp/feat", Branch: featureBranch},
+	}
+
+	for _, index := range []int{0, 1} {
+		m.state.data.selectedIndex = index
+		m.showMoveWorktree()
+		if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+			t.Fatalf("expected info screen for %s", m.state.data.filteredWts[index].Path)
+		}
+		m.state.ui.screenManager.Pop()
+	}
+
+	m.state.data.selectedIndex = 2
+	if cmd := m.showMoveWorktree(); cmd == nil {
+		t.Fatal("expected blink command for input screen")
+	}
+	inputScr, ok := m.state.ui.screenManager.Current().(*appscreen.InputScreen)
+	if !ok {
+		t.Fatalf("expected input screen, got %v", m.state.ui.screenManager.Type())
+	}
+
+	if cmd := inputScr.OnSubmit("/tmp/feat", false); cmd != nil || !strings.Contains(inputScr.ErrorMsg, "different") {
+		t.Fatalf("expected error for the current path, got %q", inputScr.ErrorMsg)
+	}
+	// Relative destinations are resolved from the repository worktree dir,
+	// and an existing directory receives the worktree like git does.
+	if cmd := inputScr.OnSubmit(".", false); cmd == nil || inputScr.ErrorMsg != "" {
+		t.Fatalf("expected move command, got error %q", inputScr.ErrorMsg)
+	}
+	if cmd := inputScr.OnSubmit("taken", false); cmd != nil || !strings.Contains(inputScr.ErrorMsg, "already exists") {
+		t.Fatalf("expected error for an existing destination, got %q", inputScr.ErrorMsg)
+	}
+}
+
+func TestShowAdoptWorktrees(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.repoKey = "repo"
+	repoDir := filepath.Join(cfg.WorktreeDir, "repo")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/src/repo", Branch: mainWorktreeName, IsMain: true},
+		{Path: filepath.Join(repoDir, "managed"), Branch: "managed"},
+	}
+
+	m.showAdoptWorktrees()
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "already in") {
+		t.Fatalf("expected nothing to adopt, got %v", m.state.ui.screenManager.Type())
+	}
+	m.state.ui.screenManager.Pop()
+
+	m.state.data.worktrees = append(m.state.data.worktrees,
+		&models.WorktreeInfo{Path: "/src/outside", Branch: "outside"},
+		&models.WorktreeInfo{Path: "/src/frozen", Branch: "frozen", Locked: true},
+	)
+	m.showAdoptWorktrees()
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	if !ok {
+		t.Fatalf("expected confirm screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(confirmScreen.Message, "Move 1 worktree(s)") ||
+		!strings.Contains(confirmScreen.Message, filepath.Join(repoDir, "outside")) ||
+		!strings.Contains(confirmScreen.Message, "Skipped:\n/src/frozen (locked)") {
+		t.Fatalf("unexpected confirmation: %q", confirmScreen.Message)
+	}
+}
+
+func TestHandleMoveWorktreesResultMigratesState(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.repoKey = "repo"
+	m.worktreeNotes[worktreeNoteKey("/src/outside")] = models.WorktreeNote{Note: "keep me"}
+	m.state.data.accessHistory["/src/outside"] = 42
+	newPath := filepath.Join(cfg.WorktreeDir, "repo", "outside")
+
+	m.handleMoveWorktreesResult(moveWorktreesResultMsg{
+		moved:     []worktreeMove{{oldPath: "/src/outside", newPath: newPath}},
+		failures:  []string{"failed to move /src/other"},
+		worktrees: []*models.WorktreeInfo{{Path: "/src/repo", Branch: mainWorktreeName, IsMain: true}, {Path: newPath, Branch: "outside"}},
+	})
+
+	if note, ok := m.worktreeNotes[worktreeNoteKey(newPath)]; !ok || note.Note != "keep me" {
+		t.Fatalf("expected note to follow the worktree, got %v", m.worktreeNotes)
+	}
+	if _, ok := m.state.data.accessHistory["/src/outside"]; ok || m.state.data.accessHistory[newPath] != 42 {
+		t.Fatalf("expected access history to follow the worktree, got %v", m.state.data.accessHistory)
+	}
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "/src/other") {
+		t.Fatalf("expected move failure info, got %v", m.state.ui.screenManager.Type())
+	}
+}
@@ -12,7 +12,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
 	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/multiplexer"
 	"github.com/chmouel/lazyworktree/internal/utils"
 )
 
@@ -174,14 +176,14 @@ func (m *Model) showCreateFromChangesInput(wt *models.WorktreeInfo, currentBranc
 		}
 
 		// Check if worktree path already exists
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), newBranch)
+		targetPath := m.worktreeLayout().Path(newBranch, newBranch)
 		if _, err := os.Stat(targetPath); err == nil {
 			inputScr.ErrorMsg = fmt.Sprintf("Path already exists: %s", targetPath)
 			return nil
 		}
 
 		inputScr.ErrorMsg = ""
-		if err := os.MkdirAll(m.getRepoWorktreeDir(), 0o750); err != nil {
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0o750); err != nil {
 			return func() tea.Msg { return errMsg{err: fmt.Errorf("failed to create worktree directory: %w", err)} }
 		}
 
@@ -231,6 +233,8 @@ func (m *Model) showCreateFromChangesInput(wt *models.WorktreeInfo, currentBranc
 
 		// Drop the stash from the original location
 		m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "stash", "drop", stashRef}, wt.Path, "Failed to drop stash")
+		m.updateNewWorktreeSubmodules(targetPath)
+		m.recordBaseRef(targetPath, newBranch, currentBranch)
 
 		// Run init commands and refresh
 		env := m.buildCommandEnv(newBranch, targetPath)
@@ -350,7 +354,7 @@ func (m *Model) handleCreateFromCurrentReady(msg createFromCurrentReadyMsg) tea.
 			return nil
 		}
 
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), newBranch)
+		targetPath := m.worktreeLayout().Path(newBranch, newBranch)
 		if m.worktreePathExists(targetPath) {
 			inputScr.ErrorMsg = fmt.Sprintf("Path already exists: %s", targetPath)
 			return nil
@@ -395,7 +399,7 @@ func (m *Model) handleCreateFromCurrentReady(msg createFromCurrentReadyMsg) tea.
 // executeCreateWithChanges creates a worktree and moves changes from the current worktree.
 func (m *Model) executeCreateWithChanges(wt *models.WorktreeInfo, currentBranch, newBranch, targetPath string) tea.Cmd {
 	return func() tea.Msg {
-		if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
+		if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
 			return errMsg{err: err}
 		}
 
@@ -451,6 +455,8 @@ func (m *Model) executeCreateWithChanges(wt *models.WorktreeInfo, currentBranch,
 
 		// Drop the stash from the original location
 		m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "stash", "drop", stashRef}, wt.Path, "Failed to drop stash")
+		m.updateNewWorktreeSubmodules(targetPath)
+		m.recordBaseRef(targetPath, newBranch, currentBranch)
 
 		// Run init commands and refresh
 		env := m.buildCommandEnv(newBranch, targetPath)
@@ -469,7 +475,7 @@ func (m *Model) executeCreateWithChanges(wt *models.WorktreeInfo, currentBranch,
 // executeCreateWithoutChanges creates a worktree without moving changes.
 func (m *Model) executeCreateWithoutChanges(currentBranch, newBranch, targetPath string) tea.Cmd {
 	return func() tea.Msg {
-		if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
+		if err := m.ensureWorktreeDir(filepath.Dir(targetPath)); err != nil {
 			return errMsg{err: err}
 		}
 
@@ -477,6 +483,8 @@ func (m *Model) executeCreateWithoutChanges(currentBranch, newBranch, targetPath
 		if !m.state.services.git.RunCommandChecked(m.ctx, args, "", fmt.Sprintf("Failed to create worktree %s", newBranch)) {
 			return errMsg{err: fmt.Errorf("failed to create worktree %s", newBranch)}
 		}
+		m.updateNewWorktreeSubmodules(targetPath)
+		m.recordBaseRef(targetPath, newBranch, currentBranch)
 
 		env := m.buildCommandEnv(newBranch, targetPath)
 		initCmds := m.collectInitCommands()
@@ -500,12 +508,77 @@ func (m *Model) showDeleteWorktree() tea.Cmd {
 	if wt.IsMain {
 		return nil
 	}
+	if wt.Locked {
+		m.showInfo(fmt.Sprintf("Worktree is %s.\n\nUnlock it before deleting.", services.DescribeLock(wt)), nil)
+		return nil
+	}
 	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Delete worktree?\n\nPath: %s\nBranch: %s", wt.Path, wt.Branch), m.theme)
 	confirmScreen.OnConfirm = m.deleteWorktreeOnlyCmd(wt)
 	m.state.ui.screenManager.Push(confirmScreen)
 	return nil
 }
 
+// showLockWorktree shows an input screen for the reason of locking a worktree.
+func (m *Model) showLockWorktree() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if wt.IsMain {
+		m.showInfo("Cannot lock the main worktree.", nil)
+		return nil
+	}
+	if wt.Locked {
+		m.showInfo(fmt.Sprintf("Worktree is already %s.", services.DescribeLock(wt)), nil)
+		return nil
+	}
+
+	prompt := fmt.Sprintf("Lock '%s' (reason is optional)", filepath.Base(wt.Path))
+	inputScr := appscreen.NewInputScreen(prompt, "Reason", "", m.theme, m.config.IconsEnabled())
+	inputScr.OnSubmit = func(value string, _ bool) tea.Cmd {
+		args := []string{"git", "worktree", "lock"}
+		if reason := strings.TrimSpace(value); reason != "" {
+			args = append(args, "--reason", reason)
+		}
+		args = append(args, wt.Path)
+		return m.runWorktreeLockCmd(args, fmt.Sprintf("Failed to lock worktree %s", wt.Path))
+	}
+	inputScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(inputScr)
+	return textinput.Blink
+}
+
+// unlockWorktree unlocks the selected worktree.
+func (m *Model) unlockWorktree() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if !wt.Locked {
+		m.showInfo("Worktree is not locked.", nil)
+		return nil
+	}
+	return m.runWorktreeLockCmd([]string{"git", "worktree", "unlock", wt.Path}, fmt.Sprintf("Failed to unlock worktree %s", wt.Path))
+}
+
+// runWorktreeLockCmd runs a git worktree lock or unlock command and reloads
+// the worktrees so the table shows the new state.
+func (m *Model) runWorktreeLockCmd(args []string, errorMsg string) tea.Cmd {
+	return func() tea.Msg {
+		m.state.services.git.RunCommandChecked(m.ctx, args, "", errorMsg)
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return worktreesLoadedMsg{
+			worktrees: worktrees,
+			err:       err,
+		}
+	}
+}
+
 // showRenameWorktree shows an input screen for renaming a worktree.
 func (m *Model) showRenameWorktree() tea.Cmd {
 	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
@@ -533,8 +606,11 @@ func (m *Model) showRenameWorktree() tea.Cmd {
 			return nil
 		}
 
-		parentDir := filepath.Dir(wt.Path)
-		newPath := filepath.Join(parentDir, newBranch)
+		renamedBranch := wt.Branch
+		if filepath.Base(wt.Path) == wt.Branch {
+			renamedBranch = newBranch
+		}
+		newPath := m.worktreeLayout().RenamePath(wt.Path, newBranch, renamedBranch)
 		if _, err := os.Stat(newPath); err == nil {
 			inputScr.ErrorMsg = fmt.Sprintf("Destination already exists: %s", newPath)
 			return nil
@@ -545,6 +621,13 @@ func (m *Model) showRenameWorktree() tea.Cmd {
 		oldBranch := wt.Branch
 
 		return func() tea.Msg {
+			if err := os.MkdirAll(filepath.Dir(newPath), 0o750); err != nil {
+				return renameWorktreeResultMsg{
+					oldPath: oldPath,
+					newPath: newPath,
+					err:     fmt.Errorf("failed to create worktree directory: %w", err),
+				}
+			}
 			ok := m.state.services.git.RenameWorktree(m.ctx, oldPath, newPath, oldBranch, newBranch)
 			if !ok {
 				return renameWorktreeResultMsg{
@@ -553,6 +636,7 @@ func (m *Model) showRenameWorktree() tea.Cmd {
 					err:     fmt.Errorf("failed to rename %s to %s", oldBranch, newBranch),
 				}
 			}
+			multiplexer.RenameWorktreeSessions(m.ctx, m.config.SessionPrefix, oldPath, newPath)
 			worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
 			return renameWorktreeResultMsg{
 				oldPath:   oldPath,
@@ -706,11 +790,10 @@ func (m *Model) showPruneMerged() tea.Cmd {
 
 // performMergedWorktreeCheck checks for merged worktrees and shows a checklist.
 func (m *Model) performMergedWorktreeCheck() tea.Cmd {
-	mainBranch := m.state.services.git.GetMainBranch(m.ctx)
-
+	// Locked worktrees are never offered for pruning
 	wtBranches := make(map[string]*models.WorktreeInfo)
 	for _, wt := range m.state.data.worktrees {
-		if !wt.IsMain {
+		if !wt.IsMain && !wt.Locked {
 			wtBranches[wt.Branch] = wt
 		}
 	}
@@ -724,7 +807,7 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 
 	// 1. PR-based detection (existing logic)
 	for _, wt := range m.state.data.worktrees {
-		if wt.IsMain {
+		if wt.IsMain || wt.Locked {
 			continue
 		}
 		if wt.PR != nil && strings.EqualFold(wt.PR.State, "MERGED") {
@@ -732,16 +815,13 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 		}
 	}
 
-	// 2. Git-based detection
-	mergedBranches := m.state.services.git.GetMergedBranches(m.ctx, mainBranch)
-	for _, branch := range mergedBranches {
-		if wt, exists := wtBranches[branch]; exists {
-			if existing, found := candidateMap[branch]; found {
-				existing.source = "both"
-				candidateMap[branch] = existing
-			} else {
-				candidateMap[branch] = candidate{wt: wt, source: "git"}
-			}
+	// 2. Git-based detection, against the base branch of each worktree
+	for _, branch := range services.MergedIntoBase(m.ctx, m.state.services.git, wtBranches) {
+		if existing, found := candidateMap[branch]; found {
+			existing.source = "both"
+			candidateMap[branch] = existing
+		} else {
+			candidateMap[branch] = candidate{wt: wtBranches[branch], source: "git"}
 		}
 	}
 
@@ -861,7 +941,7 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 			// Delete orphaned directories
 			// Re-fetch valid paths to ensure we have current state
 			validPaths := m.getValidWorktreePaths()
-			repoDir := m.getRepoWorktreeDir()
+			repoDir := m.getWorktreeRootDir()
 
 			for _, orphanPath := range orphansToDelete {
 				// Re-validate: skip if now registered with git
@@ -874,7 +954,7 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 				}
 
 				// Verify path is still within expected repo directory bounds
-				if !strings.HasPrefix(orphanPath, repoDir) {
+				if repoDir == "" || !strings.HasPrefix(orphanPath, repoDir) {
 					failed++
 					continue
 				}
@@ -908,7 +988,8 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 	return textinput.Blink
 }
 
-// showAbsorbWorktree shows a confirmation dialog for absorbing a worktree into main.
+// showAbsorbWorktree shows a confirmation dialog for absorbing a worktree into
+// its base branch, main unless another branch was recorded at creation.
 func (m *Model) showAbsorbWorktree() tea.Cmd {
 	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
 		return nil
@@ -927,57 +1008,63 @@ func (m *Model) showAbsorbWorktree() tea.Cmd {
 		return nil
 	}
 
-	// Find the main worktree explicitly (don't use fallback)
-	var mainWorktree *models.WorktreeInfo
-	for _, w := range m.state.data.worktrees {
-		if w.IsMain {
-			mainWorktree = w
-			break
-		}
+	// Find the worktree of the base branch explicitly (don't use fallback)
+	baseBranch := services.BaseBranch(m.ctx, m.state.services.git, wt)
+	if wt.Branch == baseBranch {
+		m.showInfo(fmt.Sprintf("Cannot absorb: worktree is on its base branch (%s).", baseBranch), nil)
+		return nil
 	}
-	if mainWorktree == nil {
-		m.showInfo("Cannot find main worktree.", nil)
+	targetWorktree := services.BaseWorktree(m.state.data.worktrees, wt, baseBranch, mainBranch)
+	if targetWorktree == nil {
+		if baseBranch == mainBranch {
+			m.showInfo("Cannot find main worktree.", nil)
+		} else {
+			m.showInfo(fmt.Sprintf("Cannot absorb: the base branch %s is not checked out in any worktree.", baseBranch), nil)
+		}
 		return nil
 	}
 
-	// Check if main worktree has uncommitted changes
-	if mainWorktree.Dirty {
-		m.showInfo(fmt.Sprintf("Cannot absorb: main worktree has uncommitted changes.\n\nCommit or stash changes in:\n%s", mainWorktree.Path), nil)
+	// Check if the base worktree has uncommitted changes
+	if targetWorktree.Dirty {
+		m.showInfo(fmt.Sprintf("Cannot absorb: %s worktree has uncommitted changes.\n\nCommit or stash changes in:\n%s", targetWorktree.Branch, targetWorktree.Path), nil)
 		return nil
 	}
 
-	mainPath := mainWorktree.Path
+	targetBranch := targetWorktree.Branch
+	targetPath := targetWorktree.Path
 	mergeMethod := m.config.MergeMethod
 	if mergeMethod == "" {
 		mergeMethod = mergeMethodRebase
 	}
 
-	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Absorb worktree into %s (%s)?\n\nPath: %s\nBranch: %s -> %s", mainBranch, mergeMethod, wt.Path, wt.Branch, mainBranch), m.theme)
+	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Absorb worktree into %s (%s)?\n\nPath: %s\nBranch: %s -> %s", targetBranch, mergeMethod, wt.Path, wt.Branch, targetBranch), m.theme)
 	confirmScreen.OnConfirm = func() tea.Cmd {
 		return func() tea.Msg {
 			if mergeMethod == mergeMethodRebase {
-				// Rebase: first rebase the feature branch onto main, then fast-forward main
-				if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", wt.Path, "rebase", mainBranch}, "", fmt.Sprintf("Failed to rebase %s onto %s", wt.Branch, mainBranch)) {
+				// Rebase: first rebase the feature branch onto the base, then fast-forward the base
+				if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", wt.Path, "rebase", targetBranch}, "", fmt.Sprintf("Failed to rebase %s onto %s", wt.Branch, targetBranch)) {
 					return absorbMergeResultMsg{
-						path:   wt.Path,
-						branch: wt.Branch,
-						err:    fmt.Errorf("rebase failed; resolve conflicts in %s and retry", wt.Path),
+						path:         wt.Path,
+						branch:       wt.Branch,
+						conflictPath: wt.Path,
+						err:          fmt.Errorf("rebase failed; resolve conflicts in %s and retry", wt.Path),
 					}
 				}
-				// Fast-forward main to the rebased branch
-				if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", mainPath, "merge", "--ff-only", wt.Branch}, "", fmt.Sprintf("Failed to fast-forward %s to %s", mainBranch, wt.Branch)) {
+				// Fast-forward the base to the rebased branch
+				if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", targetPath, "merge", "--ff-only", wt.Branch}, "", fmt.Sprintf("Failed to fast-forward %s to %s", targetBranch, wt.Branch)) {
 					return absorbMergeResultMsg{
 						path:   wt.Path,
 						branch: wt.Branch,
 						err:    fmt.Errorf("fast-forward failed; the branch may have diverged"),
 					}
 				}
-			} else if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", mainPath, "merge", "--no-edit", wt.Branch}, "", fmt.Sprintf("Failed to merge %s into %s", wt.Branch, mainBranch)) {
+			} else if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", targetPath, "merge", "--no-edit", wt.Branch}, "", fmt.Sprintf("Failed to merge %s into %s", wt.Branch, targetBranch)) {
 				// Merge: traditional merge
 				return absorbMergeResultMsg{
-					path:   wt.Path,
-					branch: wt.Branch,
-					err:    fmt.Errorf("merge failed; resolve conflicts in %s and retry", mainPath),
+					path:         wt.Path,
+					branch:       wt.Branch,
+					conflictPath: targetPath,
+					err:          fmt.Errorf("merge failed; resolve conflicts in %s and retry", targetPath),
 				}
 			}
 
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -463,6 +464,71 @@ func TestShowAbsorbWorktreeCreatesConfirmScreen(t *testing.T) {
 	}
 }
 
+func TestShowAbsorbWorktreeIntoRecordedBase(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
+		if name == "git" && len(args) > 0 && args[0] == "rev-parse" && args[len(args)-1] == "refs/heads/release" {
+			return exec.Command("printf", "abc123")
+		}
+		return exec.Command("printf", "")
+	}
+
+	feature := &models.WorktreeInfo{Path: "/path/to/feature", Branch: "feature-branch", BaseRef: "release"}
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/path/to/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/path/to/release", Branch: "release"},
+		feature,
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 2
+
+	m.showAbsorbWorktree()
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	if !ok {
+		t.Fatalf("Expected confirm screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(confirmScreen.Message, "Absorb worktree into release") || !strings.Contains(confirmScreen.Message, "feature-branch -> release") {
+		t.Errorf("Expected the recorded base as absorb target, got %q", confirmScreen.Message)
+	}
+
+	if info := m.buildInfoContent(feature); !strings.Contains(info, "Base:") || !strings.Contains(info, "release") {
+		t.Errorf("Expected the base in the info pane, got %q", info)
+	}
+}
+
+func TestShowAbsorbWorktreeTrackingRecordedBase(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
+		if name == "git" && len(args) > 0 && args[0] == "rev-parse" && args[len(args)-1] == "refs/remotes/origin/foo" {
+			return exec.Command("printf", "abc123")
+		}
+		return exec.Command("printf", "")
+	}
+
+	// Created from origin/foo, which also became its upstream
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/path/to/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/path/to/foo", Branch: "foo", UpstreamBranch: "origin/foo", BaseRef: "origin/foo"},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 1
+
+	m.showAbsorbWorktree()
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	if !ok {
+		t.Fatalf("Expected confirm screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(confirmScreen.Message, "Absorb worktree into main") {
+		t.Errorf("Expected a branch tracking its recorded base to absorb into main, got %q", confirmScreen.Message)
+	}
+}
+
 func TestShowAbsorbWorktreeNoMainWorktree(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
@@ -558,6 +624,66 @@ func TestShowDeleteWorktree(t *testing.T) {
 	}
 }
 
+func TestShowDeleteWorktreeLocked(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+	m.state.data.filteredWts = []*models.WorktreeInfo{
+		{Path: "/tmp/feat", Branch: featureBranch, Locked: true, LockReason: "on usb"},
+	}
+	m.state.data.selectedIndex = 0
+
+	m.showDeleteWorktree()
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok {
+		t.Fatalf("expected info screen for locked worktree, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(infoScreen.Message, "locked (on usb)") {
+		t.Fatalf("expected lock reason in info, got %q", infoScreen.Message)
+	}
+}
+
+func TestShowLockWorktree(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+	m.state.data.filteredWts = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/tmp/feat", Branch: featureBranch},
+		{Path: "/tmp/locked", Branch: "locked", Locked: true},
+	}
+
+	m.state.data.selectedIndex = 0
+	m.showLockWorktree()
+	if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+		t.Fatal("expected info screen for main worktree")
+	}
+	m.state.ui.screenManager.Pop()
+
+	m.state.data.selectedIndex = 1
+	if cmd := m.showLockWorktree(); cmd == nil {
+		t.Fatal("expected blink command for input screen")
+	}
+	if m.state.ui.screenManager.Type() != appscreen.TypeInput {
+		t.Fatal("expected input screen for the lock reason")
+	}
+	if cmd := m.unlockWorktree(); cmd != nil {
+		t.Fatal("expected nil command when unlocking an unlocked worktree")
+	}
+	m.state.ui.screenManager.Pop()
+	m.state.ui.screenManager.Pop()
+
+	m.state.data.selectedIndex = 2
+	if cmd := m.showLockWorktree(); cmd != nil {
+		t.Fatal("expected nil command when locking a locked worktree")
+	}
+	if cmd := m.unlockWorktree(); cmd == nil {
+		t.Fatal("expected unlock command for a locked worktree")
+	}
+}
+
 func TestShowRenameWorktree(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
@@ -682,6 +808,36 @@ func TestShowPruneMergedUnknownHost(t *testing.T) {
 	}
 }
 
+func TestPerformMergedWorktreeCheckSkipsLocked(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+
+	repo := t.TempDir()
+	runGit(t, repo, "init")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "config", "commit.gpgsign", "false")
+	runGit(t, repo, "commit", "--allow-empty", "-m", "Initial commit")
+
+	withCwd(t, repo)
+
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: repo, Branch: mainWorktreeName, IsMain: true},
+		{Path: filepath.Join(cfg.WorktreeDir, "locked"), Branch: "locked", Locked: true, PR: &models.PRInfo{Number: 1, State: "MERGED"}},
+	}
+
+	m.performMergedWorktreeCheck()
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok {
+		t.Fatalf("expected info screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(infoScreen.Message, "No merged worktrees") {
+		t.Fatalf("expected no candidates, got %q", infoScreen.Message)
+	}
+}
+
 func TestShowCreateFromCurrent(t *testing.T) {
 	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
 	m := NewModel(cfg, "")
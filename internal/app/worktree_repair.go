@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+)
+
+// showRepairWorktrees scans the worktrees for problems and lists one entry
+// per issue and fix. Selecting an entry applies that fix.
+func (m *Model) showRepairWorktrees() tea.Cmd {
+	issues := services.ScanWorktreeHealth(m.state.data.worktrees, m.getWorktreeRootDir())
+	if len(issues) == 0 {
+		m.showInfo("No worktree problems found.", nil)
+		return nil
+	}
+
+	items := make([]appscreen.SelectionItem, 0, len(issues))
+	for i, issue := range issues {
+		for _, fix := range issue.Fixes {
+			items = append(items, appscreen.SelectionItem{
+				ID:          fmt.Sprintf("%d:%s", i, fix),
+				Label:       fmt.Sprintf("%s %s", fix, m.repairDisplayPath(issue.Path)),
+				Description: fmt.Sprintf("%s: %s", issue.Kind, issue.Detail),
+			})
+		}
+	}
+
+	listScreen := appscreen.NewListSelectionScreen(
+		items,
+		"Repair Worktrees",
+		"Filter...",
+		"No problems match.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
+		m.theme,
+	)
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		index, fix, ok := strings.Cut(item.ID, ":")
+		if !ok {
+			return nil
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(issues) {
+			return nil
+		}
+		issue := issues[i]
+		if services.HealthFix(fix) == services.HealthFixRemove && issue.Kind == services.HealthOrphanedDir {
+			confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Delete orphaned directory and all its files?\n\n%s", issue.Path), m.theme)
+			confirmScreen.OnConfirm = func() tea.Cmd {
+				return m.fixHealthIssueCmd(issue, services.HealthFixRemove)
+			}
+			// Replace the list so it is not left behind the confirmation.
+			m.state.ui.screenManager.Pop()
+			m.state.ui.screenManager.Push(confirmScreen)
+			return nil
+		}
+		return m.fixHealthIssueCmd(issue, services.HealthFix(fix))
+	}
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(listScreen)
+	return nil
+}
+
+// fixHealthIssueCmd applies fix to issue and reloads the worktrees.
+func (m *Model) fixHealthIssueCmd(issue services.HealthIssue, fix services.HealthFix) tea.Cmd {
+	return func() tea.Msg {
+		fixErr := m.state.services.worktree.FixHealthIssue(m.ctx, issue, fix)
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return repairResultMsg{
+			worktrees: worktrees,
+			err:       err,
+			fixErr:    fixErr,
+		}
+	}
+}
+
+// handleRepairResult reloads the worktrees and shows the remaining problems.
+func (m *Model) handleRepairResult(msg repairResultMsg) (tea.Model, tea.Cmd) {
+	model, cmd := m.handleWorktreesLoaded(worktreesLoadedMsg{worktrees: msg.worktrees, err: msg.err})
+	if msg.err != nil {
+		return model, cmd
+	}
+	if msg.fixErr != nil {
+		m.showInfo(fmt.Sprintf("Repair failed\n\n%s", msg.fixErr.Error()), nil)
+		return model, cmd
+	}
+	return model, tea.Batch(cmd, m.showRepairWorktrees())
+}
+
+// repairDisplayPath shortens paths inside the repository worktree dir.
+func (m *Model) repairDisplayPath(path string) string {
+	root, _ := m.worktreeLayout().Root()
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowRepairWorktrees(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.repoKey = "repo"
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+	}
+
+	m.showRepairWorktrees()
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "No worktree problems") {
+		t.Fatalf("expected no problems info, got %v", m.state.ui.screenManager.Type())
+	}
+	m.state.ui.screenManager.Pop()
+
+	orphan := filepath.Join(cfg.WorktreeDir, "repo", "orphan")
+	if err := os.MkdirAll(orphan, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	m.showRepairWorktrees()
+	listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if !ok {
+		t.Fatalf("expected list screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if len(listScreen.Items) != 2 || listScreen.Items[0].Label != "adopt orphan" || listScreen.Items[1].Label != "remove orphan" {
+		t.Fatalf("unexpected items: %+v", listScreen.Items)
+	}
+
+	if cmd := listScreen.OnSelect(listScreen.Items[1]); cmd != nil {
+		t.Fatal("expected removal to ask for confirmation first")
+	}
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	if !ok {
+		t.Fatalf("expected confirm screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if !strings.Contains(confirmScreen.Message, orphan) {
+		t.Fatalf("expected orphan path in confirmation, got %q", confirmScreen.Message)
+	}
+	m.state.ui.screenManager.Pop()
+	if m.state.ui.screenManager.IsActive() {
+		t.Fatal("expected the list to be replaced by the confirmation")
+	}
+}
+
+func TestHandleRepairResultShowsFixError(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.repoKey = "repo"
+
+	m.handleRepairResult(repairResultMsg{
+		worktrees: []*models.WorktreeInfo{{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true}},
+		fixErr:    errors.New("boom"),
+	})
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "Repair failed") || !strings.Contains(infoScreen.Message, "boom") {
+		t.Fatalf("expected repair failure info, got %v", m.state.ui.screenManager.Type())
+	}
+}
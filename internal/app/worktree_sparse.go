@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+)
+
+const sparseFullCheckoutID = "\x00full"
+
+// sparseProfiles returns the sparse-checkout profiles of the configuration,
+// overridden by those of the .wt file of the repository.
+func (m *Model) sparseProfiles() map[string][]string {
+	m.ensureRepoConfig()
+	return config.SparseProfiles(m.config, m.repoConfig)
+}
+
+// sparseProfileItems lists a full checkout followed by every profile.
+func sparseProfileItems(profiles map[string][]string) []appscreen.SelectionItem {
+	items := []appscreen.SelectionItem{
+		{ID: sparseFullCheckoutID, Label: "Full checkout", Description: "Check out every file"},
+	}
+	for _, name := range config.SparseProfileNames(profiles) {
+		items = append(items, appscreen.SelectionItem{
+			ID:          name,
+			Label:       name,
+			Description: strings.Join(profiles[name], ", "),
+		})
+	}
+	return items
+}
+
+// withSparseProfile asks which sparse-checkout profile a new worktree uses
+// and calls next with its directories, nil for a full checkout. Without any
+// profile configured, next is called right away.
+func (m *Model) withSparseProfile(next func(sparseDirs []string) tea.Cmd) tea.Cmd {
+	profiles := m.sparseProfiles()
+	if len(profiles) == 0 {
+		return next(nil)
+	}
+
+	listScreen := appscreen.NewListSelectionScreen(
+		sparseProfileItems(profiles),
+		"Sparse-checkout profile",
+		"Filter profiles...",
+		"No profiles.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
+		m.theme,
+	)
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		return next(profiles[item.ID])
+	}
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(listScreen)
+	return textinput.Blink
+}
+
+// checkoutSparse checks out the worktree at targetPath, added with
+// --no-checkout, restricted to sparseDirs. The worktree is removed when this
+// fails. Nothing is done without sparseDirs.
+func (m *Model) checkoutSparse(targetPath string, sparseDirs []string) error {
+	if len(sparseDirs) == 0 {
+		return nil
+	}
+	if err := services.CheckoutSparse(m.ctx, m.state.services.git, targetPath, sparseDirs); err != nil {
+		m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "worktree", "remove", "--force", targetPath}, "", "Failed to cleanup worktree")
+		return err
+	}
+	return nil
+}
+
+// sparseProfileLabel returns the name of the profile checking out dirs, or
+// "custom" when none does. The .wt file is the one loaded with the worktrees,
+// so this is safe to call while rendering.
+func (m *Model) sparseProfileLabel(dirs []string) string {
+	if name := services.SparseProfileName(config.SparseProfiles(m.config, m.repoConfig), dirs); name != "" {
+		return name
+	}
+	return "custom"
+}
+
+// showChangeSparseProfile lets the user switch the selected worktree to
+// another sparse-checkout profile, or back to a full checkout.
+func (m *Model) showChangeSparseProfile() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+
+	profiles := m.sparseProfiles()
+	if len(profiles) == 0 {
+		m.showInfo("No sparse-checkout profiles configured.\n\nAdd sparse_profiles to the configuration or the .wt file of the repository.", nil)
+		return nil
+	}
+
+	current := ""
+	if wt.Sparse {
+		current = m.sparseProfileLabel(wt.SparseDirs)
+	}
+	title := fmt.Sprintf("Sparse-checkout profile for %s", filepath.Base(wt.Path))
+	if current != "" {
+		title = fmt.Sprintf("%s (currently %s)", title, current)
+	}
+
+	listScreen := appscreen.NewListSelectionScreen(
+		sparseProfileItems(profiles),
+		title,
+		"Filter profiles...",
+		"No profiles.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
+		m.theme,
+	)
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		dirs := profiles[item.ID]
+		if len(dirs) == 0 && !wt.Sparse {
+			return nil
+		}
+		return m.setSparseCheckoutCmd(wt.Path, dirs)
+	}
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(listScreen)
+	return textinput.Blink
+}
+
+// setSparseCheckoutCmd applies the sparse-checkout directories to the
+// worktree at path and reloads the worktrees.
+func (m *Model) setSparseCheckoutCmd(path string, dirs []string) tea.Cmd {
+	return func() tea.Msg {
+		if err := services.SetSparseCheckout(m.ctx, m.state.services.git, path, dirs); err != nil {
+			return errMsg{err: err}
+		}
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return worktreesLoadedMsg{
+			worktrees: worktrees,
+			err:       err,
+		}
+	}
+}
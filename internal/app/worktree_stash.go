@@ -0,0 +1,245 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const (
+	stashActionDiff  = "diff"
+	stashActionApply = "apply"
+	stashActionPop   = "pop"
+	stashActionDrop  = "drop"
+)
+
+// stashResultMsg reports a stash operation, with the worktrees it changed.
+type stashResultMsg struct {
+	action  string
+	message string
+	paths   []string
+	err     error
+}
+
+// showStashes lists the stashes of the repository, which every worktree
+// shares, with the branch each one was created on.
+func (m *Model) showStashes() tea.Cmd {
+	stashes := m.state.services.git.ListStashes(m.ctx)
+	if len(stashes) == 0 {
+		m.showInfo("No stashes.", nil)
+		return nil
+	}
+
+	items := make([]appscreen.SelectionItem, 0, len(stashes))
+	for _, stash := range stashes {
+		items = append(items, stashSelectionItem(stash))
+	}
+
+	listScreen := appscreen.NewListSelectionScreen(
+		items,
+		fmt.Sprintf("Stashes (%d)", len(stashes)),
+		"Filter stashes...",
+		"No stashes found.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
+		m.theme,
+	)
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		for _, stash := range stashes {
+			if stash.SHA == item.ID {
+				return m.showStashActions(stash)
+			}
+		}
+		return nil
+	}
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(listScreen)
+	return textinput.Blink
+}
+
+func stashSelectionItem(stash *models.StashEntry) appscreen.SelectionItem {
+	desc := "on " + stash.Branch
+	if stash.Branch == "" {
+		desc = "detached HEAD"
+	}
+	if !stash.Created.IsZero() {
+		desc += ", " + formatRelativeTime(stash.Created)
+	}
+	return appscreen.SelectionItem{
+		ID:          stash.SHA,
+		Label:       fmt.Sprintf("%s: %s", stash.Ref, stash.Message),
+		Description: desc,
+	}
+}
+
+// showStashActions offers to show, apply, pop or drop the stash.
+func (m *Model) showStashActions(stash *models.StashEntry) tea.Cmd {
+	items := []appscreen.SelectionItem{
+		{ID: stashActionDiff, Label: "Show diff", Description: "Show the changes of the stash"},
+		{ID: stashActionApply, Label: "Apply to worktree", Description: "Apply the stash and keep it"},
+		{ID: stashActionPop, Label: "Pop to worktree", Description: "Apply the stash and drop it"},
+		{ID: stashActionDrop, Label: "Drop", Description: "Delete the stash"},
+	}
+
+	listScreen := appscreen.NewListSelectionScreen(
+		items,
+		fmt.Sprintf("%s: %s", stash.Ref, stash.Message),
+		"Filter actions...",
+		"No actions.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
+		m.theme,
+	)
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		switch item.ID {
+		case stashActionDiff:
+			// Back to the stash list once the pager exits
+			return m.showStashDiff(stash.SHA, m.stashDiffWorktree())
+		case stashActionApply, stashActionPop:
+			return m.showStashApplyPicker(stash, item.ID == stashActionPop)
+		case stashActionDrop:
+			return m.showDropStash(stash)
+		}
+		return nil
+	}
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(listScreen)
+	return textinput.Blink
+}
+
+// stashDiffWorktree returns the worktree a stash diff is shown from.
+func (m *Model) stashDiffWorktree() *models.WorktreeInfo {
+	if m.state.data.selectedIndex >= 0 && m.state.data.selectedIndex < len(m.state.data.filteredWts) {
+		return m.state.data.filteredWts[m.state.data.selectedIndex]
+	}
+	for _, wt := range m.state.data.worktrees {
+		if wt.IsMain {
+			return wt
+		}
+	}
+	return nil
+}
+
+// showStashApplyPicker picks the worktree the stash is applied to, starting
+// on the one with the branch the stash was created on.
+func (m *Model) showStashApplyPicker(stash *models.StashEntry, pop bool) tea.Cmd {
+	initialPath := ""
+	if wt := m.stashDiffWorktree(); wt != nil {
+		initialPath = wt.Path
+	}
+	for _, wt := range m.state.data.worktrees {
+		if stash.Branch != "" && wt.Branch == stash.Branch {
+			initialPath = wt.Path
+			break
+		}
+	}
+
+	verb := "Apply"
+	if pop {
+		verb = "Pop"
+	}
+	return m.showWorktreePicker(
+		fmt.Sprintf("%s %s to worktree", verb, stash.Ref),
+		"No worktrees available.",
+		"",
+		initialPath,
+		func(target *models.WorktreeInfo) tea.Cmd {
+			m.state.ui.screenManager.Clear()
+			return m.applyStashCmd(stash, target, pop)
+		},
+	)
+}
+
+func (m *Model) applyStashCmd(stash *models.StashEntry, target *models.WorktreeInfo, pop bool) tea.Cmd {
+	action, verb := "Stash apply", "Applied"
+	if pop {
+		action, verb = "Stash pop", "Popped"
+	}
+	return func() tea.Msg {
+		err := m.state.services.git.ApplyStash(m.ctx, stash, target.Path, pop)
+		return stashResultMsg{
+			action:  action,
+			message: fmt.Sprintf("%s %s to %s (%s)", verb, stash.Ref, filepath.Base(target.Path), target.Branch),
+			paths:   []string{target.Path},
+			err:     err,
+		}
+	}
+}
+
+func (m *Model) showDropStash(stash *models.StashEntry) tea.Cmd {
+	confirmScreen := appscreen.NewConfirmScreen(
+		fmt.Sprintf("Drop %s?\n\n%s\n\nThis cannot be undone.", stash.Ref, stash.Message),
+		m.theme,
+	)
+	confirmScreen.OnConfirm = func() tea.Cmd {
+		m.state.ui.screenManager.Clear()
+		return func() tea.Msg {
+			return stashResultMsg{
+				action:  "Stash drop",
+				message: fmt.Sprintf("Dropped %s", stash.Ref),
+				err:     m.state.services.git.DropStash(m.ctx, stash),
+			}
+		}
+	}
+	m.state.ui.screenManager.Push(confirmScreen)
+	return nil
+}
+
+// showMoveChanges stashes the changes of the selected worktree and applies
+// them in the worktree picked, the stash being dropped once applied.
+func (m *Model) showMoveChanges() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	source := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if !source.Dirty {
+		m.showInfo(fmt.Sprintf("No changes to move in %s.", filepath.Base(source.Path)), nil)
+		return nil
+	}
+
+	return m.showWorktreePicker(
+		fmt.Sprintf("Move changes of %s to worktree", filepath.Base(source.Path)),
+		"No other worktrees to move changes to.",
+		source.Path,
+		"",
+		func(target *models.WorktreeInfo) tea.Cmd {
+			return func() tea.Msg {
+				err := m.state.services.git.TransferChanges(m.ctx, source.Path, target.Path, git.TransferStashMessage(target.Path))
+				return stashResultMsg{
+					action:  "Moving changes",
+					message: fmt.Sprintf("Moved changes of %s to %s (%s)", filepath.Base(source.Path), filepath.Base(target.Path), target.Branch),
+					paths:   []string{source.Path, target.Path},
+					err:     err,
+				}
+			}
+		},
+	)
+}
+
+// handleStashResult reports a stash operation and reloads the worktrees it
+// changed, whether it succeeded or left conflicts behind.
+func (m *Model) handleStashResult(msg stashResultMsg) tea.Cmd {
+	for _, path := range msg.paths {
+		m.deleteDetailsCache(path)
+	}
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("%s failed\n\nError: %v", msg.action, msg.err), m.refreshWorktrees())
+		return nil
+	}
+	m.showInfo(msg.message, m.refreshWorktrees())
+	return nil
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowMoveChanges(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/tmp/feat", Branch: featureBranch, Dirty: true},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+
+	m.state.data.selectedIndex = 0
+	m.showMoveChanges()
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "No changes") {
+		t.Fatalf("expected info screen for a clean worktree, got %v", m.state.ui.screenManager.Type())
+	}
+	m.state.ui.screenManager.Pop()
+
+	m.state.data.selectedIndex = 1
+	if cmd := m.showMoveChanges(); cmd == nil {
+		t.Fatal("expected blink command for worktree picker")
+	}
+	listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if !ok {
+		t.Fatalf("expected list selection screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if len(listScreen.Items) != 1 || listScreen.Items[0].ID != "/tmp/main" {
+		t.Fatalf("expected only the other worktree, got %+v", listScreen.Items)
+	}
+}
+
+func TestShowStashApplyPickerStartsOnStashBranch(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/tmp/feat", Branch: featureBranch},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+
+	stash := &models.StashEntry{Ref: "stash@{0}", SHA: "abc123", Branch: featureBranch, Message: "wip"}
+	if cmd := m.showStashApplyPicker(stash, true); cmd == nil {
+		t.Fatal("expected blink command for worktree picker")
+	}
+	listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if !ok {
+		t.Fatalf("expected list selection screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if len(listScreen.Items) != 2 || listScreen.Cursor != 1 {
+		t.Fatalf("expected cursor on the stash branch worktree, got %d in %+v", listScreen.Cursor, listScreen.Items)
+	}
+	if !strings.Contains(listScreen.Title, "Pop stash@{0}") {
+		t.Fatalf("unexpected title %q", listScreen.Title)
+	}
+}
+
+func TestShowStashActions(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	stash := &models.StashEntry{Ref: "stash@{1}", SHA: "def456", Branch: featureBranch, Message: "login"}
+
+	m.showStashActions(stash)
+	listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if !ok {
+		t.Fatalf("expected list selection screen, got %v", m.state.ui.screenManager.Type())
+	}
+	ids := make([]string, 0, len(listScreen.Items))
+	for _, item := range listScreen.Items {
+		ids = append(ids, item.ID)
+	}
+	if got := strings.Join(ids, ","); got != "diff,apply,pop,drop" {
+		t.Fatalf("unexpected actions %s", got)
+	}
+
+	listScreen.OnSelect(listScreen.Items[3])
+	if m.state.ui.screenManager.Type() != appscreen.TypeConfirm {
+		t.Fatalf("expected confirm screen for drop, got %v", m.state.ui.screenManager.Type())
+	}
+}
+
+func TestHandleStashResult(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.cache.detailsCache["/tmp/feat"] = &detailsCacheEntry{}
+
+	m.handleStashResult(stashResultMsg{action: "Stash pop", paths: []string{"/tmp/feat"}, err: errors.New("CONFLICT (content)")})
+	if _, ok := m.cache.detailsCache["/tmp/feat"]; ok {
+		t.Fatal("expected the details cache of the worktree to be cleared")
+	}
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScreen.Message, "Stash pop failed") || !strings.Contains(infoScreen.Message, "CONFLICT") {
+		t.Fatalf("expected failure info, got %v", m.state.ui.screenManager.Type())
+	}
+}
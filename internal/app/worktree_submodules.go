@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/security"
+)
+
+// submoduleSettings returns the submodule settings of the repository. Like its
+// commands, those of the .wt file only apply once it is trusted.
+func (m *Model) submoduleSettings() (update, reference bool) {
+	repoConfig := m.repoConfig
+	if repoConfig != nil {
+		switch strings.ToLower(strings.TrimSpace(m.config.TrustMode)) {
+		case "always":
+		case "never":
+			repoConfig = nil
+		default:
+			if m.repoConfigPath == "" || m.state.services.trustManager.CheckTrust(m.repoConfigPath) != security.TrustStatusTrusted {
+				repoConfig = nil
+			}
+		}
+	}
+	return config.SubmoduleSettings(m.config, repoConfig)
+}
+
+// updateNewWorktreeSubmodules updates the submodules of the worktree just
+// created at targetPath when submodule_update is enabled. A failure is
+// notified by the git service but leaves the worktree in place.
+func (m *Model) updateNewWorktreeSubmodules(targetPath string) {
+	update, reference := m.submoduleSettings()
+	if !update {
+		return
+	}
+	if err := services.UpdateSubmodules(m.ctx, m.state.services.git, targetPath, reference); err != nil {
+		m.debugf("submodule update failed: %v", err)
+	}
+}
+
+// updateSubmodules initialises and updates the submodules of the selected
+// worktree, then reloads the worktrees so the status pane shows their state.
+func (m *Model) updateSubmodules() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if !services.HasSubmodules(wt.Path) {
+		m.showInfo("This worktree has no submodules.", nil)
+		return nil
+	}
+
+	_, reference := m.submoduleSettings()
+	m.loading = true
+	m.statusContent = "Updating submodules..."
+	m.setLoadingScreen(m.statusContent)
+
+	// Clear cache so status pane refreshes
+	m.deleteDetailsCache(wt.Path)
+
+	return func() tea.Msg {
+		// Failures are notified by the git service, refresh regardless
+		if err := services.UpdateSubmodules(m.ctx, m.state.services.git, wt.Path, reference); err != nil {
+			m.debugf("submodule update failed: %v", err)
+		}
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return worktreesLoadedMsg{
+			worktrees: worktrees,
+			err:       err,
+		}
+	}
+}
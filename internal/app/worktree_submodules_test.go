@@ -0,0 +1,31 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+)
+
+func TestSubmoduleSettingsNeedTrustedRepoConfig(t *testing.T) {
+	enabled := true
+	for _, tc := range []struct {
+		trustMode string
+		want      bool
+	}{
+		{"always", true},
+		{"never", false},
+		{"tofu", false},
+	} {
+		m := NewModel(&config.AppConfig{WorktreeDir: t.TempDir(), TrustMode: tc.trustMode}, "")
+		m.repoConfig = &config.RepoConfig{SubmoduleUpdate: &enabled}
+		if update, _ := m.submoduleSettings(); update != tc.want {
+			t.Errorf("trust mode %q: submodule update = %v, want %v", tc.trustMode, update, tc.want)
+		}
+	}
+
+	m := NewModel(&config.AppConfig{WorktreeDir: t.TempDir(), TrustMode: "never", SubmoduleUpdate: true}, "")
+	m.repoConfig = &config.RepoConfig{}
+	if update, _ := m.submoduleSettings(); !update {
+		t.Error("expected the global submodule_update to apply without a trusted .wt file")
+	}
+}
@@ -13,6 +13,15 @@ import (
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
+// Options of the sync choice offered when a branch is behind its base.
+const (
+	syncChoiceGitHub = "gh"
+	syncChoiceLocal  = "local"
+	syncChoicePull   = "pull"
+
+	syncStageUpdateFromBase = "update-from-base"
+)
+
 // pushToUpstream pushes the current branch to its upstream.
 func (m *Model) pushToUpstream() tea.Cmd {
 	wt := m.selectedWorktree()
@@ -56,11 +65,9 @@ func (m *Model) syncWithUpstream() tea.Cmd {
 		return nil
 	}
 
-	// Check if this worktree has a PR and if we're behind the base branch
-	if wt.PR != nil && wt.PR.BaseBranch != "" {
-		if m.isBehindBase(wt) {
-			return m.showSyncChoice(wt)
-		}
+	// Offer to update from the base branch (the PR base, or main) when behind it
+	if base := m.worktreeBaseBranch(wt); base != "" && base != wt.Branch && m.isBehindBase(wt, base) {
+		return m.showSyncChoice(wt, base)
 	}
 
 	// Normal sync (pull + push)
@@ -94,15 +101,15 @@ func (m *Model) beginSync(wt *models.WorktreeInfo, pullArgs, pushArgs []string)
 	return m.runSync(wt, pullArgs, pushArgs)
 }
 
-// isBehindBase checks if the current branch is behind its base branch.
-func (m *Model) isBehindBase(wt *models.WorktreeInfo) bool {
-	if wt.PR == nil || wt.PR.BaseBranch == "" {
+// isBehindBase checks if the current branch is behind base.
+func (m *Model) isBehindBase(wt *models.WorktreeInfo, base string) bool {
+	if base == "" {
 		return false
 	}
 	// Check if current branch is behind the base branch
 	// Use git merge-base to find common ancestor, then check if we're behind
 	mergeBase := m.state.services.git.RunGit(m.ctx, []string{
-		"git", "merge-base", "HEAD", wt.PR.BaseBranch,
+		"git", "merge-base", "HEAD", base,
 	}, wt.Path, []int{0, 1}, true, false)
 
 	if mergeBase == "" {
@@ -111,45 +118,109 @@ func (m *Model) isBehindBase(wt *models.WorktreeInfo) bool {
 
 	// Check if there are commits in base that aren't in HEAD
 	behindCount := m.state.services.git.RunGit(m.ctx, []string{
-		"git", "rev-list", "--count", fmt.Sprintf("HEAD..%s", wt.PR.BaseBranch),
+		"git", "rev-list", "--count", fmt.Sprintf("HEAD..%s", base),
 	}, wt.Path, []int{0}, true, false)
 
 	behind, _ := strconv.Atoi(strings.TrimSpace(behindCount))
 	return behind > 0
 }
 
-// showSyncChoice shows a confirmation dialog for syncing with base branch.
-func (m *Model) showSyncChoice(wt *models.WorktreeInfo) tea.Cmd {
-	// Store the worktree for later use in confirm/cancel handlers
-	savedWt := wt
+// showSyncChoice lets the user pick how to synchronise a branch behind base:
+// update it from base with gh (for a GitHub PR) or locally with git, or pull
+// and push as usual.
+func (m *Model) showSyncChoice(wt *models.WorktreeInfo, base string) tea.Cmd {
+	mergeMethod := m.syncMergeMethod()
+	items := make([]appscreen.SelectionItem, 0, 3)
+	isGitLab := m.state.services.git.IsGitHubOrGitLab(m.ctx) && !m.state.services.git.IsGitHub(m.ctx)
+	if wt.PR != nil && wt.PR.BaseBranch != "" && !isGitLab {
+		items = append(items, appscreen.SelectionItem{
+			ID:          syncChoiceGitHub,
+			Label:       fmt.Sprintf("Update from %s with gh", base),
+			Description: fmt.Sprintf("gh pr update-branch (%s)", mergeMethod),
+		})
+	}
+	items = append(items,
+		appscreen.SelectionItem{
+			ID:          syncChoiceLocal,
+			Label:       fmt.Sprintf("Update from %s locally", base),
+			Description: fmt.Sprintf("git fetch, then %s onto the fetched %s", mergeMethod, base),
+		},
+		appscreen.SelectionItem{
+			ID:          syncChoicePull,
+			Label:       "Pull + push",
+			Description: "Synchronise with the upstream branch only",
+		},
+	)
 
-	confirmScreen := appscreen.NewConfirmScreen(
-		fmt.Sprintf("Branch behind %s\n\nUpdate from base branch?\n(This will merge/rebase latest %s into your branch.\nChoose 'No' for normal sync: pull + push)",
-			wt.PR.BaseBranch, wt.PR.BaseBranch),
+	listScreen := appscreen.NewListSelectionScreen(
+		items,
+		fmt.Sprintf("Branch behind %s", base),
+		"Filter...",
+		"No options.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
 		m.theme,
 	)
-	confirmScreen.OnConfirm = func() tea.Cmd {
-		// User chose YES: update from base
-		return m.updateFromBase(savedWt)
-	}
-	confirmScreen.OnCancel = func() tea.Cmd {
-		// User chose NO: do normal sync (pull + push)
-		if savedWt.HasUpstream {
-			remote, branch, ok := m.validatedUpstream(savedWt, "synchronise")
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		switch item.ID {
+		case syncChoiceGitHub:
+			return m.updateFromBase(wt)
+		case syncChoiceLocal:
+			return m.updateFromBaseLocal(wt, base)
+		}
+		if wt.HasUpstream {
+			remote, branch, ok := m.validatedUpstream(wt, "synchronise")
 			if !ok {
 				return nil
 			}
-			return m.beginSync(savedWt, []string{remote, branch}, []string{remote, fmt.Sprintf("HEAD:%s", branch)})
+			return m.beginSync(wt, []string{remote, branch}, []string{remote, fmt.Sprintf("HEAD:%s", branch)})
 		}
-		return m.showUpstreamInput(savedWt, func(remote, branch string) tea.Cmd {
-			return m.beginSync(savedWt, []string{remote, branch}, []string{"-u", remote, fmt.Sprintf("HEAD:%s", branch)})
+		return m.showUpstreamInput(wt, func(remote, branch string) tea.Cmd {
+			return m.beginSync(wt, []string{remote, branch}, []string{"-u", remote, fmt.Sprintf("HEAD:%s", branch)})
 		})
 	}
-	m.state.ui.screenManager.Push(confirmScreen)
-	return nil
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(listScreen)
+	return textinput.Blink
+}
+
+// syncMergeMethod returns the configured merge method, rebase by default.
+func (m *Model) syncMergeMethod() string {
+	mergeMethod := strings.TrimSpace(m.config.MergeMethod)
+	if mergeMethod == "" {
+		mergeMethod = mergeMethodRebase
+	}
+	return mergeMethod
 }
 
-// updateFromBase updates the branch from its base branch.
+// updateFromBaseLocal fetches base and rebases or merges it into the branch
+// of wt, following merge_method. Conflicts open the conflict screen.
+func (m *Model) updateFromBaseLocal(wt *models.WorktreeInfo, base string) tea.Cmd {
+	m.loading = true
+	m.loadingOperation = "sync"
+	m.statusContent = fmt.Sprintf("Updating from %s...", base)
+	m.setLoadingScreen(fmt.Sprintf("Updating from %s...", base))
+
+	// Clear cache so status pane refreshes
+	m.deleteDetailsCache(wt.Path)
+
+	env := m.buildCommandEnv(wt.Branch, wt.Path)
+	mergeMethod := m.syncMergeMethod()
+	return func() tea.Msg {
+		output, err := m.state.services.worktree.UpdateFromBaseLocal(m.ctx, wt, base, mergeMethod, env)
+		return syncResultMsg{
+			path:   wt.Path,
+			stage:  syncStageUpdateFromBase,
+			output: output,
+			err:    err,
+		}
+	}
+}
+
+// updateFromBase updates the branch from its PR base branch with gh.
 func (m *Model) updateFromBase(wt *models.WorktreeInfo) tea.Cmd {
 	m.loading = true
 	m.loadingOperation = "sync"
@@ -158,11 +229,7 @@ func (m *Model) updateFromBase(wt *models.WorktreeInfo) tea.Cmd {
 
 	// Use gh pr update-branch with --rebase if merge_method is rebase
 	args := []string{"gh", "pr", "update-branch"}
-	mergeMethod := strings.TrimSpace(m.config.MergeMethod)
-	if mergeMethod == "" {
-		mergeMethod = mergeMethodRebase
-	}
-	if mergeMethod == mergeMethodRebase {
+	if m.syncMergeMethod() == mergeMethodRebase {
 		args = append(args, "--rebase")
 	}
 
@@ -305,6 +372,7 @@ func (m *Model) runSync(wt *models.WorktreeInfo, pullArgs, pushArgs []string) te
 		pullText := strings.TrimSpace(string(pullOutput))
 		if pullErr != nil {
 			return syncResultMsg{
+				path:   wt.Path,
 				stage:  "pull",
 				output: pullText,
 				err:    pullErr,
@@ -336,11 +404,7 @@ func (m *Model) runSync(wt *models.WorktreeInfo, pullArgs, pushArgs []string) te
 
 // syncPullArgs adds merge method flags to pull arguments.
 func (m *Model) syncPullArgs(pullArgs []string) []string {
-	mergeMethod := strings.TrimSpace(m.config.MergeMethod)
-	if mergeMethod == "" {
-		mergeMethod = mergeMethodRebase
-	}
-	if mergeMethod == mergeMethodRebase {
+	if m.syncMergeMethod() == mergeMethodRebase {
 		return append(pullArgs, pullRebaseFlag)
 	}
 	return pullArgs
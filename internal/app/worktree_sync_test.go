@@ -34,7 +34,7 @@ func TestPushToUpstreamRunsGitPush(t *testing.T) {
 	var gotName string
 	var gotArgs []string
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		gotName = name
@@ -103,7 +103,7 @@ func TestPushToUpstreamPromptsForUpstream(t *testing.T) {
 	var gotName string
 	var gotArgs []string
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		gotName = name
@@ -259,7 +259,7 @@ func TestSyncWithUpstreamRunsPullThenPush(t *testing.T) {
 	}
 	var calls []call
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		calls = append(calls, call{name: name, args: append([]string{}, args...)})
@@ -337,7 +337,7 @@ func TestSyncWithUpstreamPromptsForUpstream(t *testing.T) {
 	}
 	var calls []call
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		calls = append(calls, call{name: name, args: append([]string{}, args...)})
@@ -463,7 +463,7 @@ func TestSyncWithUpstreamUsesRebasePull(t *testing.T) {
 	}
 	var calls []call
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		calls = append(calls, call{name: name, args: append([]string{}, args...)})
@@ -520,7 +520,7 @@ func TestSyncWithNoPRDoesNormalSync(t *testing.T) {
 	}
 	var calls []call
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		calls = append(calls, call{name: name, args: append([]string{}, args...)})
@@ -534,7 +534,7 @@ func TestSyncWithNoPRDoesNormalSync(t *testing.T) {
 
 	_ = cmd()
 
-	// Should do normal sync without checking if behind
+	// Not behind main: normal sync
 	if len(calls) != 2 {
 		t.Fatalf("expected 2 commands (pull+push), got %d", len(calls))
 	}
@@ -571,7 +571,7 @@ func TestSyncPREmptyBaseBranchDoesNormalSync(t *testing.T) {
 	}
 	var calls []call
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		calls = append(calls, call{name: name, args: append([]string{}, args...)})
@@ -615,7 +615,7 @@ func TestUpdateFromBaseWithRebaseFlag(t *testing.T) {
 	var gotName string
 	var gotArgs []string
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		gotName = name
@@ -668,7 +668,7 @@ func TestUpdateFromBaseWithoutRebaseFlag(t *testing.T) {
 	var gotName string
 	var gotArgs []string
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		gotName = name
@@ -687,7 +687,7 @@ func TestUpdateFromBaseWithoutRebaseFlag(t *testing.T) {
 	}
 }
 
-func TestShowSyncChoiceCreatesConfirmScreen(t *testing.T) {
+func TestShowSyncChoiceListsOptions(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
 	}
@@ -707,30 +707,34 @@ func TestShowSyncChoiceCreatesConfirmScreen(t *testing.T) {
 		},
 	}
 
-	cmd := m.showSyncChoice(wt)
-	if cmd != nil {
-		t.Fatal("expected showSyncChoice to return nil (no immediate command)")
-	}
+	m.showSyncChoice(wt, "main")
 
 	if !m.state.ui.screenManager.IsActive() {
 		t.Fatal("expected screen manager to be active")
 	}
-	if m.state.ui.screenManager.Type() != appscreen.TypeConfirm {
-		t.Fatalf("expected confirm screen, got %v", m.state.ui.screenManager.Type())
-	}
-	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
 	if !ok {
-		t.Fatal("expected confirm screen in screen manager")
+		t.Fatalf("expected list selection screen, got %v", m.state.ui.screenManager.Type())
 	}
-	if confirmScreen.OnConfirm == nil {
-		t.Fatal("expected OnConfirm to be set")
+	ids := make([]string, 0, len(listScreen.Items))
+	for _, item := range listScreen.Items {
+		ids = append(ids, item.ID)
 	}
-	if confirmScreen.OnCancel == nil {
-		t.Fatal("expected OnCancel to be set")
+	if strings.Join(ids, ",") != "gh,local,pull" {
+		t.Fatalf("expected gh, local and pull options, got %v", ids)
+	}
+
+	// Without a PR there is no gh option
+	m.state.ui.screenManager.Pop()
+	wt.PR = nil
+	m.showSyncChoice(wt, "main")
+	listScreen = m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if len(listScreen.Items) != 2 || listScreen.Items[0].ID != syncChoiceLocal {
+		t.Fatalf("expected local and pull options only, got %+v", listScreen.Items)
 	}
 }
 
-func TestConfirmYesCallsUpdateFromBase(t *testing.T) {
+func TestSyncChoiceGitHubCallsUpdateFromBase(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
 		MergeMethod: mergeMethodRebase,
@@ -751,13 +755,13 @@ func TestConfirmYesCallsUpdateFromBase(t *testing.T) {
 		},
 	}
 
-	// Set up confirm screen
-	_ = m.showSyncChoice(wt)
+	// Set up sync choice screen
+	_ = m.showSyncChoice(wt, "main")
 
 	var gotName string
 	var gotArgs []string
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		gotName = name
@@ -765,11 +769,11 @@ func TestConfirmYesCallsUpdateFromBase(t *testing.T) {
 		return exec.Command("printf", "")
 	}
 
-	// Simulate user pressing YES (y key)
-	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	// Simulate user picking the gh option
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
 	if cmd == nil {
-		t.Fatal("expected command to be returned from confirmAction")
+		t.Fatal("expected command to be returned from the gh option")
 	}
 	_ = cmd()
 
@@ -780,12 +784,9 @@ func TestConfirmYesCallsUpdateFromBase(t *testing.T) {
 	if len(gotArgs) < 3 || gotArgs[0] != "pr" || gotArgs[1] != "update-branch" || gotArgs[2] != "--rebase" {
 		t.Fatalf("expected gh pr update-branch --rebase, got %v", gotArgs)
 	}
-
-	// Screen manager should have popped the confirm screen after the callback
-	// (In practice, the callback would be invoked by the Update handler which pops the screen)
 }
 
-func TestConfirmNoDoesNormalSync(t *testing.T) {
+func TestSyncChoicePullDoesNormalSync(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
 	}
@@ -807,8 +808,8 @@ func TestConfirmNoDoesNormalSync(t *testing.T) {
 		},
 	}
 
-	// Set up confirm screen
-	_ = m.showSyncChoice(wt)
+	// Set up sync choice screen
+	_ = m.showSyncChoice(wt, "main")
 
 	type call struct {
 		name string
@@ -816,19 +817,21 @@ func TestConfirmNoDoesNormalSync(t *testing.T) {
 	}
 	var calls []call
 	m.commandRunner = func(_ context.Context, name string, args ...string) *exec.Cmd {
-		if name == "git" && len(args) > 0 && args[0] == "worktree" {
+		if isSyncProbe(name, args) {
 			return exec.Command("printf", "")
 		}
 		calls = append(calls, call{name: name, args: append([]string{}, args...)})
 		return exec.Command("printf", "")
 	}
 
-	// Simulate user pressing NO (n key)
-	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	// Simulate user picking the pull + push option
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
 	_ = newModel.(*Model)
 
 	if cmd == nil {
-		t.Fatal("expected command to be returned from confirmCancel")
+		t.Fatal("expected command to be returned from the pull option")
 	}
 	_ = cmd()
 
@@ -843,3 +846,17 @@ func TestConfirmNoDoesNormalSync(t *testing.T) {
 		t.Fatalf("expected git push, got %v %v", calls[1].name, calls[1].args)
 	}
 }
+
+// isSyncProbe reports whether a command only inspects the repository (the
+// worktree list, or the main branch and merge base checked before offering to
+// update from base) rather than synchronising it.
+func isSyncProbe(name string, args []string) bool {
+	if name != "git" || len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "worktree", "symbolic-ref", "merge-base":
+		return true
+	}
+	return false
+}
@@ -10,60 +10,6 @@ import (
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
-func TestParseMarkdownTaskLine(t *testing.T) {
-	tests := []struct {
-		name     string
-		line     string
-		wantOK   bool
-		wantDone bool
-		wantText string
-	}{
-		{name: "unchecked", line: "- [ ] Write docs", wantOK: true, wantDone: false, wantText: "Write docs"},
-		{name: "checked upper", line: "* [X] Ship it", wantOK: true, wantDone: true, wantText: "Ship it"},
-		{name: "checked lower", line: "+ [x] Merge PR", wantOK: true, wantDone: true, wantText: "Merge PR"},
-		{name: "not task", line: "- TODO: plain text tag", wantOK: false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			done, text, ok := parseMarkdownTaskLine(tt.line)
-			if ok != tt.wantOK {
-				t.Fatalf("ok=%v want=%v", ok, tt.wantOK)
-			}
-			if !ok {
-				return
-			}
-			if done != tt.wantDone {
-				t.Fatalf("checked=%v want=%v", done, tt.wantDone)
-			}
-			if text != tt.wantText {
-				t.Fatalf("text=%q want=%q", text, tt.wantText)
-			}
-		})
-	}
-}
-
-func TestToggleMarkdownTaskLinePreservesFormatting(t *testing.T) {
-	note := "## Notes\n  - [ ]   Keep spacing exactly\n- [x] done"
-	updated, ok := toggleMarkdownTaskLine(note, 1)
-	if !ok {
-		t.Fatal("expected toggle to succeed")
-	}
-	lines := strings.Split(updated, "\n")
-	if len(lines) != 3 {
-		t.Fatalf("unexpected line count: %d", len(lines))
-	}
-	if lines[0] != "## Notes" {
-		t.Fatalf("expected heading unchanged, got %q", lines[0])
-	}
-	if lines[1] != "  - [x]   Keep spacing exactly" {
-		t.Fatalf("expected only checkbox marker to flip, got %q", lines[1])
-	}
-	if lines[2] != "- [x] done" {
-		t.Fatalf("expected unrelated line unchanged, got %q", lines[2])
-	}
-}
-
 func TestShowTaskboardNoTasksShowsTaskboard(t *testing.T) {
 	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
 	m := NewModel(cfg, "")
@@ -115,153 +61,6 @@ func TestShowTaskboardAndToggleTask(t *testing.T) {
 	}
 }
 
-func TestParseTodoKeywordLine(t *testing.T) {
-	tests := []struct {
-		name     string
-		line     string
-		wantOK   bool
-		wantDone bool
-		wantText string
-	}{
-		{name: "todo with colon", line: "TODO: Review the PR", wantOK: true, wantDone: false, wantText: "Review the PR"},
-		{name: "todo without colon", line: "TODO fix the parser", wantOK: true, wantDone: false, wantText: "fix the parser"},
-		{name: "done with colon", line: "DONE: Set up CI", wantOK: true, wantDone: true, wantText: "Set up CI"},
-		{name: "done without colon", line: "DONE shipped it", wantOK: true, wantDone: true, wantText: "shipped it"},
-		{name: "leading whitespace", line: "  TODO: indented task", wantOK: true, wantDone: false, wantText: "indented task"},
-		{name: "bare todo", line: "TODO", wantOK: true, wantDone: false, wantText: "(untitled task)"},
-		{name: "bare done", line: "DONE", wantOK: true, wantDone: true, wantText: "(untitled task)"},
-		{name: "bare todo colon", line: "TODO:", wantOK: true, wantDone: false, wantText: "(untitled task)"},
-		{name: "lowercase not matched", line: "todo: lowercase", wantOK: false},
-		{name: "mid-line not matched", line: "some TODO: text", wantOK: false},
-		{name: "checkbox not matched", line: "- [ ] task", wantOK: false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			done, text, ok := parseTodoKeywordLine(tt.line)
-			if ok != tt.wantOK {
-				t.Fatalf("ok=%v want=%v", ok, tt.wantOK)
-			}
-			if !ok {
-				return
-			}
-			if done != tt.wantDone {
-				t.Fatalf("checked=%v want=%v", done, tt.wantDone)
-			}
-			if text != tt.wantText {
-				t.Fatalf("text=%q want=%q", text, tt.wantText)
-			}
-		})
-	}
-}
-
-func TestToggleTodoKeywordLine(t *testing.T) {
-	tests := []struct {
-		name     string
-		note     string
-		line     int
-		wantLine string
-		wantOK   bool
-	}{
-		{
-			name:     "todo to done",
-			note:     "TODO: Review PR",
-			line:     0,
-			wantLine: "DONE: Review PR",
-			wantOK:   true,
-		},
-		{
-			name:     "done to todo",
-			note:     "DONE: Review PR",
-			line:     0,
-			wantLine: "TODO: Review PR",
-			wantOK:   true,
-		},
-		{
-			name:     "preserves indentation",
-			note:     "  TODO: indented",
-			line:     0,
-			wantLine: "  DONE: indented",
-			wantOK:   true,
-		},
-		{
-			name:     "preserves no colon",
-			note:     "TODO fix it",
-			line:     0,
-			wantLine: "DONE fix it",
-			wantOK:   true,
-		},
-		{
-			name:     "preserves surrounding lines",
-			note:     "# Heading\nTODO: task\n- [ ] checkbox",
-			line:     1,
-			wantLine: "DONE: task",
-			wantOK:   true,
-		},
-		{
-			name:   "out of range",
-			note:   "TODO: task",
-			line:   5,
-			wantOK: false,
-		},
-		{
-			name:   "non-keyword line",
-			note:   "plain text",
-			line:   0,
-			wantOK: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, ok := toggleTodoKeywordLine(tt.note, tt.line)
-			if ok != tt.wantOK {
-				t.Fatalf("ok=%v want=%v", ok, tt.wantOK)
-			}
-			if !ok {
-				return
-			}
-			lines := strings.Split(result, "\n")
-			if lines[tt.line] != tt.wantLine {
-				t.Fatalf("got %q want %q", lines[tt.line], tt.wantLine)
-			}
-		})
-	}
-}
-
-func TestExtractTaskRefsMixedItems(t *testing.T) {
-	note := "TODO: Review the PR\n- [ ] Write tests\nDONE: Set up CI\n- [x] Merge PR\nTODO fix the parser"
-	refs := extractTaskRefs("/tmp/wt", note)
-	if len(refs) != 5 {
-		t.Fatalf("expected 5 refs, got %d", len(refs))
-	}
-
-	// Verify order matches line order
-	expected := []struct {
-		text      string
-		checked   bool
-		isKeyword bool
-	}{
-		{"Review the PR", false, true},
-		{"Write tests", false, false},
-		{"Set up CI", true, true},
-		{"Merge PR", true, false},
-		{"fix the parser", false, true},
-	}
-
-	for i, exp := range expected {
-		if refs[i].Text != exp.text {
-			t.Fatalf("ref[%d] text=%q want=%q", i, refs[i].Text, exp.text)
-		}
-		if refs[i].Checked != exp.checked {
-			t.Fatalf("ref[%d] checked=%v want=%v", i, refs[i].Checked, exp.checked)
-		}
-		if refs[i].IsKeyword != exp.isKeyword {
-			t.Fatalf("ref[%d] isKeyword=%v want=%v", i, refs[i].IsKeyword, exp.isKeyword)
-		}
-	}
-}
-
 func TestShowTaskboardWithTodoLines(t *testing.T) {
 	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
 	m := NewModel(cfg, "")
@@ -33,9 +33,24 @@ func Run(args []string) int {
 		Commands: []*cli.Command{
 			createCommand(),
 			renameCommand(),
+			moveCommand(),
 			deleteCommand(),
+			lockCommand(),
+			unlockCommand(),
 			listCommand(),
 			execCommand(),
+			statusCommand(),
+			pruneCommand(),
+			repairCommand(),
+			absorbCommand(),
+			syncCommand(),
+			noteCommand(),
+			tasksCommand(),
+			stashCommand(),
+			doctorCommand(),
+			configCommand(),
+			trustCommand(),
+			shellInitCommand(),
 		},
 
 		Action: func(ctx context.Context, cmd *cli.Command) error {
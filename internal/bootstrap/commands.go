@@ -24,7 +24,7 @@ import (
 )
 
 type (
-	createFromBranchFuncType       func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, branchName, worktreeName string, withChange, silent bool) (string, error)
+	createFromBranchFuncType       func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, branchName, worktreeName, sparseProfile string, withChange, silent bool) (string, error)
 	createFromPRFuncType           func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, prNumber int, noWorkspace, silent bool) (string, error)
 	createFromIssueFuncType        func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, issueNumber int, baseBranch string, noWorkspace, silent bool) (string, error)
 	renameWorktreeFuncType         func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, worktreePath, newName string, silent bool) error
@@ -36,8 +36,8 @@ type (
 var (
 	loadCLIConfigFunc                             = loadCLIConfig
 	newCLIGitServiceFunc                          = newCLIGitService
-	createFromBranchFunc createFromBranchFuncType = func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, branchName, worktreeName string, withChange, silent bool) (string, error) {
-		return cli.CreateFromBranch(ctx, gitSvc, cfg, branchName, worktreeName, withChange, silent)
+	createFromBranchFunc createFromBranchFuncType = func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, branchName, worktreeName, sparseProfile string, withChange, silent bool) (string, error) {
+		return cli.CreateFromBranch(ctx, gitSvc, cfg, branchName, worktreeName, sparseProfile, withChange, silent)
 	}
 	createFromPRFunc createFromPRFuncType = func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, prNumber int, noWorkspace, silent bool) (string, error) {
 		return cli.CreateFromPR(ctx, gitSvc, cfg, prNumber, noWorkspace, silent)
@@ -149,7 +149,15 @@ func completionWorktreeBasenames(ctx context.Context, cmd *appiCli.Command) []st
 		return listSubcommandWorktreeNamesFunc(ctx, cmd)
 	}
 
-	if (cmd.Name != "delete" && cmd.Name != "rename") || cmd.NArg() != 0 {
+	switch {
+	case cmd.Name == "delete", cmd.Name == "rename", cmd.Name == "move", cmd.Name == "absorb", cmd.Name == "sync", cmd.Name == "lock", cmd.Name == "unlock":
+	case isNestedSubcommandOf(cmd, "note") && cmd.Name != "list":
+	case isNestedSubcommandOf(cmd, "tasks") && (cmd.Name == "list" || cmd.Name == "add"):
+	case isNestedSubcommandOf(cmd, "stash") && cmd.Name == "move":
+	default:
+		return nil
+	}
+	if cmd.NArg() != 0 {
 		return nil
 	}
 
@@ -174,7 +182,7 @@ func listSubcommandWorktreeNames(ctx context.Context, cmd *appiCli.Command) []st
 		return nil
 	}
 
-	return uniqueSortedWorktreeBasenames(worktrees)
+	return uniqueSortedWorktreeNames(worktrees, cli.LoadWorktreeLayout(ctx, gitSvc, cfg))
 }
 
 func loadCompletionConfig(cmd *appiCli.Command) (*config.AppConfig, error) {
@@ -196,7 +204,10 @@ func loadCompletionConfig(cmd *appiCli.Command) (*config.AppConfig, error) {
 	return cfg, nil
 }
 
-func uniqueSortedWorktreeBasenames(worktrees []*models.WorktreeInfo) []string {
+// uniqueSortedWorktreeNames returns the names of the linked worktrees, as
+// encoded in their path by layout, or their basename when the path does not
+// follow it.
+func uniqueSortedWorktreeNames(worktrees []*models.WorktreeInfo, layout services.WorktreeLayout) []string {
 	seen := make(map[string]struct{}, len(worktrees))
 	names := make([]string, 0, len(worktrees))
 
@@ -205,7 +216,10 @@ func uniqueSortedWorktreeBasenames(worktrees []*models.WorktreeInfo) []string {
 			continue
 		}
 
-		name := filepath.Base(strings.TrimSpace(wt.Path))
+		name, ok := layout.Name(strings.TrimSpace(wt.Path))
+		if !ok {
+			name = filepath.Base(strings.TrimSpace(wt.Path))
+		}
 		if name == "" || name == "." || name == string(filepath.Separator) {
 			continue
 		}
@@ -302,6 +316,10 @@ func createCommand() *appiCli.Command {
 				Name:  "with-change",
 				Usage: "Carry over uncommitted changes to the new worktree",
 			},
+			&appiCli.StringFlag{
+				Name:  "sparse",
+				Usage: "Check out only the directories of this sparse-checkout profile (from sparse_profiles)",
+			},
 			&appiCli.BoolFlag{
 				Name:    "no-workspace",
 				Aliases: []string{"N"},
@@ -346,6 +364,10 @@ func deleteCommand() *appiCli.Command {
 				Name:  "no-branch",
 				Usage: "Skip branch deletion",
 			},
+			&appiCli.BoolFlag{
+				Name:  "force",
+				Usage: "Delete the worktree even when it is locked",
+			},
 			&appiCli.BoolFlag{
 				Name:  "silent",
 				Usage: "Suppress progress messages",
@@ -408,6 +430,7 @@ func validateCreateFlags(ctx context.Context, cmd *appiCli.Command) error {
 	generate := cmd.Bool("generate")
 	withChange := cmd.Bool("with-change")
 	noWorkspace := cmd.Bool("no-workspace")
+	sparse := cmd.String("sparse")
 
 	if err := validateMutualExclusivity(map[string]bool{
 		"--from-pr":                fromPR > 0,
@@ -432,6 +455,7 @@ func validateCreateFlags(ctx context.Context, cmd *appiCli.Command) error {
 		{"positional name argument", hasName, "--from-issue", fromIssue > 0},
 		{"positional name argument", hasName, "--from-issue-interactive", fromIssueInteractive},
 		{"positional name argument", hasName, "--from-pr-interactive", fromPRInteractive},
+		{"--sparse", sparse != "", "--with-change", withChange},
 	}
 	for _, pair := range incompatible {
 		if err := validateIncompatibility(pair.name1, pair.set1, pair.name2, pair.set2); err != nil {
@@ -445,6 +469,12 @@ func validateCreateFlags(ctx context.Context, cmd *appiCli.Command) error {
 		}
 	}
 
+	if sparse != "" {
+		if fromPR > 0 || fromIssue > 0 || fromIssueInteractive || fromPRInteractive {
+			return fmt.Errorf("--sparse cannot be used with --from-pr, --from-issue, --from-issue-interactive, or --from-pr-interactive")
+		}
+	}
+
 	query := cmd.String("query")
 	if query != "" && !fromPRInteractive && !fromIssueInteractive {
 		return fmt.Errorf("--query requires --from-pr-interactive or --from-issue-interactive")
@@ -570,7 +600,7 @@ func handleCreateAction(ctx context.Context, cmd *appiCli.Command) error {
 			}
 		}
 
-		outputPath, opErr = createFromBranchFunc(ctx, gitSvc, cfg, sourceBranch, name, withChange, silent)
+		outputPath, opErr = createFromBranchFunc(ctx, gitSvc, cfg, sourceBranch, name, cmd.String("sparse"), withChange, silent)
 	}
 
 	if opErr != nil {
@@ -734,6 +764,11 @@ type worktreeJSON struct {
 	Behind     int    `json:"behind"`
 	Unpushed   int    `json:"unpushed,omitempty"`
 	LastActive string `json:"last_active"`
+	Head       string `json:"head,omitempty"`
+	Detached   bool   `json:"detached,omitempty"`
+	Locked     bool   `json:"locked,omitempty"`
+	LockReason string `json:"lock_reason,omitempty"`
+	Prunable   bool   `json:"prunable,omitempty"`
 }
 
 // handleListAction handles the list subcommand action.
@@ -796,6 +831,11 @@ func outputListJSON(worktrees []*models.WorktreeInfo) error {
 			Behind:     wt.Behind,
 			Unpushed:   wt.Unpushed,
 			LastActive: wt.LastActive,
+			Head:       wt.Head,
+			Detached:   wt.Detached,
+			Locked:     wt.Locked,
+			LockReason: wt.LockReason,
+			Prunable:   wt.Prunable,
 		})
 	}
 
@@ -845,8 +885,18 @@ func buildStatusString(wt *models.WorktreeInfo) string {
 	if !wt.HasUpstream && wt.Unpushed > 0 {
 		parts = append(parts, fmt.Sprintf("?%d", wt.Unpushed))
 	}
+	status := strings.Join(parts, "")
 
-	return strings.Join(parts, "")
+	if wt.Detached {
+		status += " detached"
+	}
+	if wt.Locked {
+		status += " locked"
+	}
+	if wt.Prunable {
+		status += " prunable"
+	}
+	return status
 }
 
 // handleDeleteAction handles the delete subcommand action.
@@ -872,11 +922,12 @@ func handleDeleteAction(ctx context.Context, cmd *appiCli.Command) error {
 
 	// Extract command-specific flags
 	noBranch := cmd.Bool("no-branch")
+	force := cmd.Bool("force")
 	silent := cmd.Bool("silent")
 
 	// Execute delete operation
 	deleteBranch := !noBranch
-	if err := cli.DeleteWorktree(ctx, gitSvc, cfg, worktreePath, deleteBranch, silent); err != nil {
+	if err := cli.DeleteWorktree(ctx, gitSvc, cfg, worktreePath, deleteBranch, force, silent); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		_ = log.Close()
 		return err
@@ -968,6 +1019,29 @@ func execCommand() *appiCli.Command {
 				Aliases: []string{"k"},
 				Usage:   "Custom command key to trigger (e.g. 't' for tmux)",
 			},
+			&appiCli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "Run the command in every worktree",
+			},
+			&appiCli.StringFlag{
+				Name:  "filter",
+				Usage: "Run in worktrees whose name or branch matches a glob or substring (implies --all)",
+			},
+			&appiCli.BoolFlag{
+				Name:  "dirty-only",
+				Usage: "Run only in worktrees with uncommitted changes (implies --all)",
+			},
+			&appiCli.IntFlag{
+				Name:    "jobs",
+				Aliases: []string{"j"},
+				Usage:   "Maximum number of worktrees to run in parallel with --all",
+				Value:   1,
+			},
+			&appiCli.BoolFlag{
+				Name:  "group",
+				Usage: "Capture output and print it per worktree instead of prefixing each line",
+			},
 		},
 	}
 }
@@ -980,6 +1054,14 @@ func handleExecAction(ctx context.Context, cmd *appiCli.Command) error {
 		command = cmd.Args().Get(0)
 	}
 
+	if isExecFanOut(cmd) {
+		return handleExecAllAction(ctx, cmd, command)
+	}
+	if cmd.IsSet("jobs") || cmd.Bool("group") {
+		fmt.Fprintf(os.Stderr, "Error: --jobs and --group require --all, --filter or --dirty-only\n")
+		return fmt.Errorf("--jobs and --group require --all, --filter or --dirty-only")
+	}
+
 	// Validate: key and command are mutually exclusive
 	if key != "" && command != "" {
 		fmt.Fprintf(os.Stderr, "Error: --key and command argument are mutually exclusive\n")
@@ -1015,7 +1097,7 @@ func handleExecAction(ctx context.Context, cmd *appiCli.Command) error {
 	var targetWorktree *models.WorktreeInfo
 	if workspace != "" {
 		// User provided workspace flag
-		targetWorktree, err = cli.FindWorktreeByPathOrName(workspace, worktrees, cfg.WorktreeDir, gitSvc.ResolveRepoName(ctx))
+		targetWorktree, err = cli.FindWorktreeByPathOrName(workspace, worktrees, cli.LoadWorktreeLayout(ctx, gitSvc, cfg))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return err
@@ -0,0 +1,257 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/utils"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// Sources of effective values set on the command line.
+const (
+	configSourceCLIOverride = "--config"
+	configSourceWorktreeDir = "--worktree-dir"
+)
+
+// configEntry is one effective configuration value and where it came from.
+type configEntry struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+func configCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "config",
+		Usage: "Show, change and validate configuration",
+		Commands: []*appiCli.Command{
+			nestedSubcommand("show", "Print every effective setting and where it came from", "", handleConfigShowAction, jsonFlag()),
+			nestedSubcommand("get", "Print the effective value of a setting", "<key>", handleConfigGetAction),
+			nestedSubcommand("set", "Write a setting to the config file or git config", "<key> <value>", handleConfigSetAction,
+				&appiCli.BoolFlag{
+					Name:  "git",
+					Usage: "Write to the repository git config (lw.<key>) instead of the config file",
+				},
+				&appiCli.BoolFlag{
+					Name:  "global",
+					Usage: "With --git, write to the global git config",
+				},
+			),
+			nestedSubcommand("validate", "Report unknown keys and invalid values", "", handleConfigValidateAction),
+		},
+	}
+}
+
+func handleConfigShowAction(ctx context.Context, cmd *appiCli.Command) error {
+	entries, err := effectiveConfigEntries(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	return outputConfigEntries(os.Stdout, entries)
+}
+
+func handleConfigGetAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() != 1 {
+		return fmt.Errorf("expected exactly one key")
+	}
+	key, ok := config.LookupConfigKey(cmd.Args().Get(0))
+	if !ok {
+		return fmt.Errorf("unknown config key %q", cmd.Args().Get(0))
+	}
+	cfg, err := loadCLIConfigFunc(cmd.String("config-file"), cmd.String("worktree-dir"), cmd.StringSlice("config"))
+	if err != nil {
+		return err
+	}
+
+	if values, ok := key.Value(cfg).([]string); ok {
+		for _, v := range values {
+			fmt.Fprintln(os.Stdout, v)
+		}
+		return nil
+	}
+	fmt.Fprintln(os.Stdout, config.FormatConfigValue(key.Value(cfg)))
+	return nil
+}
+
+func handleConfigSetAction(_ context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() != 2 {
+		return fmt.Errorf("expected <key> <value>")
+	}
+	if err := validateIncompatibility("--global", cmd.Bool("global"), "a config file target (use --git)", !cmd.Bool("git")); err != nil {
+		return err
+	}
+	key, ok := config.LookupConfigKey(cmd.Args().Get(0))
+	if !ok {
+		return fmt.Errorf("unknown config key %q", cmd.Args().Get(0))
+	}
+	value := cmd.Args().Get(1)
+	parsed, err := config.ParseConfigValue(key, value)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("git") {
+		return config.SaveGitConfigValue(cmd.Bool("global"), key.Name, fmt.Sprint(parsed))
+	}
+
+	cfg, err := config.LoadConfig(cmd.String("config-file"))
+	if err != nil {
+		return err
+	}
+	if cmd.String("config-file") != "" && cfg.ConfigPath == "" {
+		expanded, err := expandConfigFilePath(cmd.String("config-file"))
+		if err != nil {
+			return err
+		}
+		cfg.ConfigPath = expanded
+	}
+	if err := config.SaveConfigValue(cfg, key.Name, parsed); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Set %s in %s\n", key.Name, cfg.ConfigPath)
+	return nil
+}
+
+func handleConfigValidateAction(_ context.Context, cmd *appiCli.Command) error {
+	configFile := cmd.String("config-file")
+	path, issues, err := config.ValidateConfigFile(configFile)
+	if err != nil {
+		return err
+	}
+	problems := 0
+	for _, issue := range issues {
+		fmt.Fprintf(os.Stdout, "%s:%s\n", path, formatConfigIssue(issue))
+		problems++
+	}
+	for _, problem := range config.ValidateGitConfig(configFile) {
+		fmt.Fprintln(os.Stdout, problem)
+		problems++
+	}
+	if _, err := config.CLIOverrideKeys(cmd.StringSlice("config")); err != nil {
+		fmt.Fprintf(os.Stdout, "%s: %v\n", configSourceCLIOverride, err)
+		problems++
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found %d problem(s)", problems)
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "No config file found; git config keys are valid.")
+	} else {
+		fmt.Fprintf(os.Stderr, "%s is valid.\n", path)
+	}
+	return nil
+}
+
+// formatConfigIssue renders an issue so that it follows "path:".
+func formatConfigIssue(issue config.ConfigIssue) string {
+	if issue.Line == 0 {
+		return " " + issue.Message
+	}
+	return issue.String()
+}
+
+// effectiveConfigEntries returns every configuration key with its effective
+// value and source, followed by the commands of the repository .wt file.
+func effectiveConfigEntries(ctx context.Context, cmd *appiCli.Command) ([]configEntry, error) {
+	configFile := cmd.String("config-file")
+	cfg, err := loadCLIConfigFunc(configFile, cmd.String("worktree-dir"), cmd.StringSlice("config"))
+	if err != nil {
+		return nil, err
+	}
+	overrideKeys, err := config.CLIOverrideKeys(cmd.StringSlice("config"))
+	if err != nil {
+		return nil, err
+	}
+
+	sources := config.ConfigSources(configFile)
+	for _, key := range overrideKeys {
+		sources[key] = configSourceCLIOverride
+	}
+	if cmd.String("worktree-dir") != "" {
+		sources["worktree_dir"] = configSourceWorktreeDir
+	}
+	if _, ok := sources["theme"]; !ok {
+		sources["theme"] = config.SourceDetected
+	}
+
+	entries := buildConfigEntries(cfg, sources)
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	if gitSvc.RunGit(ctx, []string{"git", "rev-parse", "--is-inside-work-tree"}, "", []int{0}, true, true) == "true" {
+		mainPath := gitSvc.GetMainWorktreePath(ctx)
+		if repoCfg, path, err := config.LoadRepoConfig(mainPath); err == nil && repoCfg != nil {
+			entries = append(entries, repoConfigEntries(repoCfg, path)...)
+		}
+	}
+	return entries, nil
+}
+
+// buildConfigEntries pairs every key with its effective value in cfg and its
+// source, defaulting to config.SourceDefault.
+func buildConfigEntries(cfg *config.AppConfig, sources map[string]string) []configEntry {
+	keys := config.ConfigKeys()
+	entries := make([]configEntry, 0, len(keys))
+	for _, key := range keys {
+		source, ok := sources[key.Name]
+		if !ok {
+			source = config.SourceDefault
+		}
+		entries = append(entries, configEntry{Key: key.Name, Value: key.Value(cfg), Source: source})
+	}
+	return entries
+}
+
+// repoConfigEntries describes the .wt commands that run in addition to the
+// global init and terminate commands, and the sparse-checkout profiles and
+// submodule settings that override the global ones.
+func repoConfigEntries(repoCfg *config.RepoConfig, path string) []configEntry {
+	var entries []configEntry
+	if len(repoCfg.InitCommands) > 0 {
+		entries = append(entries, configEntry{Key: "init_commands", Value: repoCfg.InitCommands, Source: path})
+	}
+	if len(repoCfg.TerminateCommands) > 0 {
+		entries = append(entries, configEntry{Key: "terminate_commands", Value: repoCfg.TerminateCommands, Source: path})
+	}
+	if len(repoCfg.SparseProfiles) > 0 {
+		entries = append(entries, configEntry{Key: "sparse_profiles", Value: repoCfg.SparseProfiles, Source: path})
+	}
+	if repoCfg.SubmoduleUpdate != nil {
+		entries = append(entries, configEntry{Key: "submodule_update", Value: *repoCfg.SubmoduleUpdate, Source: path})
+	}
+	if repoCfg.SubmoduleReference != nil {
+		entries = append(entries, configEntry{Key: "submodule_reference", Value: *repoCfg.SubmoduleReference, Source: path})
+	}
+	return entries
+}
+
+func outputConfigEntries(out io.Writer, entries []configEntry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Key, config.FormatConfigValue(entry.Value), entry.Source)
+	}
+	return w.Flush()
+}
+
+// expandConfigFilePath resolves the --config-file path for a file that does
+// not exist yet.
+func expandConfigFilePath(path string) (string, error) {
+	expanded, err := utils.ExpandPath(path)
+	if err != nil {
+		return "", fmt.Errorf("error expanding config-file: %w", err)
+	}
+	return filepath.Abs(expanded)
+}
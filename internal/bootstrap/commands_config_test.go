@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConfigEntries(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Theme = "nord"
+	cfg.MaxDiffChars = 42
+
+	entries := buildConfigEntries(cfg, map[string]string{
+		"theme":          config.SourceGitGlobal,
+		"max_diff_chars": configSourceCLIOverride,
+	})
+	require.Len(t, entries, len(config.ConfigKeys()))
+
+	byKey := make(map[string]configEntry, len(entries))
+	for _, entry := range entries {
+		byKey[entry.Key] = entry
+	}
+	assert.Equal(t, configEntry{Key: "theme", Value: "nord", Source: config.SourceGitGlobal}, byKey["theme"])
+	assert.Equal(t, configEntry{Key: "max_diff_chars", Value: 42, Source: configSourceCLIOverride}, byKey["max_diff_chars"])
+	assert.Equal(t, config.SourceDefault, byKey["sort_mode"].Source)
+}
+
+func TestRepoConfigEntries(t *testing.T) {
+	assert.Empty(t, repoConfigEntries(&config.RepoConfig{}, "/repo/.wt"))
+
+	entries := repoConfigEntries(&config.RepoConfig{InitCommands: []string{"make"}}, "/repo/.wt")
+	assert.Equal(t, []configEntry{{Key: "init_commands", Value: []string{"make"}, Source: "/repo/.wt"}}, entries)
+
+	update := false
+	entries = repoConfigEntries(&config.RepoConfig{SubmoduleUpdate: &update}, "/repo/.wt")
+	assert.Equal(t, []configEntry{{Key: "submodule_update", Value: false, Source: "/repo/.wt"}}, entries)
+}
+
+func TestOutputConfigEntries(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, outputConfigEntries(&buf, []configEntry{
+		{Key: "theme", Value: "nord", Source: config.SourceGitLocal},
+		{Key: "auto_fetch_prs", Value: true, Source: config.SourceDefault},
+	}))
+	assert.Equal(t, "KEY             VALUE  SOURCE\ntheme           nord   git config --local\nauto_fetch_prs  true   default\n", buf.String())
+}
+
+func TestFormatConfigIssue(t *testing.T) {
+	assert.Equal(t, "3:5: bad", formatConfigIssue(config.ConfigIssue{Line: 3, Column: 5, Message: "bad"}))
+	assert.Equal(t, " bad", formatConfigIssue(config.ConfigIssue{Message: "bad"}))
+}
@@ -0,0 +1,333 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/log"
+	"github.com/chmouel/lazyworktree/internal/security"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+const (
+	doctorPass = "pass"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// doctorCheck is the outcome of one diagnostic.
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// doctorGitService is the subset of git.Service the doctor inspects.
+type doctorGitService interface {
+	RunGit(ctx context.Context, args []string, cwd string, okReturncodes []int, strip, silent bool) string
+	DetectHost(ctx context.Context) string
+	GetAuthenticatedUsername(ctx context.Context) string
+	UseGitPager() bool
+}
+
+// doctorEnv holds the inputs of the diagnostics so tests can replace them.
+type doctorEnv struct {
+	cfg          *config.AppConfig
+	cfgErr       error
+	configFile   string
+	gitSvc       doctorGitService
+	trust        *security.TrustManager
+	lookPath     func(string) (string, error)
+	toolVersion  func(ctx context.Context, name string, args ...string) string
+	statWorktree func(string) (os.FileInfo, error)
+}
+
+var (
+	doctorLookPath    = exec.LookPath
+	doctorToolVersion = func(ctx context.Context, name string, args ...string) string {
+		// #nosec G204 -- fixed tool names and version flags
+		out, err := exec.CommandContext(ctx, name, args...).Output()
+		if err != nil {
+			return ""
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		return line
+	}
+)
+
+func doctorCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "doctor",
+		Usage: "Check the environment and repository for common problems",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleDoctorAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			jsonFlag(),
+		},
+	}
+}
+
+func handleDoctorAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+
+	configFile := cmd.String("config-file")
+	cfg, cfgErr := config.LoadConfig(configFile)
+	if cfgErr != nil {
+		cfg = config.DefaultConfig()
+	}
+	if err := applyWorktreeDirConfig(cfg, cmd.String("worktree-dir")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+	if err := cfg.ApplyCLIOverrides(cmd.StringSlice("config")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+
+	checks := runDoctorChecks(ctx, doctorEnv{
+		cfg:          cfg,
+		cfgErr:       cfgErr,
+		configFile:   configFile,
+		gitSvc:       newCLIGitServiceFunc(cfg),
+		trust:        security.NewTrustManager(),
+		lookPath:     doctorLookPath,
+		toolVersion:  doctorToolVersion,
+		statWorktree: os.Stat,
+	})
+
+	if cmd.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(checks); err != nil {
+			return err
+		}
+	} else {
+		outputDoctorChecks(os.Stdout, checks)
+	}
+	return doctorChecksError(checks)
+}
+
+// runDoctorChecks runs every diagnostic in display order.
+func runDoctorChecks(ctx context.Context, env doctorEnv) []doctorCheck {
+	checks := []doctorCheck{
+		checkDoctorGit(ctx, env),
+		checkDoctorRepository(ctx, env),
+	}
+	checks = append(checks, checkDoctorConfig(env)...)
+	checks = append(checks,
+		checkDoctorWorktreeDir(env),
+		checkDoctorForge(ctx, env),
+		checkDoctorPager(env),
+		checkDoctorMultiplexer(ctx, env, "tmux", "-V"),
+		checkDoctorMultiplexer(ctx, env, "zellij", "--version"),
+		checkDoctorTrust(env),
+	)
+	return checks
+}
+
+func checkDoctorGit(ctx context.Context, env doctorEnv) doctorCheck {
+	if _, err := env.lookPath("git"); err != nil {
+		return doctorCheck{Name: "git", Status: doctorFail, Message: "git not found in PATH", Hint: "install git"}
+	}
+	version := env.toolVersion(ctx, "git", "--version")
+	if version == "" {
+		version = "git found"
+	}
+	return doctorCheck{Name: "git", Status: doctorPass, Message: version}
+}
+
+func checkDoctorRepository(ctx context.Context, env doctorEnv) doctorCheck {
+	top := env.gitSvc.RunGit(ctx, []string{"git", "rev-parse", "--show-toplevel"}, "", []int{0}, true, true)
+	if top == "" {
+		return doctorCheck{
+			Name:    "repository",
+			Status:  doctorWarn,
+			Message: "not inside a git repository",
+			Hint:    "run lazyworktree from a repository or one of its worktrees",
+		}
+	}
+	return doctorCheck{Name: "repository", Status: doctorPass, Message: top}
+}
+
+func checkDoctorConfig(env doctorEnv) []doctorCheck {
+	if env.cfgErr != nil {
+		return []doctorCheck{{
+			Name:    "config",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("failed to load config: %v", env.cfgErr),
+			Hint:    "fix the reported error; defaults are used until then",
+		}}
+	}
+
+	path, warnings := config.ConfigFileWarnings(env.configFile)
+	if path == "" {
+		if env.configFile != "" {
+			return []doctorCheck{{
+				Name:    "config",
+				Status:  doctorWarn,
+				Message: fmt.Sprintf("config file %s not found, using defaults", env.configFile),
+				Hint:    "check the --config-file path",
+			}}
+		}
+		return []doctorCheck{{Name: "config", Status: doctorPass, Message: "no config file, using defaults"}}
+	}
+	if len(warnings) == 0 {
+		return []doctorCheck{{Name: "config", Status: doctorPass, Message: path}}
+	}
+
+	checks := make([]doctorCheck, 0, len(warnings))
+	for _, warning := range warnings {
+		checks = append(checks, doctorCheck{
+			Name:    "config",
+			Status:  doctorWarn,
+			Message: fmt.Sprintf("%s: %s", path, warning),
+			Hint:    "edit the config file; see lazyworktree(1) for valid values",
+		})
+	}
+	return checks
+}
+
+func checkDoctorWorktreeDir(env doctorEnv) doctorCheck {
+	dir := env.cfg.WorktreeDir
+	info, err := env.statWorktree(dir)
+	switch {
+	case os.IsNotExist(err):
+		return doctorCheck{
+			Name:    "worktree_dir",
+			Status:  doctorWarn,
+			Message: fmt.Sprintf("%s does not exist yet", dir),
+			Hint:    "it is created with the first worktree; set worktree_dir to change the location",
+		}
+	case err != nil:
+		return doctorCheck{Name: "worktree_dir", Status: doctorFail, Message: fmt.Sprintf("cannot access %s: %v", dir, err), Hint: "check the permissions of worktree_dir"}
+	case !info.IsDir():
+		return doctorCheck{Name: "worktree_dir", Status: doctorFail, Message: fmt.Sprintf("%s is not a directory", dir), Hint: "set worktree_dir to a directory"}
+	}
+	return doctorCheck{Name: "worktree_dir", Status: doctorPass, Message: dir}
+}
+
+func checkDoctorForge(ctx context.Context, env doctorEnv) doctorCheck {
+	if env.cfg.DisablePR {
+		return doctorCheck{Name: "forge", Status: doctorPass, Message: "PR/MR integration disabled by disable_pr"}
+	}
+
+	var tool string
+	switch host := env.gitSvc.DetectHost(ctx); host {
+	case "github":
+		tool = "gh"
+	case "gitlab":
+		tool = "glab"
+	default:
+		return doctorCheck{
+			Name:    "forge",
+			Status:  doctorWarn,
+			Message: "origin is not a GitHub or GitLab remote; PR/MR and CI information is unavailable",
+			Hint:    "set disable_pr: true to silence this check",
+		}
+	}
+
+	if _, err := env.lookPath(tool); err != nil {
+		return doctorCheck{
+			Name:    "forge",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("%s not found in PATH", tool),
+			Hint:    fmt.Sprintf("install %s to show PRs/MRs and CI status", tool),
+		}
+	}
+	username := env.gitSvc.GetAuthenticatedUsername(ctx)
+	if username == "" {
+		return doctorCheck{
+			Name:    "forge",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("%s is not authenticated", tool),
+			Hint:    fmt.Sprintf("run '%s auth login'", tool),
+		}
+	}
+	return doctorCheck{Name: "forge", Status: doctorPass, Message: fmt.Sprintf("%s authenticated as %s", tool, username)}
+}
+
+func checkDoctorPager(env doctorEnv) doctorCheck {
+	pager := strings.TrimSpace(env.cfg.GitPager)
+	if pager == "" {
+		return doctorCheck{Name: "pager", Status: doctorPass, Message: "diff formatting disabled (git_pager is empty)"}
+	}
+	if !env.gitSvc.UseGitPager() {
+		return doctorCheck{
+			Name:    "pager",
+			Status:  doctorWarn,
+			Message: fmt.Sprintf("git_pager %q not found in PATH; diffs are shown unformatted", pager),
+			Hint:    fmt.Sprintf("install %s or set git_pager to an empty string", pager),
+		}
+	}
+	return doctorCheck{Name: "pager", Status: doctorPass, Message: pager}
+}
+
+func checkDoctorMultiplexer(ctx context.Context, env doctorEnv, name, versionFlag string) doctorCheck {
+	if _, err := env.lookPath(name); err != nil {
+		return doctorCheck{
+			Name:    name,
+			Status:  doctorWarn,
+			Message: fmt.Sprintf("%s not found in PATH", name),
+			Hint:    fmt.Sprintf("install %s to use %s session commands", name, name),
+		}
+	}
+	version := env.toolVersion(ctx, name, versionFlag)
+	if version == "" {
+		version = name + " found"
+	}
+	return doctorCheck{Name: name, Status: doctorPass, Message: version}
+}
+
+func checkDoctorTrust(env doctorEnv) doctorCheck {
+	if err := env.trust.LoadError(); err != nil {
+		return doctorCheck{
+			Name:    "trust",
+			Status:  doctorFail,
+			Message: fmt.Sprintf("%s: %v", env.trust.DBPath(), err),
+			Hint:    "fix or remove the file; trusted .wt files will prompt again",
+		}
+	}
+	return doctorCheck{
+		Name:    "trust",
+		Status:  doctorPass,
+		Message: fmt.Sprintf("trust_mode %s, %d trusted file(s) in %s", env.cfg.TrustMode, env.trust.TrustedCount(), env.trust.DBPath()),
+	}
+}
+
+// outputDoctorChecks writes one line per check with its hint underneath.
+func outputDoctorChecks(out io.Writer, checks []doctorCheck) {
+	for _, check := range checks {
+		fmt.Fprintf(out, "[%s] %s: %s\n", check.Status, check.Name, check.Message)
+		if check.Hint != "" && check.Status != doctorPass {
+			fmt.Fprintf(out, "       hint: %s\n", check.Hint)
+		}
+	}
+}
+
+// doctorChecksError returns an error when any check failed.
+func doctorChecksError(checks []doctorCheck) error {
+	failed := 0
+	for _, check := range checks {
+		if check.Status == doctorFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDoctorGitService struct {
+	topLevel string
+	host     string
+	username string
+	pager    bool
+}
+
+func (f *fakeDoctorGitService) RunGit(context.Context, []string, string, []int, bool, bool) string {
+	return f.topLevel
+}
+
+func (f *fakeDoctorGitService) DetectHost(context.Context) string {
+	return f.host
+}
+
+func (f *fakeDoctorGitService) GetAuthenticatedUsername(context.Context) string {
+	return f.username
+}
+
+func (f *fakeDoctorGitService) UseGitPager() bool {
+	return f.pager
+}
+
+func newTestDoctorEnv(t *testing.T, installed ...string) doctorEnv {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	tools := make(map[string]bool, len(installed))
+	for _, name := range installed {
+		tools[name] = true
+	}
+
+	return doctorEnv{
+		cfg:        cfg,
+		configFile: filepath.Join(t.TempDir(), "config.yaml"),
+		gitSvc:     &fakeDoctorGitService{topLevel: "/repo", host: "github", username: "octocat", pager: true},
+		trust:      security.NewTrustManager(),
+		lookPath: func(name string) (string, error) {
+			if tools[name] {
+				return "/usr/bin/" + name, nil
+			}
+			return "", errors.New("not found")
+		},
+		toolVersion: func(_ context.Context, name string, _ ...string) string {
+			return name + " 1.0"
+		},
+		statWorktree: os.Stat,
+	}
+}
+
+func doctorChecksByName(checks []doctorCheck) map[string]doctorCheck {
+	byName := make(map[string]doctorCheck, len(checks))
+	for _, check := range checks {
+		byName[check.Name] = check
+	}
+	return byName
+}
+
+func TestRunDoctorChecksHealthy(t *testing.T) {
+	env := newTestDoctorEnv(t, "git", "gh", "tmux", "zellij")
+	require.NoError(t, os.WriteFile(env.configFile, []byte("trust_mode: tofu\n"), 0o600))
+
+	checks := runDoctorChecks(context.Background(), env)
+	for _, check := range checks {
+		assert.Equal(t, doctorPass, check.Status, "%s: %s", check.Name, check.Message)
+	}
+	byName := doctorChecksByName(checks)
+	assert.Equal(t, "gh authenticated as octocat", byName["forge"].Message)
+	assert.Equal(t, "tmux 1.0", byName["tmux"].Message)
+	require.NoError(t, doctorChecksError(checks))
+}
+
+func TestRunDoctorChecksProblems(t *testing.T) {
+	env := newTestDoctorEnv(t, "git", "glab")
+	env.gitSvc = &fakeDoctorGitService{host: "gitlab"}
+	env.cfg.WorktreeDir = filepath.Join(env.cfg.WorktreeDir, "missing")
+	require.NoError(t, os.WriteFile(env.configFile, []byte("trust_mode: sometimes\n"), 0o600))
+
+	trustPath := filepath.Join(os.Getenv("XDG_DATA_HOME"), "lazyworktree", "trusted.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(trustPath), 0o750))
+	require.NoError(t, os.WriteFile(trustPath, []byte("{{"), 0o600))
+	env.trust = security.NewTrustManager()
+
+	byName := doctorChecksByName(runDoctorChecks(context.Background(), env))
+	assert.Equal(t, doctorWarn, byName["repository"].Status)
+	assert.Equal(t, doctorWarn, byName["config"].Status)
+	assert.Contains(t, byName["config"].Message, "trust_mode")
+	assert.Equal(t, doctorWarn, byName["worktree_dir"].Status)
+	assert.Equal(t, doctorFail, byName["forge"].Status)
+	assert.Equal(t, "run 'glab auth login'", byName["forge"].Hint)
+	assert.Equal(t, doctorWarn, byName["tmux"].Status)
+	assert.Equal(t, doctorFail, byName["trust"].Status)
+
+	env.gitSvc = &fakeDoctorGitService{host: "github", pager: false}
+	byName = doctorChecksByName(runDoctorChecks(context.Background(), env))
+	assert.Equal(t, "gh not found in PATH", byName["forge"].Message)
+	assert.Equal(t, doctorWarn, byName["pager"].Status)
+
+	env.cfg.DisablePR = true
+	env.cfg.GitPager = ""
+	byName = doctorChecksByName(runDoctorChecks(context.Background(), env))
+	assert.Equal(t, doctorPass, byName["forge"].Status)
+	assert.Equal(t, doctorPass, byName["pager"].Status)
+}
+
+func TestOutputDoctorChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "git", Status: doctorPass, Message: "git version 2.45"},
+		{Name: "forge", Status: doctorFail, Message: "gh is not authenticated", Hint: "run 'gh auth login'"},
+	}
+
+	var buf bytes.Buffer
+	outputDoctorChecks(&buf, checks)
+	assert.Equal(t, "[pass] git: git version 2.45\n[fail] forge: gh is not authenticated\n       hint: run 'gh auth login'\n", buf.String())
+
+	err := doctorChecksError(checks)
+	require.Error(t, err)
+	assert.Equal(t, "1 check(s) failed", err.Error())
+}
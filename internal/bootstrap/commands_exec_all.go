@@ -0,0 +1,310 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/log"
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// execAllResult is the outcome of running the exec command in one worktree.
+type execAllResult struct {
+	Worktree *models.WorktreeInfo
+	ExitCode int
+	Err      error
+	Output   []byte
+	Duration time.Duration
+}
+
+// execAllOptions controls how runExecAll fans out a command.
+type execAllOptions struct {
+	Command string
+	Jobs    int
+	Group   bool
+	Env     func(wt *models.WorktreeInfo) map[string]string
+}
+
+// isExecFanOut reports whether exec should run across several worktrees.
+func isExecFanOut(cmd *appiCli.Command) bool {
+	return cmd.Bool("all") || cmd.String("filter") != "" || cmd.Bool("dirty-only")
+}
+
+func validateExecAllFlags(cmd *appiCli.Command, command string) error {
+	if err := validateIncompatibility("--all/--filter/--dirty-only", true, "--workspace", cmd.String("workspace") != ""); err != nil {
+		return err
+	}
+	if err := validateIncompatibility("--all/--filter/--dirty-only", true, "--key", cmd.String("key") != ""); err != nil {
+		return err
+	}
+	if command == "" {
+		return fmt.Errorf("a command argument is required with --all, --filter or --dirty-only")
+	}
+	if cmd.Int("jobs") < 1 {
+		return fmt.Errorf("--jobs must be at least 1")
+	}
+	return nil
+}
+
+// handleExecAllAction runs a shell command in every selected worktree.
+func handleExecAllAction(ctx context.Context, cmd *appiCli.Command, command string) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	if err := validateExecAllFlags(cmd, command); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting worktrees: %v\n", err)
+		return err
+	}
+	sortWorktreesByPath(worktrees)
+
+	selected := selectExecWorktrees(worktrees, cmd.String("filter"), cmd.Bool("dirty-only"))
+	if len(selected) == 0 {
+		fmt.Fprintln(os.Stderr, "No worktrees match the selection.")
+		return nil
+	}
+
+	repoName := gitSvc.ResolveRepoName(ctx)
+	mainWorktreePath := ""
+	for _, wt := range worktrees {
+		if wt.IsMain {
+			mainWorktreePath = wt.Path
+			break
+		}
+	}
+
+	results := runExecAll(ctx, selected, execAllOptions{
+		Command: command,
+		Jobs:    int(cmd.Int("jobs")),
+		Group:   cmd.Bool("group"),
+		Env: func(wt *models.WorktreeInfo) map[string]string {
+			return services.BuildCommandEnv(wt.Branch, wt.Path, repoName, mainWorktreePath)
+		},
+	}, os.Stdout)
+
+	if err := outputExecAllSummary(os.Stdout, results); err != nil {
+		return err
+	}
+	return execAllResultsError(results)
+}
+
+// selectExecWorktrees filters worktrees by name/branch pattern and dirtiness.
+// The pattern is a glob when it contains glob characters, otherwise a substring.
+func selectExecWorktrees(worktrees []*models.WorktreeInfo, filter string, dirtyOnly bool) []*models.WorktreeInfo {
+	selected := make([]*models.WorktreeInfo, 0, len(worktrees))
+	for _, wt := range worktrees {
+		if dirtyOnly && !wt.Dirty && wt.Untracked == 0 && wt.Modified == 0 && wt.Staged == 0 {
+			continue
+		}
+		if filter != "" && !matchesExecFilter(wt, filter) {
+			continue
+		}
+		selected = append(selected, wt)
+	}
+	return selected
+}
+
+func matchesExecFilter(wt *models.WorktreeInfo, filter string) bool {
+	for _, candidate := range []string{filepath.Base(wt.Path), wt.Branch} {
+		if strings.ContainsAny(filter, "*?[") {
+			if ok, _ := filepath.Match(filter, candidate); ok {
+				return true
+			}
+			continue
+		}
+		if strings.Contains(candidate, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// runExecAll runs opts.Command in each worktree with at most opts.Jobs
+// commands in flight. Output is either streamed with a "[name] " prefix on
+// every line, or captured and written as one block per worktree when
+// opts.Group is set. Results are returned in worktree order.
+func runExecAll(ctx context.Context, worktrees []*models.WorktreeInfo, opts execAllOptions, out io.Writer) []execAllResult {
+	jobs := opts.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	results := make([]execAllResult, len(worktrees))
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, jobs)
+	)
+
+	for i, wt := range worktrees {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, wt *models.WorktreeInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			name := filepath.Base(wt.Path)
+			var (
+				captured bytes.Buffer
+				writer   io.Writer
+				prefixed *prefixWriter
+			)
+			if opts.Group {
+				writer = &captured
+			} else {
+				prefixed = &prefixWriter{mu: &mu, out: out, prefix: fmt.Sprintf("[%s] ", name)}
+				writer = prefixed
+			}
+
+			var env map[string]string
+			if opts.Env != nil {
+				env = opts.Env(wt)
+			}
+
+			start := time.Now()
+			err := runExecAllCommand(ctx, opts.Command, wt.Path, env, writer)
+			result := execAllResult{Worktree: wt, Err: err, Duration: time.Since(start)}
+			result.ExitCode = execExitCode(err)
+
+			if prefixed != nil {
+				prefixed.Flush()
+			} else {
+				result.Output = captured.Bytes()
+				mu.Lock()
+				fmt.Fprintf(out, "==> %s (%s) exit %d\n", name, wt.Path, result.ExitCode)
+				_, _ = out.Write(result.Output)
+				if len(result.Output) > 0 && result.Output[len(result.Output)-1] != '\n' {
+					fmt.Fprintln(out)
+				}
+				mu.Unlock()
+			}
+			results[i] = result
+		}(i, wt)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runExecAllCommand runs command non-interactively so parallel jobs do not
+// compete for the terminal.
+func runExecAllCommand(ctx context.Context, command, cwd string, env map[string]string, w io.Writer) error {
+	shellPath := strings.TrimSpace(os.Getenv("SHELL"))
+	if shellPath == "" {
+		shellPath = "bash"
+	}
+	// #nosec G204 -- explicit user-provided command executed by request
+	execCmd := exec.CommandContext(ctx, shellPath, "-lc", command)
+	execCmd.Dir = cwd
+	execCmd.Stdout = w
+	execCmd.Stderr = w
+	execCmd.Env = append(os.Environ(), services.EnvMapToList(env)...)
+	return execCmd.Run()
+}
+
+// execExitCode maps a command error to an exit code, using -1 when the
+// command could not be started.
+func execExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// outputExecAllSummary writes the exit code of every worktree.
+func outputExecAllSummary(out io.Writer, results []execAllResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nNAME\tBRANCH\tEXIT\tDURATION")
+	for _, r := range results {
+		exitCode := fmt.Sprintf("%d", r.ExitCode)
+		if r.ExitCode < 0 && r.Err != nil {
+			exitCode = fmt.Sprintf("error: %v", r.Err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(r.Worktree.Path), r.Worktree.Branch, exitCode, r.Duration.Round(time.Millisecond))
+	}
+	return w.Flush()
+}
+
+// execAllResultsError returns an error when the command failed in any worktree.
+func execAllResultsError(results []execAllResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.ExitCode != 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("command failed in %d of %d worktree(s)", failed, len(results))
+	}
+	return nil
+}
+
+// prefixWriter writes complete lines to out with a prefix, serialising
+// writes from concurrent commands through mu.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+	for {
+		idx := bytes.IndexByte(p.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		p.writeLine(p.buf[:idx+1])
+		p.buf = p.buf[idx+1:]
+	}
+	return len(data), nil
+}
+
+// Flush writes any trailing partial line.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) == 0 {
+		return
+	}
+	p.writeLine(append(p.buf, '\n'))
+	p.buf = nil
+}
+
+func (p *prefixWriter) writeLine(line []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = io.WriteString(p.out, p.prefix)
+	_, _ = p.out.Write(line)
+}
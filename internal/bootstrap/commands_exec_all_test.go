@@ -0,0 +1,133 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+func TestSelectExecWorktrees(t *testing.T) {
+	worktrees := []*models.WorktreeInfo{
+		{Path: "/wt/main", Branch: "main", IsMain: true},
+		{Path: "/wt/repo/feature-a", Branch: "feature-a", Modified: 1},
+		{Path: "/wt/repo/feature-b", Branch: "feature-b"},
+		{Path: "/wt/repo/fix", Branch: "bugfix/login", Dirty: true},
+	}
+
+	names := func(wts []*models.WorktreeInfo) []string {
+		out := make([]string, 0, len(wts))
+		for _, wt := range wts {
+			out = append(out, filepath.Base(wt.Path))
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"main", "feature-a", "feature-b", "fix"}, names(selectExecWorktrees(worktrees, "", false)))
+	assert.Equal(t, []string{"feature-a", "feature-b"}, names(selectExecWorktrees(worktrees, "feature", false)))
+	assert.Equal(t, []string{"feature-b"}, names(selectExecWorktrees(worktrees, "*-b", false)))
+	assert.Equal(t, []string{"fix"}, names(selectExecWorktrees(worktrees, "bugfix/*", false)))
+	assert.Equal(t, []string{"feature-a", "fix"}, names(selectExecWorktrees(worktrees, "", true)))
+	assert.Equal(t, []string{"feature-a"}, names(selectExecWorktrees(worktrees, "feature", true)))
+}
+
+func TestRunExecAllPrefixesOutput(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+	worktrees := []*models.WorktreeInfo{
+		{Path: dirA, Branch: "a"},
+		{Path: dirB, Branch: "b"},
+	}
+
+	var out bytes.Buffer
+	results := runExecAll(context.Background(), worktrees, execAllOptions{
+		Command: `printf 'one\ntwo'; test "$WORKTREE_BRANCH" = a`,
+		Jobs:    2,
+		Env: func(wt *models.WorktreeInfo) map[string]string {
+			return map[string]string{"WORKTREE_BRANCH": wt.Branch}
+		},
+	}, &out)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, 0, results[0].ExitCode)
+	assert.Equal(t, 1, results[1].ExitCode)
+
+	output := out.String()
+	prefixA := "[" + filepath.Base(dirA) + "] "
+	prefixB := "[" + filepath.Base(dirB) + "] "
+	assert.Contains(t, output, prefixA+"one\n")
+	assert.Contains(t, output, prefixA+"two\n")
+	assert.Contains(t, output, prefixB+"two\n")
+
+	err := execAllResultsError(results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2")
+}
+
+func TestRunExecAllGroupsOutput(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+	dir := t.TempDir()
+	worktrees := []*models.WorktreeInfo{{Path: dir, Branch: "a"}}
+
+	var out bytes.Buffer
+	results := runExecAll(context.Background(), worktrees, execAllOptions{
+		Command: "echo hello; exit 3",
+		Jobs:    1,
+		Group:   true,
+	}, &out)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, 3, results[0].ExitCode)
+	assert.Equal(t, "hello\n", string(results[0].Output))
+	assert.True(t, strings.HasPrefix(out.String(), "==> "+filepath.Base(dir)))
+	assert.Contains(t, out.String(), "exit 3")
+
+	var summary bytes.Buffer
+	require.NoError(t, outputExecAllSummary(&summary, results))
+	assert.Contains(t, summary.String(), "EXIT")
+	assert.Contains(t, summary.String(), "3")
+}
+
+func TestPrefixWriterFlushesPartialLines(t *testing.T) {
+	var out bytes.Buffer
+	w := &prefixWriter{mu: &sync.Mutex{}, out: &out, prefix: "[x] "}
+	_, _ = w.Write([]byte("a\nb"))
+	_, _ = w.Write([]byte("c\n"))
+	_, _ = w.Write([]byte("tail"))
+	w.Flush()
+	assert.Equal(t, "[x] a\n[x] bc\n[x] tail\n", out.String())
+}
+
+func TestHandleExecAllValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "workspace with all", args: []string{"lazyworktree", "exec", "--all", "-w", "foo", "ls"}, wantErr: "--workspace"},
+		{name: "key with filter", args: []string{"lazyworktree", "exec", "--filter", "foo", "--key", "t"}, wantErr: "--key"},
+		{name: "missing command", args: []string{"lazyworktree", "exec", "--dirty-only"}, wantErr: "command argument is required"},
+		{name: "zero jobs", args: []string{"lazyworktree", "exec", "--all", "--jobs", "0", "ls"}, wantErr: "--jobs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := execCommand()
+			cmd.Action = func(_ context.Context, c *urfavecli.Command) error {
+				require.True(t, isExecFanOut(c))
+				return validateExecAllFlags(c, c.Args().Get(0))
+			}
+			app := &urfavecli.Command{Name: "lazyworktree", Commands: []*urfavecli.Command{cmd}}
+			err := app.Run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
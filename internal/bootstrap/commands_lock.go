@@ -0,0 +1,87 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/log"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+func lockCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "lock",
+		Usage:     "Lock a worktree so it is not pruned or deleted",
+		ArgsUsage: "[worktree-name-or-path]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleLockAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.StringFlag{
+				Name:  "reason",
+				Usage: "Why the worktree is locked, shown in list and the TUI",
+			},
+		},
+	}
+}
+
+func unlockCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "unlock",
+		Usage:     "Unlock a locked worktree",
+		ArgsUsage: "[worktree-name-or-path]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleUnlockAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+	}
+}
+
+// handleLockAction handles the lock subcommand action.
+func handleLockAction(ctx context.Context, cmd *appiCli.Command) error {
+	return runLockAction(cmd, func(gitSvc *git.Service, cfg *config.AppConfig, target string) error {
+		return cli.LockWorktree(ctx, gitSvc, cfg, target, cmd.String("reason"))
+	})
+}
+
+// handleUnlockAction handles the unlock subcommand action.
+func handleUnlockAction(ctx context.Context, cmd *appiCli.Command) error {
+	return runLockAction(cmd, func(gitSvc *git.Service, cfg *config.AppConfig, target string) error {
+		return cli.UnlockWorktree(ctx, gitSvc, cfg, target)
+	})
+}
+
+func runLockAction(cmd *appiCli.Command, action func(*git.Service, *config.AppConfig, string) error) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	target, err := resolveTargetArg(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := action(newCLIGitServiceFunc(cfg), cfg, target); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+func TestLockCompletionSuggestsWorktreeBasenames(t *testing.T) {
+	oldList := listSubcommandWorktreeNamesFunc
+	t.Cleanup(func() {
+		listSubcommandWorktreeNamesFunc = oldList
+	})
+	listSubcommandWorktreeNamesFunc = func(context.Context, *urfavecli.Command) []string {
+		return []string{"feature-a", "feature-b"}
+	}
+
+	for _, cmd := range []*urfavecli.Command{lockCommand(), unlockCommand()} {
+		out := runSubcommandCompletion(t, cmd, []string{"lazyworktree", cmd.Name, "--generate-shell-completion"})
+		assert.Contains(t, out, "feature-a")
+		assert.Contains(t, out, "feature-b")
+	}
+}
+
+func TestLockRejectsExtraArguments(t *testing.T) {
+	app := &urfavecli.Command{
+		Name:     "lazyworktree",
+		Commands: []*urfavecli.Command{lockCommand()},
+	}
+	err := app.Run(context.Background(), []string{"lazyworktree", "lock", "a", "b"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too many arguments")
+}
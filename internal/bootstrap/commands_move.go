@@ -0,0 +1,143 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/log"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+func moveCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "move",
+		Usage:     "Move a worktree to another directory, or adopt worktrees created outside worktree_dir",
+		ArgsUsage: "<destination> | <worktree> <destination> | --adopt",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleMoveAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "adopt",
+				Usage: "Move every worktree living outside worktree_dir/<repo>, or worktree_path_template, into it",
+			},
+			&appiCli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "With --adopt, list the moves without doing them",
+			},
+			&appiCli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "With --adopt, do not ask for confirmation",
+			},
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+		},
+	}
+}
+
+func validateMoveFlags(cmd *appiCli.Command) error {
+	if cmd.Bool("adopt") {
+		return validateIncompatibility("--adopt", true, "worktree or destination arguments", cmd.NArg() > 0)
+	}
+	if cmd.Bool("dry-run") || cmd.Bool("yes") {
+		return fmt.Errorf("--dry-run and --yes only apply to --adopt")
+	}
+	if cmd.NArg() == 0 {
+		return fmt.Errorf("expected a destination, or --adopt")
+	}
+	if cmd.NArg() > 2 {
+		return fmt.Errorf("too many arguments: expected <worktree-name-or-path> <destination>")
+	}
+	return nil
+}
+
+// handleMoveAction handles the move subcommand action.
+func handleMoveAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	if err := validateMoveFlags(cmd); err != nil {
+		return err
+	}
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+	gitSvc := newCLIGitServiceFunc(cfg)
+
+	if cmd.Bool("adopt") {
+		return handleAdoptAction(ctx, cmd, gitSvc, cfg)
+	}
+
+	target, destination := "", cmd.Args().Get(0)
+	if cmd.NArg() == 2 {
+		target, destination = cmd.Args().Get(0), cmd.Args().Get(1)
+	} else if target, err = os.Getwd(); err != nil {
+		return fmt.Errorf("failed to determine current directory: %w", err)
+	}
+
+	newPath, err := cli.MoveWorktree(ctx, gitSvc, cfg, target, destination, cmd.Bool("silent"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+	fmt.Fprintln(os.Stdout, newPath)
+	return nil
+}
+
+func handleAdoptAction(ctx context.Context, cmd *appiCli.Command, gitSvc *git.Service, cfg *config.AppConfig) error {
+	moves, err := cli.PlanAdoption(ctx, gitSvc, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+	if len(moves) == 0 {
+		fmt.Fprintln(os.Stderr, "No worktrees outside the worktree directory.")
+		return nil
+	}
+
+	if err := outputAdoptionPlan(os.Stdout, moves); err != nil {
+		return err
+	}
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+	if !cmd.Bool("yes") && !confirmYes(os.Stdin, os.Stderr, fmt.Sprintf("Move %d worktree(s)?", len(moves))) {
+		return fmt.Errorf("adopt cancelled")
+	}
+
+	results := cli.AdoptWorktrees(ctx, gitSvc, cfg, moves, cmd.Bool("silent"))
+	fmt.Fprintln(os.Stdout)
+	if err := outputOperationResults(os.Stdout, results); err != nil {
+		return err
+	}
+	return operationResultsError("move", results)
+}
+
+// outputAdoptionPlan lists each worktree with its current and new path.
+func outputAdoptionPlan(out io.Writer, moves []cli.WorktreeMove) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBRANCH\tPATH\tDESTINATION")
+	for _, m := range moves {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(m.Worktree.Path), m.Worktree.Branch, m.Worktree.Path, m.Destination)
+	}
+	return w.Flush()
+}
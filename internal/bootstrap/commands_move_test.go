@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+func TestMoveFlagValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "no destination", args: []string{"move"}, want: "expected a destination"},
+		{name: "too many arguments", args: []string{"move", "a", "b", "c"}, want: "too many arguments"},
+		{name: "adopt with arguments", args: []string{"move", "--adopt", "a"}, want: "--adopt cannot be used with"},
+		{name: "dry-run without adopt", args: []string{"move", "--dry-run", "a"}, want: "only apply to --adopt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &urfavecli.Command{
+				Name:     "lazyworktree",
+				Commands: []*urfavecli.Command{moveCommand()},
+			}
+			err := app.Run(context.Background(), append([]string{"lazyworktree"}, tt.args...))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestOutputAdoptionPlan(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, outputAdoptionPlan(&buf, []cli.WorktreeMove{
+		{Worktree: &models.WorktreeInfo{Path: "/src/feature", Branch: "feature"}, Destination: "/wt/repo/feature"},
+	}))
+	out := buf.String()
+	assert.Contains(t, out, "DESTINATION")
+	assert.Regexp(t, `feature\s+feature\s+/src/feature\s+/wt/repo/feature`, out)
+}
@@ -0,0 +1,390 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/log"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/multiplexer"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+const noteListPreviewWidth = 60
+
+// noteJSON describes a worktree note in --json output.
+type noteJSON struct {
+	Worktree  string `json:"worktree"`
+	Path      string `json:"path"`
+	Branch    string `json:"branch"`
+	Key       string `json:"key"`
+	Note      string `json:"note"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// noteTarget is a resolved worktree together with the note storage settings.
+type noteTarget struct {
+	cfg       *config.AppConfig
+	repoKey   string
+	layout    services.WorktreeLayout
+	worktrees []*models.WorktreeInfo
+	worktree  *models.WorktreeInfo
+}
+
+func (t *noteTarget) load() (models.WorktreeNote, bool, error) {
+	return t.loadPath(t.worktree.Path)
+}
+
+func (t *noteTarget) loadPath(path string) (models.WorktreeNote, bool, error) {
+	return services.LoadWorktreeNote(t.repoKey, t.cfg.WorktreeDir, t.cfg.WorktreeNotesPath, path)
+}
+
+func (t *noteTarget) save(noteText string) error {
+	return t.savePath(t.worktree.Path, noteText)
+}
+
+func (t *noteTarget) savePath(path, noteText string) error {
+	if n := len([]rune(strings.TrimSpace(noteText))); n > services.WorktreeNoteMaxChars {
+		return fmt.Errorf("note is too long (%d/%d characters)", n, services.WorktreeNoteMaxChars)
+	}
+	return services.SaveWorktreeNote(t.repoKey, t.cfg.WorktreeDir, t.cfg.WorktreeNotesPath, path, noteText)
+}
+
+func (t *noteTarget) clear() error {
+	return services.DeleteWorktreeNote(t.repoKey, t.cfg.WorktreeDir, t.cfg.WorktreeNotesPath, t.worktree.Path)
+}
+
+func (t *noteTarget) toJSON(wt *models.WorktreeInfo, note models.WorktreeNote) noteJSON {
+	return noteJSON{
+		Worktree:  filepath.Base(wt.Path),
+		Path:      wt.Path,
+		Branch:    wt.Branch,
+		Key:       services.WorktreeNoteKey(t.repoKey, t.cfg.WorktreeDir, t.cfg.WorktreeNotesPath, wt.Path),
+		Note:      note.Note,
+		UpdatedAt: note.UpdatedAt,
+	}
+}
+
+func noteCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "note",
+		Usage: "Read and write worktree notes",
+		Commands: []*appiCli.Command{
+			nestedSubcommand("get", "Print the note of a worktree", "[worktree]", handleNoteGetAction, jsonFlag()),
+			nestedSubcommand("set", "Replace the note of a worktree (reads stdin when text is omitted or '-')", "[worktree] [text]", handleNoteSetAction),
+			nestedSubcommand("append", "Append text to the note of a worktree (reads stdin when text is omitted or '-')", "[worktree] [text]", handleNoteAppendAction),
+			nestedSubcommand("edit", "Edit the note of a worktree in your editor", "[worktree]", handleNoteEditAction),
+			nestedSubcommand("list", "List worktrees that have notes", "", handleNoteListAction, jsonFlag()),
+			nestedSubcommand("clear", "Remove the note of a worktree", "[worktree]", handleNoteClearAction),
+		},
+	}
+}
+
+func jsonFlag() appiCli.Flag {
+	return &appiCli.BoolFlag{
+		Name:  "json",
+		Usage: "Output as JSON",
+	}
+}
+
+// nestedSubcommand builds a leaf command below a command group such as note
+// or tasks, reporting handler errors on stderr.
+func nestedSubcommand(name, usage, argsUsage string, handler func(context.Context, *appiCli.Command) error, flags ...appiCli.Flag) *appiCli.Command {
+	return &appiCli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: argsUsage,
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			defer func() {
+				_ = log.Close()
+			}()
+			if err := handler(ctx, cmd); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return err
+			}
+			return nil
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags:         flags,
+	}
+}
+
+// isNestedSubcommandOf reports whether cmd is a subcommand of the named
+// command group.
+func isNestedSubcommandOf(cmd *appiCli.Command, group string) bool {
+	lineage := cmd.Lineage()
+	return len(lineage) > 1 && lineage[1].Name == group
+}
+
+// resolveNoteTarget loads config and resolves the worktree named by arg, or
+// the worktree containing the current directory when arg is empty.
+func resolveNoteTarget(ctx context.Context, cmd *appiCli.Command, arg string) (*noteTarget, error) {
+	target, err := loadNoteTarget(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Name == "list" {
+		return target, nil
+	}
+	if err := target.selectWorktree(arg); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// loadNoteTarget loads config and the worktrees of the repository without
+// selecting one.
+func loadNoteTarget(ctx context.Context, cmd *appiCli.Command) (*noteTarget, error) {
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	return &noteTarget{
+		cfg:       cfg,
+		repoKey:   gitSvc.ResolveRepoName(ctx),
+		layout:    cli.LoadWorktreeLayout(ctx, gitSvc, cfg),
+		worktrees: worktrees,
+	}, nil
+}
+
+// selectWorktree resolves arg, or the current directory when arg is empty.
+func (t *noteTarget) selectWorktree(arg string) error {
+	if arg == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to determine current directory: %w", err)
+		}
+		arg = cwd
+	}
+	wt, err := cli.FindWorktreeByPathOrName(arg, t.worktrees, t.layout)
+	if err != nil {
+		return err
+	}
+	t.worktree = wt
+	return nil
+}
+
+func handleNoteGetAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() > 1 {
+		return fmt.Errorf("too many arguments: expected [worktree]")
+	}
+	target, err := resolveNoteTarget(ctx, cmd, cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	note, _, err := target.load()
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		return writeNoteJSON(os.Stdout, target.toJSON(target.worktree, note))
+	}
+	if note.Note != "" {
+		fmt.Fprintln(os.Stdout, note.Note)
+	}
+	return nil
+}
+
+func handleNoteSetAction(ctx context.Context, cmd *appiCli.Command) error {
+	return handleNoteWrite(ctx, cmd, func(_, text string) string {
+		return text
+	})
+}
+
+func handleNoteAppendAction(ctx context.Context, cmd *appiCli.Command) error {
+	return handleNoteWrite(ctx, cmd, appendNoteText)
+}
+
+// handleNoteWrite resolves the target and input text, then stores
+// combine(existing, input) as the new note.
+func handleNoteWrite(ctx context.Context, cmd *appiCli.Command, combine func(existing, text string) string) error {
+	if cmd.NArg() > 2 {
+		return fmt.Errorf("too many arguments: expected [worktree] [text]")
+	}
+	target, err := resolveNoteTarget(ctx, cmd, cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	text, err := readNoteInput(cmd.Args().Get(1), cmd.NArg() > 1, os.Stdin)
+	if err != nil {
+		return err
+	}
+	existing, _, err := target.load()
+	if err != nil {
+		return err
+	}
+	return target.save(combine(existing.Note, text))
+}
+
+func handleNoteEditAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() > 1 {
+		return fmt.Errorf("too many arguments: expected [worktree]")
+	}
+	target, err := resolveNoteTarget(ctx, cmd, cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	editor := services.EditorCommand(target.cfg)
+	if strings.TrimSpace(editor) == "" {
+		return fmt.Errorf("no editor configured; set editor in config or $EDITOR")
+	}
+	existing, _, err := target.load()
+	if err != nil {
+		return err
+	}
+
+	edited, err := editNoteInEditor(ctx, editor, existing.Note)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(edited) == "" {
+		return target.clear()
+	}
+	return target.save(edited)
+}
+
+func handleNoteListAction(ctx context.Context, cmd *appiCli.Command) error {
+	target, err := resolveNoteTarget(ctx, cmd, "")
+	if err != nil {
+		return err
+	}
+	sortWorktreesByPath(target.worktrees)
+	entries := make([]noteJSON, 0, len(target.worktrees))
+	for _, wt := range target.worktrees {
+		note, ok, err := target.loadPath(wt.Path)
+		if err != nil {
+			return err
+		}
+		if ok {
+			entries = append(entries, target.toJSON(wt, note))
+		}
+	}
+
+	if cmd.Bool("json") {
+		return writeNoteJSON(os.Stdout, entries)
+	}
+	return outputNoteList(os.Stdout, entries)
+}
+
+func handleNoteClearAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() > 1 {
+		return fmt.Errorf("too many arguments: expected [worktree]")
+	}
+	target, err := resolveNoteTarget(ctx, cmd, cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	return target.clear()
+}
+
+// readNoteInput returns arg when given, otherwise reads the whole of stdin.
+// An argument of "-" also reads stdin.
+func readNoteInput(arg string, hasArg bool, stdin io.Reader) (string, error) {
+	text := arg
+	if !hasArg || arg == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read note from stdin: %w", err)
+		}
+		text = string(data)
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("note text is empty; use 'note clear' to remove a note")
+	}
+	return text, nil
+}
+
+// appendNoteText adds text on a new line after the existing note.
+func appendNoteText(existing, text string) string {
+	existing = strings.TrimRight(existing, "\n")
+	text = strings.TrimSpace(text)
+	if strings.TrimSpace(existing) == "" {
+		return text
+	}
+	return existing + "\n" + text
+}
+
+// editNoteInEditor writes noteText to a temporary file, opens it in editor and
+// returns the edited content.
+func editNoteInEditor(ctx context.Context, editor, noteText string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "lwt-note-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmpFile.WriteString(noteText); err != nil {
+		_ = tmpFile.Close()
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	_ = tmpFile.Close()
+
+	// #nosec G204 -- command is constructed from user config and controlled inputs
+	editCmd := exec.CommandContext(ctx, "bash", "-c", fmt.Sprintf("%s %s", editor, multiplexer.ShellQuote(tmpPath)))
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	// #nosec G304 -- tmpPath is created by os.CreateTemp above, not user-controlled
+	content, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func writeNoteJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// outputNoteList writes one line per worktree note with the first line as a preview.
+func outputNoteList(out io.Writer, entries []noteJSON) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "No worktree notes.")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBRANCH\tUPDATED\tNOTE")
+	for _, entry := range entries {
+		preview, _, _ := strings.Cut(entry.Note, "\n")
+		if runes := []rune(preview); len(runes) > noteListPreviewWidth {
+			preview = string(runes[:noteListPreviewWidth-1]) + "…"
+		}
+		updated := "-"
+		if entry.UpdatedAt > 0 {
+			updated = time.Unix(entry.UpdatedAt, 0).Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Worktree, entry.Branch, updated, preview)
+	}
+	return w.Flush()
+}
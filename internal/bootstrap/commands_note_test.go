@@ -0,0 +1,107 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+func TestReadNoteInput(t *testing.T) {
+	text, err := readNoteInput("from arg", true, strings.NewReader("ignored"))
+	require.NoError(t, err)
+	assert.Equal(t, "from arg", text)
+
+	text, err = readNoteInput("", false, strings.NewReader("from stdin\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "from stdin\n", text)
+
+	text, err = readNoteInput("-", true, strings.NewReader("dash"))
+	require.NoError(t, err)
+	assert.Equal(t, "dash", text)
+
+	_, err = readNoteInput("", false, strings.NewReader("  \n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "note clear")
+}
+
+func TestAppendNoteText(t *testing.T) {
+	assert.Equal(t, "new", appendNoteText("", " new\n"))
+	assert.Equal(t, "old\nnew", appendNoteText("old\n", "new"))
+}
+
+func TestNoteTargetSharedStorage(t *testing.T) {
+	worktreeDir := t.TempDir()
+	notesPath := filepath.Join(t.TempDir(), "notes.json")
+	wt := &models.WorktreeInfo{Path: filepath.Join(worktreeDir, "repo", "feature"), Branch: "feature"}
+	target := &noteTarget{
+		cfg:       &config.AppConfig{WorktreeDir: worktreeDir, WorktreeNotesPath: notesPath},
+		repoKey:   "repo",
+		worktrees: []*models.WorktreeInfo{wt},
+		worktree:  wt,
+	}
+
+	require.NoError(t, target.save("first"))
+	require.NoError(t, target.save(appendNoteText("first", "second")))
+	require.FileExists(t, notesPath)
+
+	note, ok, err := target.load()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "first\nsecond", note.Note)
+
+	entry := target.toJSON(wt, note)
+	assert.Equal(t, "feature", entry.Worktree)
+	assert.Equal(t, "feature", entry.Key)
+
+	err = target.save(strings.Repeat("x", 4001))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too long")
+
+	require.NoError(t, target.clear())
+	_, ok, err = target.load()
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestOutputNoteList(t *testing.T) {
+	entries := []noteJSON{
+		{Worktree: "feature", Branch: "feature", Note: "first line\nsecond line", UpdatedAt: 1700000000},
+		{Worktree: "long", Branch: "long", Note: strings.Repeat("y", 100)},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputNoteList(&buf, entries))
+	out := buf.String()
+	assert.Contains(t, out, "NOTE")
+	assert.Contains(t, out, "first line")
+	assert.NotContains(t, out, "second line")
+	assert.Contains(t, out, strings.Repeat("y", noteListPreviewWidth-1)+"…")
+
+	buf.Reset()
+	require.NoError(t, writeNoteJSON(&buf, entries))
+	var decoded []noteJSON
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, entries, decoded)
+}
+
+func TestNoteCompletionSuggestsWorktreeBasenames(t *testing.T) {
+	oldList := listSubcommandWorktreeNamesFunc
+	t.Cleanup(func() {
+		listSubcommandWorktreeNamesFunc = oldList
+	})
+	listSubcommandWorktreeNamesFunc = func(context.Context, *urfavecli.Command) []string {
+		return []string{"feature-a"}
+	}
+
+	out := runSubcommandCompletion(t, noteCommand(), []string{"lazyworktree", "note", "get", "--generate-shell-completion"})
+	assert.Contains(t, out, "feature-a")
+}
@@ -0,0 +1,180 @@
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/log"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+func pruneCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "prune",
+		Usage: "Remove worktrees whose branch has been merged",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handlePruneAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the worktrees that would be pruned without removing anything",
+			},
+			&appiCli.StringFlag{
+				Name:  "source",
+				Usage: "Merge detection to use: pr (merged PR/MR), git (branch merged into main) or both",
+				Value: cli.PruneSourceBoth,
+			},
+			&appiCli.BoolFlag{
+				Name:  "keep-branch",
+				Usage: "Keep the local branch after removing the worktree",
+			},
+			&appiCli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Do not ask for confirmation",
+			},
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+		},
+	}
+}
+
+func validatePruneFlags(cmd *appiCli.Command) error {
+	return cli.ValidatePruneSource(cmd.String("source"))
+}
+
+// handlePruneAction handles the prune subcommand action.
+func handlePruneAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	if err := validatePruneFlags(cmd); err != nil {
+		return err
+	}
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	candidates, err := cli.FindPruneCandidates(ctx, gitSvc, cfg, cmd.String("source"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+
+	if len(candidates) == 0 {
+		fmt.Fprintln(os.Stderr, "No merged worktrees to prune.")
+		return nil
+	}
+
+	if err := outputPruneCandidates(os.Stdout, candidates); err != nil {
+		return err
+	}
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+
+	if !cmd.Bool("yes") && !confirmPrune(os.Stdin, os.Stderr, countPrunable(candidates)) {
+		return fmt.Errorf("prune cancelled")
+	}
+
+	results := cli.PruneWorktrees(ctx, gitSvc, cfg, candidates, cmd.Bool("keep-branch"), cmd.Bool("silent"))
+	if err := outputPruneResults(os.Stdout, results); err != nil {
+		return err
+	}
+	return pruneResultsError(results)
+}
+
+// countPrunable returns how many candidates will actually be removed.
+func countPrunable(candidates []appservices.PruneCandidate) int {
+	count := 0
+	for _, c := range candidates {
+		if cli.PruneSkipReason(c.Worktree) == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// outputPruneCandidates lists prune candidates with the source that detected
+// them and what prune will do with each one.
+func outputPruneCandidates(out io.Writer, candidates []appservices.PruneCandidate) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBRANCH\tSOURCE\tACTION")
+	for _, c := range candidates {
+		action := cli.PruneStatusWouldPrune
+		if reason := cli.PruneSkipReason(c.Worktree); reason != "" {
+			action = fmt.Sprintf("skip (%s)", reason)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(c.Worktree.Path), c.Worktree.Branch, c.Source, action)
+	}
+	return w.Flush()
+}
+
+// confirmPrune asks for confirmation on w and reads the answer from r.
+func confirmPrune(r io.Reader, w io.Writer, count int) bool {
+	if count == 0 {
+		return true
+	}
+	return confirmYes(r, w, fmt.Sprintf("Prune %d worktree(s)?", count))
+}
+
+// confirmYes asks question on w and reports whether the answer read from r
+// is yes.
+func confirmYes(r io.Reader, w io.Writer, question string) bool {
+	fmt.Fprintf(w, "\n%s [y/N]: ", question)
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// outputPruneResults writes the outcome for each candidate.
+func outputPruneResults(out io.Writer, results []cli.PruneResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nNAME\tBRANCH\tSOURCE\tRESULT")
+	for _, r := range results {
+		status := r.Status
+		if r.Reason != "" {
+			status = fmt.Sprintf("%s (%s)", r.Status, r.Reason)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(r.Worktree.Path), r.Worktree.Branch, r.Source, status)
+	}
+	return w.Flush()
+}
+
+// pruneResultsError returns an error when at least one worktree failed to prune.
+func pruneResultsError(results []cli.PruneResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Status == cli.PruneStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to prune %d worktree(s)", failed)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package bootstrap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputPruneCandidates(t *testing.T) {
+	candidates := []appservices.PruneCandidate{
+		{Worktree: &models.WorktreeInfo{Path: "/wt/repo/feature", Branch: "feature"}, Source: "both"},
+		{Worktree: &models.WorktreeInfo{Path: "/wt/repo/dirty", Branch: "dirty", Dirty: true}, Source: "git"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputPruneCandidates(&buf, candidates))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "SOURCE")
+	assert.Contains(t, lines[1], "both")
+	assert.Contains(t, lines[1], "would prune")
+	assert.Contains(t, lines[2], "skip (uncommitted changes)")
+	assert.Equal(t, 1, countPrunable(candidates))
+}
+
+func TestConfirmPrune(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "y\n", want: true},
+		{input: "YES\n", want: true},
+		{input: "n\n", want: false},
+		{input: "\n", want: false},
+		{input: "", want: false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		assert.Equal(t, tt.want, confirmPrune(strings.NewReader(tt.input), &out, 2), "input %q", tt.input)
+		assert.Contains(t, out.String(), "Prune 2 worktree(s)?")
+	}
+
+	assert.True(t, confirmPrune(strings.NewReader(""), &bytes.Buffer{}, 0))
+}
+
+func TestPruneResultsError(t *testing.T) {
+	wt := &models.WorktreeInfo{Path: "/wt/repo/feature", Branch: "feature"}
+	ok := []cli.PruneResult{
+		{Worktree: wt, Source: "git", Status: cli.PruneStatusPruned},
+		{Worktree: wt, Source: "git", Status: cli.PruneStatusSkipped, Reason: "uncommitted changes"},
+	}
+	require.NoError(t, pruneResultsError(ok))
+
+	failed := append(ok, cli.PruneResult{Worktree: wt, Source: "pr", Status: cli.PruneStatusFailed, Reason: "boom"})
+	require.Error(t, pruneResultsError(failed))
+
+	var buf bytes.Buffer
+	require.NoError(t, outputPruneResults(&buf, failed))
+	assert.Contains(t, buf.String(), "failed (boom)")
+}
@@ -0,0 +1,133 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/log"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+func repairCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "repair",
+		Usage: "Find and fix orphaned directories, broken gitdir links, prunable entries and dangling symlinks",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleRepairAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the problems and the fix for each without changing anything",
+			},
+			&appiCli.BoolFlag{
+				Name:  "remove-orphans",
+				Usage: "Delete orphaned directories instead of adopting them as worktrees",
+			},
+			&appiCli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Do not ask for confirmation",
+			},
+		},
+	}
+}
+
+// handleRepairAction handles the repair subcommand action.
+func handleRepairAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	issues, err := cli.ScanWorktreeHealth(ctx, gitSvc, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(os.Stderr, "No problems found.")
+		return nil
+	}
+
+	removeOrphans := cmd.Bool("remove-orphans")
+	if err := outputHealthIssues(os.Stdout, issues, removeOrphans); err != nil {
+		return err
+	}
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+	if !cmd.Bool("yes") && !confirmYes(os.Stdin, os.Stderr, fmt.Sprintf("Apply %d fix(es)?", len(issues))) {
+		return fmt.Errorf("repair cancelled")
+	}
+
+	results := cli.RepairWorktrees(ctx, gitSvc, issues, removeOrphans)
+	if err := outputRepairResults(os.Stdout, results); err != nil {
+		return err
+	}
+	return repairResultsError(results)
+}
+
+// outputHealthIssues lists the problems found and the fix repair applies,
+// followed by the alternatives.
+func outputHealthIssues(out io.Writer, issues []appservices.HealthIssue, removeOrphans bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROBLEM\tPATH\tDETAIL\tFIX")
+	for _, issue := range issues {
+		fix := cli.RepairFix(issue, removeOrphans)
+		fixes := []string{string(fix)}
+		for _, other := range issue.Fixes {
+			if other != fix {
+				fixes = append(fixes, string(other))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Kind, issue.Path, issue.Detail, strings.Join(fixes, " | "))
+	}
+	return w.Flush()
+}
+
+// outputRepairResults writes the outcome for each issue.
+func outputRepairResults(out io.Writer, results []cli.RepairResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nPROBLEM\tPATH\tFIX\tRESULT")
+	for _, r := range results {
+		status := r.Status
+		if r.Reason != "" {
+			status = fmt.Sprintf("%s (%s)", r.Status, r.Reason)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Issue.Kind, r.Issue.Path, r.Fix, status)
+	}
+	return w.Flush()
+}
+
+// repairResultsError returns an error when at least one fix failed.
+func repairResultsError(results []cli.RepairResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Status == cli.RepairStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to fix %d problem(s)", failed)
+	}
+	return nil
+}
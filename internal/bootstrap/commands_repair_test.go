@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputHealthIssues(t *testing.T) {
+	issues := []appservices.HealthIssue{
+		{Kind: appservices.HealthBrokenGitdir, Path: "/wt/repo/moved", Detail: "moved by hand", Fixes: []appservices.HealthFix{appservices.HealthFixRepair}},
+		{Kind: appservices.HealthOrphanedDir, Path: "/wt/repo/orphan", Detail: "not in git worktree list", Fixes: []appservices.HealthFix{appservices.HealthFixAdopt, appservices.HealthFixRemove}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputHealthIssues(&buf, issues, false))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "PROBLEM")
+	assert.True(t, strings.HasSuffix(lines[1], "repair"))
+	assert.True(t, strings.HasSuffix(lines[2], "adopt | remove"))
+
+	buf.Reset()
+	require.NoError(t, outputHealthIssues(&buf, issues, true))
+	assert.Contains(t, buf.String(), "remove | adopt")
+}
+
+func TestRepairResultsError(t *testing.T) {
+	issue := appservices.HealthIssue{Kind: appservices.HealthPrunable, Path: "/wt/repo/gone"}
+	ok := []cli.RepairResult{{Issue: issue, Fix: appservices.HealthFixPrune, Status: cli.RepairStatusFixed}}
+	require.NoError(t, repairResultsError(ok))
+
+	failed := append(ok, cli.RepairResult{Issue: issue, Fix: appservices.HealthFixPrune, Status: cli.RepairStatusFailed, Reason: "boom"})
+	require.Error(t, repairResultsError(failed))
+
+	var buf bytes.Buffer
+	require.NoError(t, outputRepairResults(&buf, failed))
+	assert.Contains(t, buf.String(), "failed (boom)")
+}
@@ -0,0 +1,258 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+const defaultShellFunctionName = "wt"
+
+var (
+	shellFunctionNameRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	shellSafeArgRE      = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+,-]+$`)
+)
+
+// shellInitScripts hold the integration for each supported shell. __NAME__
+// is replaced with the function name and __CMD__ with the lazyworktree
+// command line, including the global flags given to shell-init.
+var shellInitScripts = map[string]string{
+	"bash": `# lazyworktree shell integration for bash.
+# Load it with: eval "$(lazyworktree shell-init bash)"
+
+__NAME__() {
+    local target tmp rc
+    if [[ $# -gt 0 ]]; then
+        target=$(command __CMD__ shell-init resolve -- "$1") || return
+        cd -- "$target" || return
+        return
+    fi
+
+    tmp=$(mktemp "${TMPDIR:-/tmp}/lazyworktree.selection.XXXXXX") || return
+    command __CMD__ --output-selection="$tmp"
+    rc=$?
+    if [[ $rc -eq 0 && -s "$tmp" ]]; then
+        target=$(<"$tmp")
+        [[ -n "$target" && -d "$target" ]] && cd -- "$target"
+    fi
+    rm -f -- "$tmp"
+    return $rc
+}
+
+___NAME___complete() {
+    [[ $COMP_CWORD -eq 1 ]] || return
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$(command __CMD__ shell-init names 2>/dev/null)" -- "${COMP_WORDS[COMP_CWORD]}"))
+}
+complete -o nospace -F ___NAME___complete __NAME__
+`,
+	"zsh": `# lazyworktree shell integration for zsh.
+# Load it with: eval "$(lazyworktree shell-init zsh)"
+
+__NAME__() {
+    local target tmp rc
+    if (( $# > 0 )); then
+        target=$(command __CMD__ shell-init resolve -- "$1") || return
+        cd -- "$target" || return
+        return
+    fi
+
+    tmp=$(mktemp "${TMPDIR:-/tmp}/lazyworktree.selection.XXXXXX") || return
+    command __CMD__ --output-selection="$tmp"
+    rc=$?
+    if (( rc == 0 )) && [[ -s "$tmp" ]]; then
+        target=$(<"$tmp")
+        [[ -n "$target" && -d "$target" ]] && cd -- "$target"
+    fi
+    rm -f -- "$tmp"
+    return $rc
+}
+
+___NAME___complete() {
+    (( CURRENT == 2 )) || return
+    local -a names
+    names=(${(f)"$(command __CMD__ shell-init names 2>/dev/null)"})
+    _describe 'worktree' names
+}
+(( $+functions[compdef] )) && compdef ___NAME___complete __NAME__
+`,
+	"fish": `# lazyworktree shell integration for fish.
+# Load it with: lazyworktree shell-init fish | source
+
+function __NAME__ --description 'Jump to a worktree with lazyworktree'
+    if test (count $argv) -gt 0
+        set -l target (command __CMD__ shell-init resolve -- $argv[1])
+        or return
+        cd $target
+        return
+    end
+
+    set -l tmp (mktemp -t lazyworktree.selection.XXXXXX)
+    or return
+    command __CMD__ --output-selection=$tmp
+    set -l rc $status
+    if test $rc -eq 0 -a -s $tmp
+        set -l target (cat $tmp)
+        if test -n "$target" -a -d "$target"
+            cd $target
+        end
+    end
+    rm -f -- $tmp
+    return $rc
+end
+
+complete -c __NAME__ -f -n 'test (count (commandline -opc)) -eq 1' -a '(command __CMD__ shell-init names 2>/dev/null)'
+`,
+}
+
+func shellInitCommand() *appiCli.Command {
+	resolve := nestedSubcommand("resolve", "Print the path of a worktree for the shell function", "<worktree|->", handleShellInitResolveAction)
+	resolve.Hidden = true
+	names := nestedSubcommand("names", "Print worktree names for shell completion", "", handleShellInitNamesAction)
+	names.Hidden = true
+
+	return &appiCli.Command{
+		Name:      "shell-init",
+		Usage:     "Print shell integration (a jump function with completion) for bash, zsh or fish",
+		ArgsUsage: "<bash|zsh|fish>",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			if err := handleShellInitAction(cmd); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return err
+			}
+			return nil
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.StringFlag{
+				Name:  "name",
+				Usage: "Name of the generated jump function",
+				Value: defaultShellFunctionName,
+			},
+		},
+		Commands: []*appiCli.Command{resolve, names},
+	}
+}
+
+func handleShellInitAction(cmd *appiCli.Command) error {
+	if cmd.NArg() != 1 {
+		return fmt.Errorf("expected a shell: bash, zsh or fish")
+	}
+	args, err := shellInitGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+	return writeShellInit(os.Stdout, cmd.Args().Get(0), cmd.String("name"), args)
+}
+
+// writeShellInit renders the integration script of shell.
+func writeShellInit(out io.Writer, shell, name string, globalArgs []string) error {
+	script, ok := shellInitScripts[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish)", shell)
+	}
+	if !shellFunctionNameRE.MatchString(name) {
+		return fmt.Errorf("invalid function name %q", name)
+	}
+
+	quote := posixShellQuote
+	if shell == "fish" {
+		quote = fishShellQuote
+	}
+	parts := []string{"lazyworktree"}
+	for _, arg := range globalArgs {
+		if !shellSafeArgRE.MatchString(arg) {
+			arg = quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+
+	replacer := strings.NewReplacer("__NAME__", name, "__CMD__", strings.Join(parts, " "))
+	_, err := io.WriteString(out, replacer.Replace(script))
+	return err
+}
+
+// shellInitGlobalArgs returns the global flags given to shell-init, so the
+// generated functions use the same config. Paths are made absolute because
+// the functions run from any directory.
+func shellInitGlobalArgs(cmd *appiCli.Command) ([]string, error) {
+	var args []string
+	for _, flag := range []string{"config-file", "worktree-dir"} {
+		value := cmd.String(flag)
+		if value == "" {
+			continue
+		}
+		abs, err := expandConfigFilePath(value)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--"+flag, abs)
+	}
+	for _, override := range cmd.StringSlice("config") {
+		args = append(args, "--config", override)
+	}
+	return args, nil
+}
+
+func posixShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func fishShellQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func handleShellInitResolveAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() != 1 {
+		return fmt.Errorf("expected a worktree name or -")
+	}
+	target, err := loadNoteTarget(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	arg := cmd.Args().Get(0)
+	if arg == "-" {
+		path, err := lastSelectedWorktree(target.cfg.WorktreeDir, target.repoKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, path)
+		return nil
+	}
+	if err := target.selectWorktree(arg); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, target.worktree.Path)
+	return nil
+}
+
+func handleShellInitNamesAction(ctx context.Context, cmd *appiCli.Command) error {
+	outputCompletionLines(listSubcommandWorktreeNamesFunc(ctx, cmd))
+	return nil
+}
+
+// lastSelectedWorktree returns the worktree the TUI last exited on for the
+// repository.
+func lastSelectedWorktree(worktreeDir, repoKey string) (string, error) {
+	// #nosec G304 -- path built from worktree_dir and the repository name
+	data, err := os.ReadFile(filepath.Join(worktreeDir, repoKey, models.LastSelectedFilename))
+	if err != nil {
+		return "", fmt.Errorf("no last selected worktree found")
+	}
+	path := strings.TrimSpace(string(data))
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("last selected worktree %s no longer exists", path)
+	}
+	return path, nil
+}
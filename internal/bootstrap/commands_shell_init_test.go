@@ -0,0 +1,56 @@
+package bootstrap
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteShellInit(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeShellInit(&buf, shell, "jt", []string{"--worktree-dir", "/srv/work trees"}))
+			script := buf.String()
+			assert.NotContains(t, script, "__NAME__")
+			assert.NotContains(t, script, "__CMD__")
+			assert.Contains(t, script, "jt")
+			assert.Contains(t, script, "lazyworktree --worktree-dir '/srv/work trees' shell-init resolve -- ")
+			assert.Contains(t, script, "--output-selection=")
+			assert.Contains(t, script, "shell-init names")
+		})
+	}
+
+	var buf bytes.Buffer
+	require.Error(t, writeShellInit(&buf, "tcsh", "wt", nil))
+	require.Error(t, writeShellInit(&buf, "bash", "wt; rm -rf /", nil))
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'it'\''s'`, posixShellQuote("it's"))
+	assert.Equal(t, `'it\'s \\ here'`, fishShellQuote(`it's \ here`))
+}
+
+func TestLastSelectedWorktree(t *testing.T) {
+	worktreeDir := t.TempDir()
+	_, err := lastSelectedWorktree(worktreeDir, "org/repo")
+	require.Error(t, err)
+
+	selected := filepath.Join(worktreeDir, "org", "repo", "feature")
+	require.NoError(t, os.MkdirAll(selected, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, "org", "repo", models.LastSelectedFilename), []byte(selected+"\n"), 0o600))
+
+	path, err := lastSelectedWorktree(worktreeDir, "org/repo")
+	require.NoError(t, err)
+	assert.Equal(t, selected, path)
+
+	require.NoError(t, os.Remove(selected))
+	_, err = lastSelectedWorktree(worktreeDir, "org/repo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no longer exists")
+}
@@ -0,0 +1,205 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// stashJSON describes a single stash in --json output.
+type stashJSON struct {
+	Ref     string `json:"ref"`
+	SHA     string `json:"sha"`
+	Branch  string `json:"branch"`
+	Message string `json:"message"`
+	Created int64  `json:"created"`
+}
+
+func stashCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "stash",
+		Usage: "List, apply and move stashes, which all worktrees share",
+		Commands: []*appiCli.Command{
+			nestedSubcommand("list", "List stashes with the branch they were created on", "", handleStashListAction, jsonFlag()),
+			nestedSubcommand("show", "Print the patch of a stash", "<stash>", handleStashShowAction),
+			nestedSubcommand("apply", "Apply a stash to a worktree (current worktree when omitted)", "<stash> [worktree]", func(ctx context.Context, cmd *appiCli.Command) error {
+				return handleStashApplyAction(ctx, cmd, false)
+			}),
+			nestedSubcommand("pop", "Apply a stash to a worktree (current worktree when omitted) and drop it", "<stash> [worktree]", func(ctx context.Context, cmd *appiCli.Command) error {
+				return handleStashApplyAction(ctx, cmd, true)
+			}),
+			nestedSubcommand("drop", "Delete a stash", "<stash>", handleStashDropAction),
+			nestedSubcommand("move", "Stash the changes of a worktree and apply them in another", "<worktree>", handleStashMoveAction,
+				&appiCli.StringFlag{
+					Name:  "from",
+					Usage: "Worktree whose changes are moved (default: current worktree)",
+				},
+				&appiCli.StringFlag{
+					Name:    "message",
+					Aliases: []string{"m"},
+					Usage:   "Message of the stash carrying the changes",
+				},
+			),
+		},
+	}
+}
+
+// loadStashContext loads config and the git service for a stash subcommand.
+func loadStashContext(cmd *appiCli.Command) (*git.Service, *config.AppConfig, error) {
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newCLIGitServiceFunc(cfg), cfg, nil
+}
+
+func handleStashListAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() > 0 {
+		return fmt.Errorf("too many arguments: expected none")
+	}
+	gitSvc, _, err := loadStashContext(cmd)
+	if err != nil {
+		return err
+	}
+	stashes := gitSvc.ListStashes(ctx)
+	if cmd.Bool("json") {
+		entries := make([]stashJSON, 0, len(stashes))
+		for _, stash := range stashes {
+			entries = append(entries, stashJSON{
+				Ref:     stash.Ref,
+				SHA:     stash.SHA,
+				Branch:  stash.Branch,
+				Message: stash.Message,
+				Created: stash.Created.Unix(),
+			})
+		}
+		return writeNoteJSON(os.Stdout, entries)
+	}
+	return outputStashList(os.Stdout, stashes)
+}
+
+// outputStashList writes one line per stash, most recent first.
+func outputStashList(out io.Writer, stashes []*models.StashEntry) error {
+	if len(stashes) == 0 {
+		fmt.Fprintln(os.Stderr, "No stashes.")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STASH\tBRANCH\tCREATED\tMESSAGE")
+	for _, stash := range stashes {
+		created := "-"
+		if !stash.Created.IsZero() {
+			created = stash.Created.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", stash.Ref, stash.Branch, created, stash.Message)
+	}
+	return w.Flush()
+}
+
+func handleStashShowAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() != 1 {
+		return fmt.Errorf("expected exactly one stash")
+	}
+	gitSvc, _, err := loadStashContext(cmd)
+	if err != nil {
+		return err
+	}
+	patch, err := cli.ShowStash(ctx, gitSvc, cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(os.Stdout, patch)
+	return nil
+}
+
+func handleStashApplyAction(ctx context.Context, cmd *appiCli.Command, pop bool) error {
+	if cmd.NArg() < 1 || cmd.NArg() > 2 {
+		return fmt.Errorf("expected <stash> [worktree]")
+	}
+	target, err := stashWorktreeArg(cmd.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	gitSvc, cfg, err := loadStashContext(cmd)
+	if err != nil {
+		return err
+	}
+	stash, wt, err := cli.ApplyStash(ctx, gitSvc, cfg, cmd.Args().Get(0), target, pop)
+	if err != nil {
+		return err
+	}
+	action := "Applied"
+	if pop {
+		action = "Popped"
+	}
+	fmt.Fprintf(os.Stderr, "%s %s in %s\n", action, stash.Ref, filepath.Base(wt.Path))
+	return nil
+}
+
+func handleStashDropAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() != 1 {
+		return fmt.Errorf("expected exactly one stash")
+	}
+	gitSvc, _, err := loadStashContext(cmd)
+	if err != nil {
+		return err
+	}
+	stash, err := cli.DropStash(ctx, gitSvc, cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Dropped %s (%s)\n", stash.Ref, shortStashSHA(stash.SHA))
+	return nil
+}
+
+func handleStashMoveAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() != 1 {
+		return fmt.Errorf("expected exactly one target worktree")
+	}
+	source, err := stashWorktreeArg(cmd.String("from"))
+	if err != nil {
+		return err
+	}
+	gitSvc, cfg, err := loadStashContext(cmd)
+	if err != nil {
+		return err
+	}
+	target, err := cli.TransferChanges(ctx, gitSvc, cfg, source, cmd.Args().Get(0), cmd.String("message"))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Moved changes to %s\n", target.Path)
+	return nil
+}
+
+// stashWorktreeArg returns arg, or the current directory when arg is empty.
+func stashWorktreeArg(arg string) (string, error) {
+	if arg != "" {
+		return arg, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current directory: %w", err)
+	}
+	return cwd, nil
+}
+
+func shortStashSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+func TestStashMoveCompletionSuggestsWorktreeBasenames(t *testing.T) {
+	oldList := listSubcommandWorktreeNamesFunc
+	t.Cleanup(func() {
+		listSubcommandWorktreeNamesFunc = oldList
+	})
+	listSubcommandWorktreeNamesFunc = func(context.Context, *urfavecli.Command) []string {
+		return []string{"feature-a"}
+	}
+
+	out := runSubcommandCompletion(t, stashCommand(), []string{"lazyworktree", "stash", "move", "--generate-shell-completion"})
+	assert.Contains(t, out, "feature-a")
+
+	out = runSubcommandCompletion(t, stashCommand(), []string{"lazyworktree", "stash", "drop", "--generate-shell-completion"})
+	assert.NotContains(t, out, "feature-a")
+}
+
+func TestStashRejectsWrongArguments(t *testing.T) {
+	app := &urfavecli.Command{
+		Name:     "lazyworktree",
+		Commands: []*urfavecli.Command{stashCommand()},
+	}
+	err := app.Run(context.Background(), []string{"lazyworktree", "stash", "apply", "a", "b", "c"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected <stash> [worktree]")
+
+	err = app.Run(context.Background(), []string{"lazyworktree", "stash", "move"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected exactly one target worktree")
+}
+
+func TestOutputStashList(t *testing.T) {
+	var buf bytes.Buffer
+	created := time.Date(2026, 3, 1, 10, 30, 0, 0, time.Local)
+	err := outputStashList(&buf, []*models.StashEntry{
+		{Ref: "stash@{0}", SHA: "abc123", Branch: "feature", Message: "wip login", Created: created},
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "STASH")
+	assert.Contains(t, out, "stash@{0}")
+	assert.Contains(t, out, "feature")
+	assert.Contains(t, out, "2026-03-01 10:30")
+	assert.Contains(t, out, "wip login")
+}
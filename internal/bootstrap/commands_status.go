@@ -0,0 +1,397 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/log"
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// statusSchemaVersion is bumped whenever a field is removed or changes meaning
+// in the status JSON document or the porcelain columns. New fields may be
+// added without a bump.
+const statusSchemaVersion = 1
+
+// statusJobs bounds the worktrees whose HEAD and CI checks are fetched at
+// once, so repositories with many worktrees stay within forge rate limits.
+const statusJobs = 4
+
+const (
+	ciStatusSuccess = "success"
+	ciStatusFailure = "failure"
+	ciStatusPending = "pending"
+	ciStatusNone    = "none"
+	prStateOpen     = "OPEN"
+)
+
+// statusGitService is the subset of git operations used by the status subcommand.
+type statusGitService interface {
+	GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, error)
+	FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error)
+	FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error)
+	FetchCIStatusByCommit(ctx context.Context, commitSHA, worktreePath string) ([]*models.CICheck, error)
+	GetHeadSHA(ctx context.Context, worktreePath string) string
+	ResolveRepoName(ctx context.Context) string
+}
+
+var _ statusGitService = (*git.Service)(nil)
+
+// statusDocument is the versioned JSON document emitted by `status --json`.
+type statusDocument struct {
+	Version   int                  `json:"version"`
+	Repo      string               `json:"repo"`
+	Network   bool                 `json:"network"`
+	Worktrees []statusWorktreeJSON `json:"worktrees"`
+}
+
+// statusWorktreeJSON describes a single worktree in the status document.
+type statusWorktreeJSON struct {
+	Path         string        `json:"path"`
+	Name         string        `json:"name"`
+	Branch       string        `json:"branch"`
+	Head         string        `json:"head"`
+	IsMain       bool          `json:"is_main"`
+	Dirty        bool          `json:"dirty"`
+	Staged       int           `json:"staged"`
+	Modified     int           `json:"modified"`
+	Untracked    int           `json:"untracked"`
+	Ahead        int           `json:"ahead"`
+	Behind       int           `json:"behind"`
+	Unpushed     int           `json:"unpushed"`
+	HasUpstream  bool          `json:"has_upstream"`
+	Upstream     string        `json:"upstream"`
+	LastActive   string        `json:"last_active"`
+	LastActiveTS int64         `json:"last_active_ts"`
+	PR           *statusPRJSON `json:"pr"`
+	CI           *statusCIJSON `json:"ci"`
+}
+
+// statusPRJSON describes the pull/merge request attached to a worktree branch.
+type statusPRJSON struct {
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Author     string `json:"author"`
+	BaseBranch string `json:"base_branch,omitempty"`
+	IsDraft    bool   `json:"is_draft"`
+}
+
+// statusCIJSON summarises CI checks for a worktree.
+type statusCIJSON struct {
+	Status string            `json:"status"`
+	Checks []statusCheckJSON `json:"checks"`
+}
+
+// statusCheckJSON describes a single CI check.
+type statusCheckJSON struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	Link       string `json:"link,omitempty"`
+}
+
+func statusCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "status",
+		Usage: "Show status, PR and CI state for every worktree",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleStatusAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "json",
+				Usage: "Output a versioned JSON document",
+			},
+			&appiCli.BoolFlag{
+				Name:  "porcelain",
+				Usage: "Output one tab-separated line per worktree (stable, suitable for scripting)",
+			},
+			&appiCli.BoolFlag{
+				Name:  "no-network",
+				Usage: "Skip PR/MR and CI lookups on the forge",
+			},
+		},
+	}
+}
+
+func validateStatusFlags(cmd *appiCli.Command) error {
+	if cmd.Bool("json") && cmd.Bool("porcelain") {
+		return fmt.Errorf("--json and --porcelain are mutually exclusive")
+	}
+	return nil
+}
+
+// handleStatusAction handles the status subcommand action.
+func handleStatusAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	if err := validateStatusFlags(cmd); err != nil {
+		return err
+	}
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	network := !cmd.Bool("no-network") && !cfg.DisablePR
+
+	doc, err := collectStatus(ctx, gitSvc, network)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.Bool("json"):
+		return outputStatusJSON(os.Stdout, doc)
+	case cmd.Bool("porcelain"):
+		return outputStatusPorcelain(os.Stdout, doc)
+	default:
+		return outputStatusTable(os.Stdout, doc)
+	}
+}
+
+// collectStatus gathers worktree status and, when network is true, PR and CI
+// information from the forge.
+func collectStatus(ctx context.Context, gitSvc statusGitService, network bool) (*statusDocument, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortWorktreesByPath(worktrees)
+
+	var prMap map[string]*models.PRInfo
+	if network {
+		prMap, err = gitSvc.FetchPRMap(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch PR data: %v\n", err)
+			prMap = nil
+		}
+	}
+
+	doc := &statusDocument{
+		Version:   statusSchemaVersion,
+		Repo:      gitSvc.ResolveRepoName(ctx),
+		Network:   network,
+		Worktrees: make([]statusWorktreeJSON, len(worktrees)),
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, statusJobs)
+	)
+	for i, wt := range worktrees {
+		entry := newStatusWorktreeJSON(wt)
+		if pr, ok := prMap[wt.Branch]; ok && pr != nil {
+			entry.PR = &statusPRJSON{
+				Number:     pr.Number,
+				State:      pr.State,
+				Title:      pr.Title,
+				URL:        pr.URL,
+				Author:     pr.Author,
+				BaseBranch: pr.BaseBranch,
+				IsDraft:    pr.IsDraft,
+			}
+		}
+		doc.Worktrees[i] = entry
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, wt *models.WorktreeInfo, pr *statusPRJSON) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			head := gitSvc.GetHeadSHA(ctx, wt.Path)
+			doc.Worktrees[i].Head = head
+			if !network {
+				return
+			}
+			doc.Worktrees[i].CI = fetchStatusCI(ctx, gitSvc, wt, pr, head)
+		}(i, wt, entry.PR)
+	}
+	wg.Wait()
+
+	return doc, nil
+}
+
+func newStatusWorktreeJSON(wt *models.WorktreeInfo) statusWorktreeJSON {
+	return statusWorktreeJSON{
+		Path:         wt.Path,
+		Name:         filepath.Base(wt.Path),
+		Branch:       wt.Branch,
+		IsMain:       wt.IsMain,
+		Dirty:        wt.Dirty,
+		Staged:       wt.Staged,
+		Modified:     wt.Modified,
+		Untracked:    wt.Untracked,
+		Ahead:        wt.Ahead,
+		Behind:       wt.Behind,
+		Unpushed:     wt.Unpushed,
+		HasUpstream:  wt.HasUpstream,
+		Upstream:     wt.UpstreamBranch,
+		LastActive:   wt.LastActive,
+		LastActiveTS: wt.LastActiveTS,
+	}
+}
+
+// fetchStatusCI mirrors the TUI: open PRs use the PR checks, everything else
+// falls back to the checks attached to the HEAD commit.
+func fetchStatusCI(ctx context.Context, gitSvc statusGitService, wt *models.WorktreeInfo, pr *statusPRJSON, head string) *statusCIJSON {
+	var (
+		checks []*models.CICheck
+		err    error
+	)
+	switch {
+	case pr != nil && pr.State == prStateOpen:
+		checks, err = gitSvc.FetchCIStatus(ctx, pr.Number, wt.Branch)
+	case head != "":
+		checks, err = gitSvc.FetchCIStatusByCommit(ctx, head, wt.Path)
+	}
+	if err != nil || len(checks) == 0 {
+		return nil
+	}
+
+	ci := &statusCIJSON{
+		Status: summariseCIChecks(checks),
+		Checks: make([]statusCheckJSON, 0, len(checks)),
+	}
+	for _, check := range checks {
+		ci.Checks = append(ci.Checks, statusCheckJSON{
+			Name:       check.Name,
+			Status:     check.Status,
+			Conclusion: check.Conclusion,
+			Link:       check.Link,
+		})
+	}
+	return ci
+}
+
+// summariseCIChecks reduces individual checks to a single status:
+// any failure wins, then any pending check, otherwise success.
+func summariseCIChecks(checks []*models.CICheck) string {
+	if len(checks) == 0 {
+		return ciStatusNone
+	}
+	hasPending := false
+	for _, check := range checks {
+		switch check.Conclusion {
+		case ciStatusFailure, "cancelled":
+			return ciStatusFailure
+		case ciStatusPending, "":
+			hasPending = true
+		}
+	}
+	if hasPending {
+		return ciStatusPending
+	}
+	return ciStatusSuccess
+}
+
+// outputStatusJSON writes the status document as indented JSON.
+func outputStatusJSON(w io.Writer, doc *statusDocument) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// outputStatusPorcelain writes one tab-separated line per worktree.
+// Columns: name, branch, kind (main|worktree), state (clean|dirty), staged,
+// modified, untracked, ahead, behind, unpushed, pr number (0 when none),
+// pr state, ci status, head, path. Empty values are written as "-".
+func outputStatusPorcelain(w io.Writer, doc *statusDocument) error {
+	for _, wt := range doc.Worktrees {
+		kind := "worktree"
+		if wt.IsMain {
+			kind = "main"
+		}
+		state := "clean"
+		if wt.Dirty {
+			state = "dirty"
+		}
+		prNumber := 0
+		prState := ""
+		if wt.PR != nil {
+			prNumber = wt.PR.Number
+			prState = wt.PR.State
+		}
+		ciStatus := ""
+		if wt.CI != nil {
+			ciStatus = wt.CI.Status
+		}
+		fields := []string{
+			porcelainValue(wt.Name),
+			porcelainValue(wt.Branch),
+			kind,
+			state,
+			fmt.Sprintf("%d", wt.Staged),
+			fmt.Sprintf("%d", wt.Modified),
+			fmt.Sprintf("%d", wt.Untracked),
+			fmt.Sprintf("%d", wt.Ahead),
+			fmt.Sprintf("%d", wt.Behind),
+			fmt.Sprintf("%d", wt.Unpushed),
+			fmt.Sprintf("%d", prNumber),
+			porcelainValue(prState),
+			porcelainValue(ciStatus),
+			porcelainValue(wt.Head),
+			porcelainValue(wt.Path),
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func porcelainValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, "\t", " ")
+}
+
+// outputStatusTable writes a human-readable status table.
+func outputStatusTable(out io.Writer, doc *statusDocument) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBRANCH\tSTATUS\tPR\tCI\tLAST ACTIVE")
+
+	for _, wt := range doc.Worktrees {
+		status := buildStatusString(&models.WorktreeInfo{
+			Dirty:       wt.Dirty,
+			Ahead:       wt.Ahead,
+			Behind:      wt.Behind,
+			Unpushed:    wt.Unpushed,
+			HasUpstream: wt.HasUpstream,
+		})
+		pr := "-"
+		if wt.PR != nil {
+			pr = fmt.Sprintf("#%d %s", wt.PR.Number, strings.ToLower(wt.PR.State))
+		}
+		ci := "-"
+		if wt.CI != nil {
+			ci = wt.CI.Status
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", wt.Name, wt.Branch, status, pr, ci, wt.LastActive)
+	}
+
+	return w.Flush()
+}
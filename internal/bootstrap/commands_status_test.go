@@ -0,0 +1,255 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+type fakeStatusGitService struct {
+	worktrees    []*models.WorktreeInfo
+	prMap        map[string]*models.PRInfo
+	prChecks     map[int][]*models.CICheck
+	commitChecks map[string][]*models.CICheck
+	heads        map[string]string
+	prMapFetched bool
+}
+
+func (f *fakeStatusGitService) GetWorktrees(context.Context) ([]*models.WorktreeInfo, error) {
+	return f.worktrees, nil
+}
+
+func (f *fakeStatusGitService) FetchPRMap(context.Context) (map[string]*models.PRInfo, error) {
+	f.prMapFetched = true
+	return f.prMap, nil
+}
+
+func (f *fakeStatusGitService) FetchCIStatus(_ context.Context, prNumber int, _ string) ([]*models.CICheck, error) {
+	return f.prChecks[prNumber], nil
+}
+
+func (f *fakeStatusGitService) FetchCIStatusByCommit(_ context.Context, sha, _ string) ([]*models.CICheck, error) {
+	return f.commitChecks[sha], nil
+}
+
+func (f *fakeStatusGitService) GetHeadSHA(_ context.Context, path string) string {
+	return f.heads[path]
+}
+
+func (f *fakeStatusGitService) ResolveRepoName(context.Context) string {
+	return "owner/repo"
+}
+
+func newFakeStatusGitService() *fakeStatusGitService {
+	return &fakeStatusGitService{
+		worktrees: []*models.WorktreeInfo{
+			{Path: "/wt/repo/feature", Branch: "feature", Dirty: true, Modified: 2, Ahead: 1, HasUpstream: true},
+			{Path: "/wt/main", Branch: "main", IsMain: true, HasUpstream: true},
+			{Path: "/wt/repo/local", Branch: "local", Unpushed: 3},
+		},
+		prMap: map[string]*models.PRInfo{
+			"feature": {Number: 42, State: "OPEN", Title: "Add feature", URL: "https://example.com/pr/42"},
+		},
+		prChecks: map[int][]*models.CICheck{
+			42: {
+				{Name: "lint", Status: "completed", Conclusion: "success"},
+				{Name: "test", Status: "completed", Conclusion: "failure"},
+			},
+		},
+		commitChecks: map[string][]*models.CICheck{
+			"aaa": {{Name: "build", Status: "completed", Conclusion: "success"}},
+		},
+		heads: map[string]string{
+			"/wt/main":         "aaa",
+			"/wt/repo/feature": "bbb",
+			"/wt/repo/local":   "ccc",
+		},
+	}
+}
+
+func TestCollectStatus(t *testing.T) {
+	svc := newFakeStatusGitService()
+
+	doc, err := collectStatus(context.Background(), svc, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, statusSchemaVersion, doc.Version)
+	assert.Equal(t, "owner/repo", doc.Repo)
+	assert.True(t, doc.Network)
+	require.Len(t, doc.Worktrees, 3)
+
+	// Sorted by path
+	assert.Equal(t, "/wt/main", doc.Worktrees[0].Path)
+	assert.Equal(t, "/wt/repo/feature", doc.Worktrees[1].Path)
+	assert.Equal(t, "/wt/repo/local", doc.Worktrees[2].Path)
+
+	main := doc.Worktrees[0]
+	assert.True(t, main.IsMain)
+	assert.Equal(t, "aaa", main.Head)
+	assert.Nil(t, main.PR)
+	require.NotNil(t, main.CI)
+	assert.Equal(t, "success", main.CI.Status)
+
+	feature := doc.Worktrees[1]
+	require.NotNil(t, feature.PR)
+	assert.Equal(t, 42, feature.PR.Number)
+	require.NotNil(t, feature.CI)
+	assert.Equal(t, "failure", feature.CI.Status)
+	assert.Len(t, feature.CI.Checks, 2)
+	assert.Equal(t, 2, feature.Modified)
+
+	local := doc.Worktrees[2]
+	assert.Nil(t, local.PR)
+	assert.Nil(t, local.CI)
+	assert.Equal(t, 3, local.Unpushed)
+}
+
+func TestCollectStatusNoNetwork(t *testing.T) {
+	svc := newFakeStatusGitService()
+
+	doc, err := collectStatus(context.Background(), svc, false)
+	require.NoError(t, err)
+
+	assert.False(t, svc.prMapFetched)
+	assert.False(t, doc.Network)
+	for _, wt := range doc.Worktrees {
+		assert.Nil(t, wt.PR)
+		assert.Nil(t, wt.CI)
+		assert.NotEmpty(t, wt.Head)
+	}
+}
+
+// peakStatusGitService records how many HEADs are resolved at once.
+type peakStatusGitService struct {
+	*fakeStatusGitService
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (p *peakStatusGitService) GetHeadSHA(ctx context.Context, path string) string {
+	p.mu.Lock()
+	p.running++
+	p.peak = max(p.peak, p.running)
+	p.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+	return p.fakeStatusGitService.GetHeadSHA(ctx, path)
+}
+
+func TestCollectStatusLimitsConcurrency(t *testing.T) {
+	fake := &fakeStatusGitService{}
+	for i := range 3 * statusJobs {
+		fake.worktrees = append(fake.worktrees, &models.WorktreeInfo{Path: fmt.Sprintf("/wt/repo/%02d", i)})
+	}
+	svc := &peakStatusGitService{fakeStatusGitService: fake}
+
+	doc, err := collectStatus(context.Background(), svc, true)
+	require.NoError(t, err)
+	assert.Len(t, doc.Worktrees, 3*statusJobs)
+	assert.LessOrEqual(t, svc.peak, statusJobs)
+}
+
+func TestSummariseCIChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []*models.CICheck
+		want   string
+	}{
+		{name: "no checks", want: "none"},
+		{name: "all success", checks: []*models.CICheck{{Conclusion: "success"}, {Conclusion: "skipped"}}, want: "success"},
+		{name: "pending", checks: []*models.CICheck{{Conclusion: "success"}, {Conclusion: "pending"}}, want: "pending"},
+		{name: "failure wins", checks: []*models.CICheck{{Conclusion: "pending"}, {Conclusion: "failure"}}, want: "failure"},
+		{name: "cancelled counts as failure", checks: []*models.CICheck{{Conclusion: "cancelled"}}, want: "failure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, summariseCIChecks(tt.checks))
+		})
+	}
+}
+
+func TestOutputStatusJSON(t *testing.T) {
+	doc, err := collectStatus(context.Background(), newFakeStatusGitService(), true)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, outputStatusJSON(&buf, doc))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.InDelta(t, float64(statusSchemaVersion), decoded["version"], 0)
+
+	worktrees, ok := decoded["worktrees"].([]any)
+	require.True(t, ok)
+	require.Len(t, worktrees, 3)
+	first, ok := worktrees[0].(map[string]any)
+	require.True(t, ok)
+	for _, key := range []string{"path", "name", "branch", "head", "is_main", "dirty", "ahead", "behind", "pr", "ci"} {
+		assert.Contains(t, first, key)
+	}
+}
+
+func TestOutputStatusPorcelain(t *testing.T) {
+	doc, err := collectStatus(context.Background(), newFakeStatusGitService(), true)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, outputStatusPorcelain(&buf, doc))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	main := strings.Split(lines[0], "\t")
+	require.Len(t, main, 15)
+	assert.Equal(t, []string{"main", "main", "main", "clean", "0", "0", "0", "0", "0", "0", "0", "-", "success", "aaa", "/wt/main"}, main)
+
+	feature := strings.Split(lines[1], "\t")
+	assert.Equal(t, "dirty", feature[3])
+	assert.Equal(t, "42", feature[10])
+	assert.Equal(t, "OPEN", feature[11])
+	assert.Equal(t, "failure", feature[12])
+}
+
+func TestOutputStatusTable(t *testing.T) {
+	doc, err := collectStatus(context.Background(), newFakeStatusGitService(), true)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, outputStatusTable(&buf, doc))
+
+	output := buf.String()
+	assert.Contains(t, output, "NAME")
+	assert.Contains(t, output, "CI")
+	assert.Contains(t, output, "#42 open")
+	assert.Contains(t, output, "failure")
+}
+
+func TestHandleStatusValidation(t *testing.T) {
+	cmd := statusCommand()
+	app := &urfavecli.Command{
+		Name:     "lazyworktree",
+		Commands: []*urfavecli.Command{cmd},
+	}
+	cmd.Action = func(_ context.Context, c *urfavecli.Command) error {
+		return validateStatusFlags(c)
+	}
+
+	err := app.Run(context.Background(), []string{"lazyworktree", "status", "--json", "--porcelain"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mutually exclusive")
+
+	require.NoError(t, app.Run(context.Background(), []string{"lazyworktree", "status", "--json", "--no-network"}))
+}
@@ -0,0 +1,211 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/log"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+func absorbCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "absorb",
+		Usage:     "Merge a worktree's branch into the main worktree",
+		ArgsUsage: "[worktree-name-or-path]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleAbsorbAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.StringFlag{
+				Name:  "method",
+				Usage: "How to integrate the branch: rebase or merge (defaults to merge_method)",
+			},
+			&appiCli.BoolFlag{
+				Name:  "delete",
+				Usage: "Delete the worktree (and its branch) after a successful absorb",
+			},
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+		},
+	}
+}
+
+func syncCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "sync",
+		Usage:     "Pull and push worktrees against their upstream",
+		ArgsUsage: "[worktree-name-or-path]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleSyncAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "all",
+				Usage: "Synchronise every worktree; dirty, detached or untracked ones are skipped",
+			},
+			&appiCli.BoolFlag{
+				Name:  "from-base",
+				Usage: "Update the branch from its PR base branch instead of pull + push",
+			},
+			&appiCli.BoolFlag{
+				Name:  "local",
+				Usage: "Update from the base branch with git fetch and rebase/merge instead of gh (implies --from-base)",
+			},
+			&appiCli.StringFlag{
+				Name:  "method",
+				Usage: "Pull/update method: rebase or merge (defaults to merge_method)",
+			},
+		},
+	}
+}
+
+// resolveTargetArg returns the positional worktree argument, or the current
+// directory when none is given so FindWorktreeByPathOrName can match it.
+func resolveTargetArg(cmd *appiCli.Command) (string, error) {
+	if cmd.NArg() > 1 {
+		return "", fmt.Errorf("too many arguments: expected a single worktree name or path")
+	}
+	if cmd.NArg() == 1 {
+		return cmd.Args().Get(0), nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current directory: %w", err)
+	}
+	return cwd, nil
+}
+
+func validateMethodFlag(cmd *appiCli.Command) error {
+	if method := cmd.String("method"); method != "" {
+		return cli.ValidateMergeMethod(method)
+	}
+	return nil
+}
+
+// handleAbsorbAction handles the absorb subcommand action.
+func handleAbsorbAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	if err := validateMethodFlag(cmd); err != nil {
+		return err
+	}
+	target, err := resolveTargetArg(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	method := cli.ResolveMergeMethod(cfg, cmd.String("method"))
+	result, err := cli.AbsorbWorktree(ctx, gitSvc, cfg, target, method, cmd.Bool("delete"), cmd.Bool("silent"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+
+	results := []cli.OperationResult{result}
+	if err := outputOperationResults(os.Stdout, results); err != nil {
+		return err
+	}
+	return operationResultsError("absorb", results)
+}
+
+func validateSyncFlags(cmd *appiCli.Command) error {
+	if err := validateMethodFlag(cmd); err != nil {
+		return err
+	}
+	return validateIncompatibility("--all", cmd.Bool("all"), "a worktree argument", cmd.NArg() > 0)
+}
+
+// handleSyncAction handles the sync subcommand action.
+func handleSyncAction(ctx context.Context, cmd *appiCli.Command) error {
+	defer func() {
+		_ = log.Close()
+	}()
+	if err := validateSyncFlags(cmd); err != nil {
+		return err
+	}
+	all := cmd.Bool("all")
+	target := ""
+	if !all {
+		var err error
+		if target, err = resolveTargetArg(cmd); err != nil {
+			return err
+		}
+	}
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	worktrees, err := cli.ResolveSyncTargets(ctx, gitSvc, cfg, target, all)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return err
+	}
+	sortWorktreesByPath(worktrees)
+
+	results := cli.SyncWorktrees(ctx, gitSvc, worktrees, cli.SyncOptions{
+		MergeMethod: cli.ResolveMergeMethod(cfg, cmd.String("method")),
+		FromBase:    cmd.Bool("from-base"),
+		Local:       cmd.Bool("local"),
+		SkipInvalid: all,
+	})
+	if err := outputOperationResults(os.Stdout, results); err != nil {
+		return err
+	}
+	return operationResultsError("synchronise", results)
+}
+
+// outputOperationResults writes a per-worktree result table.
+func outputOperationResults(out io.Writer, results []cli.OperationResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBRANCH\tRESULT\tDETAILS")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(r.Worktree.Path), r.Worktree.Branch, r.Status, r.Message)
+	}
+	return w.Flush()
+}
+
+// operationResultsError returns an error when at least one worktree failed.
+func operationResultsError(action string, results []cli.OperationResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Status == cli.OperationStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d worktree(s)", action, failed)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+)
+
+func TestOutputOperationResults(t *testing.T) {
+	results := []cli.OperationResult{
+		{Worktree: &models.WorktreeInfo{Path: "/wt/repo/feature", Branch: "feature"}, Status: cli.OperationStatusOK, Message: "synchronised"},
+		{Worktree: &models.WorktreeInfo{Path: "/wt/repo/dirty", Branch: "dirty"}, Status: cli.OperationStatusSkipped, Message: "local changes"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputOperationResults(&buf, results))
+	assert.Contains(t, buf.String(), "RESULT")
+	assert.Contains(t, buf.String(), "synchronised")
+	assert.Contains(t, buf.String(), "local changes")
+	require.NoError(t, operationResultsError("synchronise", results))
+
+	results = append(results, cli.OperationResult{Worktree: &models.WorktreeInfo{Path: "/wt/repo/bad"}, Status: cli.OperationStatusFailed})
+	err := operationResultsError("synchronise", results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to synchronise 1 worktree(s)")
+}
+
+func TestHandleSyncValidation(t *testing.T) {
+	cmd := syncCommand()
+	app := &urfavecli.Command{
+		Name:     "lazyworktree",
+		Commands: []*urfavecli.Command{cmd},
+	}
+	cmd.Action = func(_ context.Context, c *urfavecli.Command) error {
+		return validateSyncFlags(c)
+	}
+
+	err := app.Run(context.Background(), []string{"lazyworktree", "sync", "--all", "feature"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used with")
+
+	err = app.Run(context.Background(), []string{"lazyworktree", "sync", "--method", "squash"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid merge method")
+
+	require.NoError(t, app.Run(context.Background(), []string{"lazyworktree", "sync", "--all", "--method", "merge"}))
+}
+
+func TestAbsorbCompletionSuggestsWorktreeBasenames(t *testing.T) {
+	oldList := listSubcommandWorktreeNamesFunc
+	t.Cleanup(func() {
+		listSubcommandWorktreeNamesFunc = oldList
+	})
+	listSubcommandWorktreeNamesFunc = func(context.Context, *urfavecli.Command) []string {
+		return []string{"feature-a", "feature-b"}
+	}
+
+	out := runSubcommandCompletion(t, absorbCommand(), []string{"lazyworktree", "absorb", "--generate-shell-completion"})
+
+	assert.Contains(t, out, "feature-a")
+	assert.Contains(t, out, "feature-b")
+}
@@ -0,0 +1,250 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// taskJSON describes a single task in --json output.
+type taskJSON struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+	Line int    `json:"line"`
+}
+
+// taskGroupJSON describes the tasks of one worktree in --json output.
+type taskGroupJSON struct {
+	Worktree string     `json:"worktree"`
+	Path     string     `json:"path"`
+	Branch   string     `json:"branch"`
+	Open     int        `json:"open"`
+	Done     int        `json:"done"`
+	Tasks    []taskJSON `json:"tasks"`
+}
+
+func tasksCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "tasks",
+		Usage: "List and update tasks found in worktree notes",
+		Commands: []*appiCli.Command{
+			nestedSubcommand("list", "List tasks grouped by worktree", "[worktree]", handleTasksListAction,
+				jsonFlag(),
+				&appiCli.BoolFlag{
+					Name:  "open-only",
+					Usage: "Only show tasks that are not done",
+				},
+			),
+			nestedSubcommand("add", "Add a task to the note of a worktree (current worktree when omitted)", "[worktree] <text>", handleTasksAddAction),
+			nestedSubcommand("done", "Mark tasks as done", "<id>...", func(ctx context.Context, cmd *appiCli.Command) error {
+				return handleTasksSetCheckedAction(ctx, cmd, true)
+			}),
+			nestedSubcommand("undo", "Mark tasks as not done", "<id>...", func(ctx context.Context, cmd *appiCli.Command) error {
+				return handleTasksSetCheckedAction(ctx, cmd, false)
+			}),
+		},
+	}
+}
+
+func handleTasksListAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() > 1 {
+		return fmt.Errorf("too many arguments: expected [worktree]")
+	}
+	target, err := loadNoteTarget(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	worktrees := target.worktrees
+	if cmd.NArg() == 1 {
+		if err := target.selectWorktree(cmd.Args().Get(0)); err != nil {
+			return err
+		}
+		worktrees = []*models.WorktreeInfo{target.worktree}
+	}
+
+	groups, err := collectTaskGroups(target, worktrees, cmd.Bool("open-only"))
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		return writeNoteJSON(os.Stdout, groups)
+	}
+	return outputTaskGroups(os.Stdout, groups)
+}
+
+func handleTasksAddAction(ctx context.Context, cmd *appiCli.Command) error {
+	var worktreeArg, text string
+	switch cmd.NArg() {
+	case 1:
+		text = cmd.Args().Get(0)
+	case 2:
+		worktreeArg, text = cmd.Args().Get(0), cmd.Args().Get(1)
+	default:
+		return fmt.Errorf("expected [worktree] <text>")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "\n") {
+		return fmt.Errorf("task text must be a single non-empty line")
+	}
+
+	target, err := loadNoteTarget(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := target.selectWorktree(worktreeArg); err != nil {
+		return err
+	}
+	note, _, err := target.load()
+	if err != nil {
+		return err
+	}
+	updated := services.AppendWorktreeTask(note.Note, text)
+	if err := target.save(updated); err != nil {
+		return err
+	}
+
+	tasks := services.ExtractWorktreeTasks(target.worktree.Path, updated)
+	fmt.Fprintln(os.Stdout, tasks[len(tasks)-1].ID)
+	return nil
+}
+
+func handleTasksSetCheckedAction(ctx context.Context, cmd *appiCli.Command, checked bool) error {
+	if cmd.NArg() == 0 {
+		return fmt.Errorf("at least one task ID is required")
+	}
+	target, err := loadNoteTarget(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	return setTasksChecked(target, cmd.Args().Slice(), checked)
+}
+
+// setTasksChecked marks the tasks with the given IDs as done or open, saving
+// each affected note once. Unknown IDs are reported after valid ones are saved.
+func setTasksChecked(target *noteTarget, ids []string, checked bool) error {
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	for _, wt := range target.worktrees {
+		note, ok, err := target.loadPath(wt.Path)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		text := note.Note
+		changed := false
+		for _, task := range services.ExtractWorktreeTasks(wt.Path, note.Note) {
+			if !pending[task.ID] {
+				continue
+			}
+			delete(pending, task.ID)
+			if next, ok := services.SetWorktreeTaskChecked(text, task, checked); ok {
+				text = next
+				changed = true
+			}
+		}
+		if changed {
+			if err := target.savePath(wt.Path, text); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		missing := make([]string, 0, len(pending))
+		for _, id := range ids {
+			if pending[id] {
+				missing = append(missing, id)
+			}
+		}
+		return fmt.Errorf("unknown task ID(s): %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// collectTaskGroups parses the notes of worktrees into per-worktree task
+// groups, skipping worktrees without tasks.
+func collectTaskGroups(target *noteTarget, worktrees []*models.WorktreeInfo, openOnly bool) ([]taskGroupJSON, error) {
+	sorted := append([]*models.WorktreeInfo(nil), worktrees...)
+	sortWorktreesByPath(sorted)
+
+	groups := make([]taskGroupJSON, 0, len(sorted))
+	for _, wt := range sorted {
+		note, ok, err := target.loadPath(wt.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		group := taskGroupJSON{
+			Worktree: taskWorktreeName(wt),
+			Path:     wt.Path,
+			Branch:   wt.Branch,
+			Tasks:    []taskJSON{},
+		}
+		for _, task := range services.ExtractWorktreeTasks(wt.Path, note.Note) {
+			if task.Checked {
+				group.Done++
+				if openOnly {
+					continue
+				}
+			} else {
+				group.Open++
+			}
+			group.Tasks = append(group.Tasks, taskJSON{
+				ID:   task.ID,
+				Text: task.Text,
+				Done: task.Checked,
+				Line: task.LineIndex + 1,
+			})
+		}
+		if len(group.Tasks) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func taskWorktreeName(wt *models.WorktreeInfo) string {
+	if wt.IsMain {
+		return "main"
+	}
+	return filepath.Base(wt.Path)
+}
+
+// outputTaskGroups writes a heading per worktree followed by its tasks.
+func outputTaskGroups(out io.Writer, groups []taskGroupJSON) error {
+	if len(groups) == 0 {
+		fmt.Fprintln(os.Stderr, "No tasks.")
+		return nil
+	}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "%s (%s) %d open, %d done\n", group.Worktree, group.Branch, group.Open, group.Done)
+		for _, task := range group.Tasks {
+			mark := " "
+			if task.Done {
+				mark = "x"
+			}
+			fmt.Fprintf(out, "  [%s] %s  %s\n", mark, task.ID, task.Text)
+		}
+	}
+	return nil
+}
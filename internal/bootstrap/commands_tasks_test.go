@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTaskTarget(t *testing.T) (*noteTarget, *models.WorktreeInfo, *models.WorktreeInfo) {
+	t.Helper()
+	worktreeDir := t.TempDir()
+	main := &models.WorktreeInfo{Path: filepath.Join(worktreeDir, "main"), Branch: "main", IsMain: true}
+	feature := &models.WorktreeInfo{Path: filepath.Join(worktreeDir, "repo", "feature"), Branch: "feature"}
+	target := &noteTarget{
+		cfg:       &config.AppConfig{WorktreeDir: worktreeDir},
+		repoKey:   "repo",
+		worktrees: []*models.WorktreeInfo{feature, main},
+	}
+	require.NoError(t, target.savePath(feature.Path, "## Plan\n- [ ] Write tests\nDONE: Set up CI"))
+	require.NoError(t, target.savePath(main.Path, "just prose"))
+	return target, main, feature
+}
+
+func TestCollectTaskGroups(t *testing.T) {
+	target, _, feature := newTestTaskTarget(t)
+
+	groups, err := collectTaskGroups(target, target.worktrees, false)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "feature", groups[0].Worktree)
+	assert.Equal(t, 1, groups[0].Open)
+	assert.Equal(t, 1, groups[0].Done)
+	require.Len(t, groups[0].Tasks, 2)
+	assert.Equal(t, services.WorktreeTaskID(feature.Path, "Write tests", 1), groups[0].Tasks[0].ID)
+	assert.Equal(t, 2, groups[0].Tasks[0].Line)
+
+	groups, err = collectTaskGroups(target, target.worktrees, true)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Len(t, groups[0].Tasks, 1)
+	assert.Equal(t, "Write tests", groups[0].Tasks[0].Text)
+	assert.Equal(t, 1, groups[0].Done)
+
+	var buf bytes.Buffer
+	require.NoError(t, outputTaskGroups(&buf, groups))
+	assert.Contains(t, buf.String(), "feature (feature) 1 open, 1 done")
+	assert.Contains(t, buf.String(), "[ ] "+groups[0].Tasks[0].ID+"  Write tests")
+}
+
+func TestSetTasksChecked(t *testing.T) {
+	target, _, feature := newTestTaskTarget(t)
+	writeID := services.WorktreeTaskID(feature.Path, "Write tests", 1)
+	ciID := services.WorktreeTaskID(feature.Path, "Set up CI", 1)
+
+	require.NoError(t, setTasksChecked(target, []string{writeID}, true))
+	note, _, err := target.loadPath(feature.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "## Plan\n- [x] Write tests\nDONE: Set up CI", note.Note)
+
+	require.NoError(t, setTasksChecked(target, []string{writeID, ciID}, false))
+	note, _, err = target.loadPath(feature.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "## Plan\n- [ ] Write tests\nTODO: Set up CI", note.Note)
+
+	err = setTasksChecked(target, []string{"nope", ciID}, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown task ID(s): nope")
+	note, _, err = target.loadPath(feature.Path)
+	require.NoError(t, err)
+	assert.Contains(t, note.Note, "DONE: Set up CI")
+}
@@ -0,0 +1,282 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/chmouel/lazyworktree/internal/security"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// Trust states shown by trust list.
+const (
+	trustStateTrusted = "trusted"
+	trustStateChanged = "changed"
+	trustStateMissing = "missing"
+)
+
+// trustEntryJSON is a trust database entry with the state of the file on disk.
+type trustEntryJSON struct {
+	Path  string `json:"path"`
+	Hash  string `json:"hash"`
+	State string `json:"state"`
+}
+
+func trustCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "trust",
+		Usage: "Inspect and manage trusted .wt files",
+		Commands: []*appiCli.Command{
+			nestedSubcommand("list", "List trusted files and whether they changed since", "", handleTrustListAction, jsonFlag()),
+			nestedSubcommand("add", "Trust the current content of .wt files", "[path...]", handleTrustAddAction),
+			nestedSubcommand("revoke", "Remove files from the trust database", "<path...>", handleTrustRevokeAction),
+			nestedSubcommand("prune", "Remove entries for files that no longer exist", "", handleTrustPruneAction),
+			nestedSubcommand("diff", "Show what changed in a .wt file since it was trusted", "[path]", handleTrustDiffAction),
+		},
+	}
+}
+
+func handleTrustListAction(_ context.Context, cmd *appiCli.Command) error {
+	tm := security.NewTrustManager()
+	if err := tm.LoadError(); err != nil {
+		return fmt.Errorf("%s: %w", tm.DBPath(), err)
+	}
+
+	entries := trustEntries(tm)
+	if cmd.Bool("json") {
+		return writeNoteJSON(os.Stdout, entries)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "No trusted files.")
+		return nil
+	}
+	return outputTrustEntries(os.Stdout, entries)
+}
+
+func handleTrustAddAction(ctx context.Context, cmd *appiCli.Command) error {
+	paths, err := trustPathArgs(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	tm := security.NewTrustManager()
+	for _, path := range paths {
+		if err := tm.TrustFile(path); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "Trusted %s\n", path)
+	}
+	return nil
+}
+
+func handleTrustRevokeAction(_ context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() == 0 {
+		return fmt.Errorf("expected at least one path")
+	}
+	tm := security.NewTrustManager()
+	for _, arg := range cmd.Args().Slice() {
+		path, err := resolveTrustPath(arg)
+		if err != nil {
+			return err
+		}
+		if err := tm.RevokeFile(path); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "Revoked %s\n", path)
+	}
+	return nil
+}
+
+func handleTrustPruneAction(_ context.Context, _ *appiCli.Command) error {
+	tm := security.NewTrustManager()
+	removed, err := tm.Prune()
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		fmt.Fprintln(os.Stderr, "Nothing to prune.")
+		return nil
+	}
+	for _, path := range removed {
+		fmt.Fprintf(os.Stdout, "Removed %s\n", path)
+	}
+	return nil
+}
+
+func handleTrustDiffAction(ctx context.Context, cmd *appiCli.Command) error {
+	if cmd.NArg() > 1 {
+		return fmt.Errorf("expected at most one path")
+	}
+	paths, err := trustPathArgs(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	return writeTrustDiff(os.Stdout, security.NewTrustManager(), paths[0])
+}
+
+// writeTrustDiff prints a line diff between the trusted and the current
+// content of path.
+func writeTrustDiff(out io.Writer, tm *security.TrustManager, path string) error {
+	hash, ok := tm.TrustedHash(path)
+	if !ok {
+		return fmt.Errorf("file is not trusted: %s", path)
+	}
+	// #nosec G304 -- path is a .wt file chosen by the user
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if tm.CheckTrust(path) == security.TrustStatusTrusted {
+		fmt.Fprintf(os.Stderr, "%s is unchanged since it was trusted.\n", path)
+		return nil
+	}
+	trusted, err := tm.TrustedContent(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s changed since it was trusted, but no copy of the trusted version was kept (trusted before snapshots were recorded)", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "--- %s (trusted %s)\n+++ %s (current)\n", path, shortTrustHash(hash), path)
+	for _, line := range diffLines(splitTrustLines(string(trusted)), splitTrustLines(string(current))) {
+		fmt.Fprintln(out, line)
+	}
+	return nil
+}
+
+// trustPathArgs resolves the path arguments, defaulting to the .wt file of
+// the current repository.
+func trustPathArgs(ctx context.Context, cmd *appiCli.Command) ([]string, error) {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		path, err := currentRepoTrustPath(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}
+
+	paths := make([]string, 0, len(args))
+	for _, arg := range args {
+		path, err := resolveTrustPath(arg)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// resolveTrustPath returns the absolute path of a .wt file, accepting the
+// repository directory that contains it.
+func resolveTrustPath(arg string) (string, error) {
+	path, err := expandConfigFilePath(arg)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, ".wt")
+	}
+	return path, nil
+}
+
+func currentRepoTrustPath(ctx context.Context, cmd *appiCli.Command) (string, error) {
+	cfg, err := loadCLIConfigFunc(cmd.String("config-file"), cmd.String("worktree-dir"), cmd.StringSlice("config"))
+	if err != nil {
+		return "", err
+	}
+	gitSvc := newCLIGitServiceFunc(cfg)
+	if gitSvc.RunGit(ctx, []string{"git", "rev-parse", "--is-inside-work-tree"}, "", []int{0}, true, true) != "true" {
+		return "", fmt.Errorf("not inside a git repository; pass the path of a .wt file")
+	}
+	return filepath.Join(gitSvc.GetMainWorktreePath(ctx), ".wt"), nil
+}
+
+func trustEntries(tm *security.TrustManager) []trustEntryJSON {
+	stored := tm.Entries()
+	entries := make([]trustEntryJSON, 0, len(stored))
+	for _, entry := range stored {
+		state := trustStateTrusted
+		switch tm.CheckTrust(entry.Path) {
+		case security.TrustStatusNotFound:
+			state = trustStateMissing
+		case security.TrustStatusUntrusted:
+			state = trustStateChanged
+		}
+		entries = append(entries, trustEntryJSON{Path: entry.Path, Hash: entry.Hash, State: state})
+	}
+	return entries
+}
+
+func outputTrustEntries(out io.Writer, entries []trustEntryJSON) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATE\tHASH\tPATH")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.State, shortTrustHash(entry.Hash), entry.Path)
+	}
+	return w.Flush()
+}
+
+func shortTrustHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+func splitTrustLines(content string) []string {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+// diffLines returns the lines of a and b prefixed with " ", "-" or "+",
+// based on their longest common subsequence. .wt files are small, so the
+// quadratic table is fine.
+func diffLines(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+"+b[j])
+	}
+	return lines
+}
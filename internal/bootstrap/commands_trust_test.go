@@ -0,0 +1,67 @@
+package bootstrap
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLines(t *testing.T) {
+	assert.Equal(t,
+		[]string{" a", "-b", "+B", " c", "+d"},
+		diffLines([]string{"a", "b", "c"}, []string{"a", "B", "c", "d"}),
+	)
+	assert.Equal(t, []string{"+x"}, diffLines(nil, splitTrustLines("x\n")))
+	assert.Empty(t, diffLines(nil, splitTrustLines("")))
+}
+
+func TestResolveTrustPath(t *testing.T) {
+	dir := t.TempDir()
+	path, err := resolveTrustPath(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".wt"), path)
+
+	file := filepath.Join(dir, "custom.wt")
+	path, err = resolveTrustPath(file)
+	require.NoError(t, err)
+	assert.Equal(t, file, path)
+}
+
+func TestTrustEntriesAndDiff(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dir := t.TempDir()
+	changed := filepath.Join(dir, "changed.wt")
+	missing := filepath.Join(dir, "missing.wt")
+	require.NoError(t, os.WriteFile(changed, []byte("init_commands:\n  - make\n"), 0o600))
+	require.NoError(t, os.WriteFile(missing, []byte("x"), 0o600))
+
+	tm := security.NewTrustManager()
+	require.NoError(t, tm.TrustFile(changed))
+	require.NoError(t, tm.TrustFile(missing))
+	require.NoError(t, os.Remove(missing))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTrustDiff(&buf, tm, changed))
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, os.WriteFile(changed, []byte("init_commands:\n  - make test\n"), 0o600))
+	entries := trustEntries(tm)
+	require.Len(t, entries, 2)
+	assert.Equal(t, trustStateChanged, entries[0].State)
+	assert.Equal(t, trustStateMissing, entries[1].State)
+
+	require.NoError(t, writeTrustDiff(&buf, tm, changed))
+	hash, _ := tm.TrustedHash(changed)
+	assert.Equal(t,
+		"--- "+changed+" (trusted "+hash[:12]+")\n+++ "+changed+" (current)\n init_commands:\n-  - make\n+  - make test\n",
+		buf.String())
+
+	buf.Reset()
+	require.NoError(t, outputTrustEntries(&buf, entries[1:]))
+	assert.Equal(t, "STATE    HASH          PATH\nmissing  "+shortTrustHash(entries[1].Hash)+"  "+missing+"\n", buf.String())
+}
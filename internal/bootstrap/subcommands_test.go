@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/chmouel/lazyworktree/internal/app/services"
 	"github.com/chmouel/lazyworktree/internal/config"
 	"github.com/chmouel/lazyworktree/internal/git"
 	"github.com/chmouel/lazyworktree/internal/models"
@@ -93,6 +94,23 @@ func TestHandleCreateValidation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "--with-change cannot be used with --from-pr",
 		},
+		{
+			name:        "sparse profile with branch (valid)",
+			args:        []string{"lazyworktree", "create", "--from-branch", "main", "--sparse", "web", "my-feature"},
+			expectError: false,
+		},
+		{
+			name:        "sparse profile with with-change (invalid)",
+			args:        []string{"lazyworktree", "create", "--sparse", "web", "--with-change"},
+			expectError: true,
+			errorMsg:    "--sparse cannot be used with --with-change",
+		},
+		{
+			name:        "sparse profile with from-pr (invalid)",
+			args:        []string{"lazyworktree", "create", "--from-pr", "123", "--sparse", "web"},
+			expectError: true,
+			errorMsg:    "--sparse cannot be used with --from-pr",
+		},
 		{
 			name:        "generate flag (valid)",
 			args:        []string{"lazyworktree", "create", "--generate"},
@@ -439,7 +457,7 @@ func TestDeleteCompletionWithPartialFlagFiltersFlags(t *testing.T) {
 	assert.NotContains(t, out, "feature-a")
 }
 
-func TestUniqueSortedWorktreeBasenames(t *testing.T) {
+func TestUniqueSortedWorktreeNames(t *testing.T) {
 	worktrees := []*models.WorktreeInfo{
 		nil,
 		{Path: "/tmp/main", IsMain: true},
@@ -449,7 +467,15 @@ func TestUniqueSortedWorktreeBasenames(t *testing.T) {
 		{Path: ""},
 	}
 
-	assert.Equal(t, []string{"alpha", "zeta"}, uniqueSortedWorktreeBasenames(worktrees))
+	assert.Equal(t, []string{"alpha", "zeta"}, uniqueSortedWorktreeNames(worktrees, services.WorktreeLayout{WorktreeDir: "/wt", RepoKey: "repo"}))
+
+	layout := services.WorktreeLayout{Template: "{repo_parent}/{repo}.worktrees/{branch}", MainPath: "/src/repo"}
+	worktrees = []*models.WorktreeInfo{
+		{Path: "/src/repo", IsMain: true},
+		{Path: "/src/repo.worktrees/feature/login"},
+		{Path: "/elsewhere/hotfix"},
+	}
+	assert.Equal(t, []string{"feature/login", "hotfix"}, uniqueSortedWorktreeNames(worktrees, layout))
 }
 
 func TestHandleListValidation(t *testing.T) {
@@ -541,7 +567,7 @@ func TestHandleCreateOutputSelection(t *testing.T) {
 	newCLIGitServiceFunc = func(*config.AppConfig) *git.Service {
 		return &git.Service{}
 	}
-	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _ string, _, _ bool) (string, error) {
+	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _, _ string, _, _ bool) (string, error) {
 		return expectedPath, nil
 	}
 	createFromPRFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _ int, _, _ bool) (string, error) {
@@ -632,7 +658,7 @@ func TestHandleCreateOutputSelectionFailureLeavesFile(t *testing.T) {
 	newCLIGitServiceFunc = func(*config.AppConfig) *git.Service {
 		return &git.Service{}
 	}
-	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _ string, _, _ bool) (string, error) {
+	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _, _ string, _, _ bool) (string, error) {
 		return "", os.ErrInvalid
 	}
 	createFromPRFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _ int, _, _ bool) (string, error) {
@@ -699,7 +725,7 @@ func TestHandleCreateExecRunsInCreatedWorktree(t *testing.T) {
 	newCLIGitServiceFunc = func(*config.AppConfig) *git.Service {
 		return &git.Service{}
 	}
-	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _ string, _, _ bool) (string, error) {
+	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _, _ string, _, _ bool) (string, error) {
 		if err := os.MkdirAll(expectedPath, 0o750); err != nil {
 			return "", err
 		}
@@ -774,7 +800,7 @@ func TestHandleCreateExecRunsInCurrentDirWithNoWorkspace(t *testing.T) {
 	newCLIGitServiceFunc = func(*config.AppConfig) *git.Service {
 		return &git.Service{}
 	}
-	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _ string, _, _ bool) (string, error) {
+	createFromBranchFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _, _, _ string, _, _ bool) (string, error) {
 		return "", os.ErrInvalid
 	}
 	createFromPRFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, _ int, noWorkspace, _ bool) (string, error) {
@@ -1081,6 +1107,16 @@ func TestBuildStatusString(t *testing.T) {
 			wt:       &models.WorktreeInfo{Dirty: false, HasUpstream: true, Unpushed: 4},
 			expected: "✓",
 		},
+		{
+			name:     "detached and locked",
+			wt:       &models.WorktreeInfo{HasUpstream: true, Detached: true, Locked: true},
+			expected: "✓ detached locked",
+		},
+		{
+			name:     "prunable",
+			wt:       &models.WorktreeInfo{HasUpstream: true, Prunable: true},
+			expected: "✓ prunable",
+		},
 	}
 
 	for _, tt := range tests {
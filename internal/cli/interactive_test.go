@@ -243,6 +243,10 @@ func (m *mockGitServiceForInteractive) RunGit(context.Context, []string, string,
 	return ""
 }
 
+func (m *mockGitServiceForInteractive) SetBaseRef(context.Context, string, string, string) error {
+	return nil
+}
+
 func TestSelectIssueInteractive_NoIssues(t *testing.T) {
 	gitSvc := &mockGitServiceForInteractive{issues: []*models.IssueInfo{}}
 	stderr := &bytes.Buffer{}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/multiplexer"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// WorktreeMove is a planned move of a worktree into the managed layout.
+type WorktreeMove struct {
+	Worktree    *models.WorktreeInfo
+	Destination string
+}
+
+// MoveWorktree moves a worktree to destination with git worktree move, then
+// migrates its note, access history and tmux/zellij sessions. When
+// destination is an existing directory the worktree is moved inside it. It
+// returns the new path.
+func MoveWorktree(ctx context.Context, gitSvc worktreeGitService, cfg *config.AppConfig, worktreePath, destination string, silent bool) (string, error) {
+	destination = strings.TrimSpace(destination)
+	if destination == "" {
+		return "", fmt.Errorf("destination is required")
+	}
+	expanded, err := utils.ExpandPath(destination)
+	if err != nil {
+		return "", err
+	}
+	if expanded, err = filepath.Abs(expanded); err != nil {
+		return "", fmt.Errorf("failed to resolve destination %s: %w", destination, err)
+	}
+
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	repoName := gitSvc.ResolveRepoName(ctx)
+	wt, err := FindWorktreeByPathOrName(worktreePath, worktrees, LoadWorktreeLayout(ctx, gitSvc, cfg))
+	if err != nil {
+		return "", err
+	}
+	if wt.IsMain {
+		return "", fmt.Errorf("the main worktree cannot be moved")
+	}
+	if wt.Locked {
+		return "", fmt.Errorf("worktree %s is %s; unlock it first", wt.Path, appservices.DescribeLock(wt))
+	}
+
+	newPath := appservices.MoveDestination(wt.Path, expanded)
+	if filepath.Clean(newPath) == filepath.Clean(wt.Path) {
+		return "", fmt.Errorf("worktree is already at %s", wt.Path)
+	}
+	if err := appservices.NewWorktreeService(gitSvc).Move(ctx, wt.Path, newPath); err != nil {
+		return "", err
+	}
+	migrateMovedWorktree(ctx, cfg, repoName, wt.Path, newPath, silent)
+	return newPath, nil
+}
+
+// PlanAdoption returns the worktrees whose path does not follow the worktree
+// layout, with the managed path each one would be moved to.
+func PlanAdoption(ctx context.Context, gitSvc worktreeGitService, cfg *config.AppConfig) ([]WorktreeMove, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	layout := LoadWorktreeLayout(ctx, gitSvc, cfg)
+	outside := appservices.OutsideWorktrees(worktrees, layout)
+	moves := make([]WorktreeMove, 0, len(outside))
+	for _, wt := range outside {
+		moves = append(moves, WorktreeMove{Worktree: wt, Destination: appservices.AdoptionPath(wt, layout)})
+	}
+	return moves, nil
+}
+
+// AdoptWorktrees moves each planned worktree into the managed layout.
+// Locked worktrees and destinations that already exist are skipped.
+func AdoptWorktrees(ctx context.Context, gitSvc worktreeGitService, cfg *config.AppConfig, moves []WorktreeMove, silent bool) []OperationResult {
+	svc := appservices.NewWorktreeService(gitSvc)
+	repoName := gitSvc.ResolveRepoName(ctx)
+
+	results := make([]OperationResult, 0, len(moves))
+	for _, move := range moves {
+		result := OperationResult{Worktree: move.Worktree, Status: OperationStatusOK, Message: fmt.Sprintf("moved to %s", move.Destination)}
+		switch {
+		case move.Worktree.Locked:
+			result.Status = OperationStatusSkipped
+			result.Message = appservices.DescribeLock(move.Worktree)
+		case pathExists(move.Destination):
+			result.Status = OperationStatusSkipped
+			result.Message = fmt.Sprintf("%s already exists", move.Destination)
+		default:
+			if err := svc.Move(ctx, move.Worktree.Path, move.Destination); err != nil {
+				result.Status = OperationStatusFailed
+				result.Message = err.Error()
+				break
+			}
+			migrateMovedWorktree(ctx, cfg, repoName, move.Worktree.Path, move.Destination, silent)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// migrateMovedWorktree carries the state kept for a worktree path over to
+// its new path. Failures are reported but do not fail the move.
+func migrateMovedWorktree(ctx context.Context, cfg *config.AppConfig, repoName, oldPath, newPath string, silent bool) {
+	if err := appservices.MigrateWorktreeState(repoName, cfg.WorktreeDir, cfg.WorktreeNotesPath, oldPath, newPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	for _, session := range multiplexer.RenameWorktreeSessions(ctx, cfg.SessionPrefix, oldPath, newPath) {
+		if !silent {
+			fmt.Fprintf(os.Stderr, "Renamed session %s\n", session)
+		}
+	}
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
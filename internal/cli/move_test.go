@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestMoveWorktree(t *testing.T) {
+	t.Parallel()
+
+	wtDir := t.TempDir()
+	dest := t.TempDir()
+	cfg := &config.AppConfig{WorktreeDir: wtDir}
+	if err := appservices.SaveWorktreeNote(testRepoName, wtDir, "", "/wt/repo/merged", "remember me"); err != nil {
+		t.Fatal(err)
+	}
+	if err := appservices.SaveAccessHistory(testRepoName, wtDir, map[string]int64{"/wt/repo/merged": 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := newFakePruneGitService()
+	newPath, err := MoveWorktree(context.Background(), svc, cfg, "merged", dest, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := filepath.Join(dest, "merged")
+	if newPath != want {
+		t.Fatalf("expected worktree to be moved inside %s, got %s", dest, newPath)
+	}
+	if got := strings.Join(svc.commands, "; "); got != "git worktree move /wt/repo/merged "+want {
+		t.Fatalf("unexpected commands: %s", got)
+	}
+
+	note, ok, err := appservices.LoadWorktreeNote(testRepoName, wtDir, "", want)
+	if err != nil || !ok || note.Note != "remember me" {
+		t.Fatalf("expected note to follow the worktree, got %+v, %v, %v", note, ok, err)
+	}
+	history, err := appservices.LoadAccessHistory(testRepoName, wtDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := history["/wt/repo/merged"]; ok || history[want] != 42 {
+		t.Fatalf("expected access history to follow the worktree, got %v", history)
+	}
+}
+
+func TestMoveWorktreeRejects(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	tests := []struct {
+		name   string
+		target string
+		dest   string
+		want   string
+	}{
+		{name: "main worktree", target: "main", dest: "/elsewhere/main", want: "main worktree cannot be moved"},
+		{name: "locked", target: "locked", dest: "/elsewhere/locked", want: "unlock it first"},
+		{name: "same path", target: "merged", dest: "/wt/repo/merged", want: "already at"},
+		{name: "no destination", target: "merged", dest: " ", want: "destination is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := newFakePruneGitService()
+			svc.worktrees = append(svc.worktrees, &models.WorktreeInfo{Path: "/wt/repo/locked", Branch: "locked", Locked: true})
+			_, err := MoveWorktree(context.Background(), svc, cfg, tt.target, tt.dest, true)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			if len(svc.commands) != 0 {
+				t.Fatalf("expected no git commands, got %v", svc.commands)
+			}
+		})
+	}
+}
+
+func TestAdoptWorktrees(t *testing.T) {
+	t.Parallel()
+
+	wtDir := t.TempDir()
+	repoDir := filepath.Join(wtDir, testRepoName)
+	if err := os.MkdirAll(filepath.Join(repoDir, "taken"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.AppConfig{WorktreeDir: wtDir}
+
+	svc := newFakePruneGitService()
+	svc.worktrees = []*models.WorktreeInfo{
+		{Path: "/src/repo", Branch: "main", IsMain: true},
+		{Path: filepath.Join(repoDir, "managed"), Branch: "managed"},
+		{Path: "/src/outside", Branch: "outside"},
+		{Path: "/src/frozen", Branch: "frozen", Locked: true},
+		{Path: "/src/taken", Branch: "taken"},
+		{Path: "/src/gone", Branch: "gone", Prunable: true},
+	}
+
+	moves, err := PlanAdoption(context.Background(), svc, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(moves) != 3 || moves[0].Destination != filepath.Join(repoDir, "outside") {
+		t.Fatalf("unexpected plan: %+v", moves)
+	}
+
+	results := AdoptWorktrees(context.Background(), svc, cfg, moves, true)
+	statuses := make(map[string]string, len(results))
+	for _, r := range results {
+		statuses[r.Worktree.Branch] = r.Status
+	}
+	if statuses["outside"] != OperationStatusOK || statuses["frozen"] != OperationStatusSkipped || statuses["taken"] != OperationStatusSkipped {
+		t.Fatalf("unexpected results: %v", statuses)
+	}
+	if got := strings.Join(svc.commands, "; "); got != "git worktree move /src/outside "+filepath.Join(repoDir, "outside") {
+		t.Fatalf("unexpected commands: %s", got)
+	}
+}
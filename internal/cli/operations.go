@@ -56,27 +56,33 @@ type gitService interface {
 	ResolveRepoName(ctx context.Context) string
 	RunCommandChecked(ctx context.Context, args []string, cwd string, errorMsg string) bool
 	RunGit(ctx context.Context, args []string, cwd string, exitCodes []int, silent bool, ignoreErrors bool) string
+	SetBaseRef(ctx context.Context, cwd, branch, base string) error
 }
 
 var _ gitService = (*git.Service)(nil)
 
-// CreateFromBranch creates a worktree from a branch name.
-func CreateFromBranch(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branchName, worktreeName string, withChange, silent bool) (string, error) {
-	return CreateFromBranchWithFS(ctx, gitSvc, cfg, branchName, worktreeName, withChange, silent, DefaultFS)
+// CreateFromBranch creates a worktree from a branch name. A non-empty
+// sparseProfile restricts the checkout to the directories of that profile.
+func CreateFromBranch(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branchName, worktreeName, sparseProfile string, withChange, silent bool) (string, error) {
+	return CreateFromBranchWithFS(ctx, gitSvc, cfg, branchName, worktreeName, sparseProfile, withChange, silent, DefaultFS)
 }
 
 // CreateFromBranchWithFS creates a worktree from a branch name using the provided filesystem.
-func CreateFromBranchWithFS(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branchName, worktreeName string, withChange, silent bool, fs OSFilesystem) (string, error) {
+func CreateFromBranchWithFS(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branchName, worktreeName, sparseProfile string, withChange, silent bool, fs OSFilesystem) (string, error) {
 	// Validate branch exists
 	if !branchExists(ctx, gitSvc, branchName) {
 		return "", fmt.Errorf("branch %q does not exist", branchName)
 	}
 
+	sparseDirs, err := resolveSparseProfile(ctx, gitSvc, cfg, sparseProfile)
+	if err != nil {
+		return "", err
+	}
+
 	// Get current worktree if --with-change is specified
 	var currentWt *models.WorktreeInfo
 	var hasChanges bool
 	if withChange {
-		var err error
 		currentWt, hasChanges, err = getCurrentWorktreeWithChangesFS(ctx, gitSvc, fs)
 		if err != nil {
 			return "", err
@@ -91,13 +97,13 @@ func CreateFromBranchWithFS(ctx context.Context, gitSvc gitService, cfg *config.
 	}
 
 	// Construct target path based on worktree name
-	repoName := gitSvc.ResolveRepoName(ctx)
+	layout := LoadWorktreeLayout(ctx, gitSvc, cfg)
 
 	// Generate random name if not provided, or validate user-provided name
 	if worktreeName == "" {
 		// Generate random name with retry for uniqueness
 		sanitizedBranch := utils.SanitizeBranchName(branchName, 50)
-		worktreeName = generateUniqueWorktreeNameFS(cfg, repoName, sanitizedBranch, fs)
+		worktreeName = generateUniqueWorktreeNameFS(layout, sanitizedBranch, fs)
 	} else {
 		// Validate and sanitise user-provided name
 		sanitised := utils.SanitizeBranchName(worktreeName, 100)
@@ -107,7 +113,8 @@ func CreateFromBranchWithFS(ctx context.Context, gitSvc gitService, cfg *config.
 		worktreeName = sanitised
 	}
 
-	targetPath := filepath.Join(cfg.WorktreeDir, repoName, worktreeName)
+	// The worktree checks out a branch named after it, see createWorktreeFromBranch
+	targetPath := layout.Path(worktreeName, worktreeName)
 
 	// Check for path conflicts
 	if _, err := fs.Stat(targetPath); err == nil {
@@ -131,7 +138,7 @@ func CreateFromBranchWithFS(ctx context.Context, gitSvc gitService, cfg *config.
 			return "", err
 		}
 	} else {
-		if err := createWorktreeFromBranch(ctx, gitSvc, cfg, branchName, worktreeName, targetPath, silent); err != nil {
+		if err := createWorktreeFromBranch(ctx, gitSvc, cfg, branchName, worktreeName, targetPath, sparseDirs, silent); err != nil {
 			return "", err
 		}
 	}
@@ -139,19 +146,46 @@ func CreateFromBranchWithFS(ctx context.Context, gitSvc gitService, cfg *config.
 	return targetPath, nil
 }
 
-func createWorktreeFromBranch(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branchName, worktreeName, targetPath string, silent bool) error {
+// resolveSparseProfile returns the directories of the sparse-checkout profile
+// called name, looked up in the .wt file of the repository and then in cfg.
+func resolveSparseProfile(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, name string) ([]string, error) {
+	if name == "" {
+		return nil, nil
+	}
+	repoConfig, wtFilePath, err := config.LoadRepoConfig(gitSvc.GetMainWorktreePath(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", wtFilePath, err)
+	}
+	profiles := config.SparseProfiles(cfg, repoConfig)
+	if dirs, ok := profiles[name]; ok {
+		return dirs, nil
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("unknown sparse profile %q: no sparse_profiles configured", name)
+	}
+	return nil, fmt.Errorf("unknown sparse profile %q (available: %s)", name, strings.Join(config.SparseProfileNames(profiles), ", "))
+}
+
+func createWorktreeFromBranch(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branchName, worktreeName, targetPath string, sparseDirs []string, silent bool) error {
 	// Create worktree normally
 	args := []string{"git", "worktree", "add"}
+	if len(sparseDirs) > 0 {
+		// Check out once sparse-checkout is set, see below
+		args = append(args, "--no-checkout")
+	}
 
 	// Determine if we need to create a new branch
+	newBranch := ""
 	switch {
 	case strings.Contains(branchName, "/"):
 		// Remote branch - create new local branch with tracking
 		args = append(args, "-b", worktreeName, "--track", targetPath, branchName)
+		newBranch = worktreeName
 	case worktreeName != branchName:
 		// Creating a new branch with a different name (e.g., random name)
 		// Always use -b to create the new branch based on the source branch
 		args = append(args, "-b", worktreeName, targetPath, branchName)
+		newBranch = worktreeName
 	default:
 		// Worktree name matches branch name - check if branch already exists
 		localBranchExists := gitSvc.RunGit(
@@ -175,6 +209,16 @@ func createWorktreeFromBranch(ctx context.Context, gitSvc gitService, cfg *confi
 	if !gitSvc.RunCommandChecked(ctx, args, "", fmt.Sprintf("Failed to create worktree from branch %s", branchName)) {
 		return fmt.Errorf("failed to create worktree")
 	}
+	if newBranch != "" {
+		recordBaseRef(ctx, gitSvc, targetPath, newBranch, branchName)
+	}
+
+	if len(sparseDirs) > 0 {
+		if err := appservices.CheckoutSparse(ctx, gitSvc, targetPath, sparseDirs); err != nil {
+			gitSvc.RunCommandChecked(ctx, []string{"git", "worktree", "remove", "--force", targetPath}, "", "Failed to cleanup worktree")
+			return err
+		}
+	}
 
 	// Run init commands
 	if err := runInitCommands(ctx, gitSvc, cfg, worktreeName, targetPath, silent); err != nil {
@@ -189,13 +233,13 @@ func createWorktreeFromBranch(ctx context.Context, gitSvc gitService, cfg *confi
 // generateUniqueWorktreeNameFS generates a unique worktree name with retries.
 // Format: <branch>-<random-adjective>-<random-noun>
 // Retries up to 10 times if path already exists.
-func generateUniqueWorktreeNameFS(cfg *config.AppConfig, repoName, branchName string, fs OSFilesystem) string {
+func generateUniqueWorktreeNameFS(layout appservices.WorktreeLayout, branchName string, fs OSFilesystem) string {
 	const maxRetries = 10
 
 	for range maxRetries {
 		randomPart := utils.RandomBranchName()
 		candidate := fmt.Sprintf("%s-%s", branchName, randomPart)
-		targetPath := filepath.Join(cfg.WorktreeDir, repoName, candidate)
+		targetPath := layout.Path(candidate, candidate)
 
 		// Check if path exists
 		if _, err := fs.Stat(targetPath); os.IsNotExist(err) {
@@ -261,9 +305,10 @@ func CreateFromPRWithFS(ctx context.Context, gitSvc gitService, cfg *config.AppC
 	}
 
 	repoName := gitSvc.ResolveRepoName(ctx)
+	layout := LoadWorktreeLayout(ctx, gitSvc, cfg)
 	localBranch := remoteBranch
 	if useGeneratedBranch {
-		localBranch = uniquePRGeneratedBranchNameFS(ctx, gitSvc, fs, cfg, repoName, worktrees, worktreeName, !noWorkspace)
+		localBranch = uniquePRGeneratedBranchNameFS(ctx, gitSvc, fs, layout, worktrees, worktreeName, !noWorkspace)
 		worktreeName = localBranch
 	} else if worktreePath, attached := findWorktreePathForBranch(worktrees, localBranch); attached {
 		return "", fmt.Errorf("branch %q is already checked out in worktree %q", localBranch, worktreePath)
@@ -277,7 +322,7 @@ func CreateFromPRWithFS(ctx context.Context, gitSvc gitService, cfg *config.AppC
 	}
 
 	// Construct target path
-	targetPath := filepath.Join(cfg.WorktreeDir, repoName, worktreeName)
+	targetPath := layout.Path(worktreeName, localBranch)
 
 	// Check for path conflicts
 	if _, err := fs.Stat(targetPath); err == nil {
@@ -358,8 +403,7 @@ func uniquePRGeneratedBranchNameFS(
 	ctx context.Context,
 	gitSvc gitService,
 	fs OSFilesystem,
-	cfg *config.AppConfig,
-	repoName string,
+	layout appservices.WorktreeLayout,
 	worktrees []*models.WorktreeInfo,
 	base string,
 	checkPath bool,
@@ -386,8 +430,7 @@ func uniquePRGeneratedBranchNameFS(
 			continue
 		}
 		if checkPath {
-			targetPath := filepath.Join(cfg.WorktreeDir, repoName, candidate)
-			if _, err := fs.Stat(targetPath); err == nil {
+			if _, err := fs.Stat(layout.Path(candidate, candidate)); err == nil {
 				continue
 			}
 		}
@@ -473,7 +516,7 @@ func CreateFromIssueWithFS(ctx context.Context, gitSvc gitService, cfg *config.A
 
 	// Construct target path
 	repoName := gitSvc.ResolveRepoName(ctx)
-	targetPath := filepath.Join(cfg.WorktreeDir, repoName, branchName)
+	targetPath := LoadWorktreeLayout(ctx, gitSvc, cfg).Path(branchName, branchName)
 
 	// Check for path conflicts
 	if _, err := fs.Stat(targetPath); err == nil {
@@ -495,6 +538,7 @@ func CreateFromIssueWithFS(ctx context.Context, gitSvc gitService, cfg *config.A
 	) {
 		return "", fmt.Errorf("failed to create worktree from issue #%d", issueNumber)
 	}
+	recordBaseRef(ctx, gitSvc, targetPath, branchName, baseBranch)
 
 	// Run init commands
 	if err := runInitCommands(ctx, gitSvc, cfg, branchName, targetPath, silent); err != nil {
@@ -509,7 +553,8 @@ func CreateFromIssueWithFS(ctx context.Context, gitSvc gitService, cfg *config.A
 }
 
 // DeleteWorktree deletes a worktree. If worktreePath is empty, lists available worktrees.
-func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath string, deleteBranch, silent bool) error {
+// Locked worktrees are refused unless force is set.
+func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath string, deleteBranch, force, silent bool) error {
 	worktrees, err := gitSvc.GetWorktrees(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get worktrees: %w", err)
@@ -538,10 +583,13 @@ func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	}
 
 	// Find the worktree to delete
-	selectedWorktree, err := FindWorktreeByPathOrName(worktreePath, nonMainWorktrees, cfg.WorktreeDir, gitSvc.ResolveRepoName(ctx))
+	selectedWorktree, err := FindWorktreeByPathOrName(worktreePath, nonMainWorktrees, LoadWorktreeLayout(ctx, gitSvc, cfg))
 	if err != nil {
 		return err
 	}
+	if selectedWorktree.Locked && !force {
+		return fmt.Errorf("worktree %s is %s; unlock it first or use --force", selectedWorktree.Path, appservices.DescribeLock(selectedWorktree))
+	}
 
 	// Run terminate commands
 	if err := runTerminateCommands(ctx, gitSvc, cfg, selectedWorktree.Branch, selectedWorktree.Path, silent); err != nil {
@@ -551,10 +599,14 @@ func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 		}
 	}
 
-	// Delete worktree
+	// Delete worktree; git needs --force twice to remove a locked worktree
+	args := []string{"git", "worktree", "remove", "--force"}
+	if selectedWorktree.Locked {
+		args = append(args, "--force")
+	}
 	if !gitSvc.RunCommandChecked(
 		ctx,
-		[]string{"git", "worktree", "remove", "--force", selectedWorktree.Path},
+		append(args, selectedWorktree.Path),
 		"",
 		fmt.Sprintf("Failed to remove worktree %s", selectedWorktree.Path),
 	) {
@@ -581,6 +633,59 @@ func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	return nil
 }
 
+// LockWorktree locks a worktree so that git and lazyworktree do not prune or
+// delete it. The reason is optional.
+func LockWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath, reason string) error {
+	wt, err := findLockTarget(ctx, gitSvc, cfg, worktreePath)
+	if err != nil {
+		return err
+	}
+	if wt.Locked {
+		return fmt.Errorf("worktree %s is already %s", wt.Path, appservices.DescribeLock(wt))
+	}
+
+	args := []string{"git", "worktree", "lock"}
+	if reason = strings.TrimSpace(reason); reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	if !gitSvc.RunCommandChecked(ctx, append(args, wt.Path), "", fmt.Sprintf("Failed to lock worktree %s", wt.Path)) {
+		return fmt.Errorf("failed to lock worktree %s", wt.Path)
+	}
+	return nil
+}
+
+// UnlockWorktree unlocks a locked worktree.
+func UnlockWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath string) error {
+	wt, err := findLockTarget(ctx, gitSvc, cfg, worktreePath)
+	if err != nil {
+		return err
+	}
+	if !wt.Locked {
+		return fmt.Errorf("worktree %s is not locked", wt.Path)
+	}
+	if !gitSvc.RunCommandChecked(ctx, []string{"git", "worktree", "unlock", wt.Path}, "", fmt.Sprintf("Failed to unlock worktree %s", wt.Path)) {
+		return fmt.Errorf("failed to unlock worktree %s", wt.Path)
+	}
+	return nil
+}
+
+// findLockTarget returns the non-main worktree matching worktreePath; git
+// does not lock the main worktree.
+func findLockTarget(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath string) (*models.WorktreeInfo, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	wt, err := FindWorktreeByPathOrName(worktreePath, worktrees, LoadWorktreeLayout(ctx, gitSvc, cfg))
+	if err != nil {
+		return nil, err
+	}
+	if wt.IsMain {
+		return nil, fmt.Errorf("the main worktree cannot be locked")
+	}
+	return wt, nil
+}
+
 // RenameWorktree renames a worktree. The branch is renamed only when the
 // current worktree name and branch name are the same.
 func RenameWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath, newName string, silent bool) error {
@@ -620,7 +725,9 @@ func RenameWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 		return fmt.Errorf("invalid new name: must contain at least one alphanumeric character")
 	}
 
-	selectedWorktree, err := FindWorktreeByPathOrName(worktreePath, nonMainWorktrees, cfg.WorktreeDir, gitSvc.ResolveRepoName(ctx))
+	repoName := gitSvc.ResolveRepoName(ctx)
+	layout := LoadWorktreeLayout(ctx, gitSvc, cfg)
+	selectedWorktree, err := FindWorktreeByPathOrName(worktreePath, nonMainWorktrees, layout)
 	if err != nil {
 		return err
 	}
@@ -630,16 +737,24 @@ func RenameWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 		return fmt.Errorf("new name must be different from current worktree name: %s", currentWorktreeName)
 	}
 
-	newPath := filepath.Join(filepath.Dir(selectedWorktree.Path), newWorktreeName)
+	newBranch := selectedWorktree.Branch
+	if currentWorktreeName == selectedWorktree.Branch {
+		newBranch = newWorktreeName
+	}
+	newPath := layout.RenamePath(selectedWorktree.Path, newWorktreeName, newBranch)
 	if _, err := os.Stat(newPath); err == nil {
 		return fmt.Errorf("destination already exists: %s", newPath)
 	} else if !os.IsNotExist(err) {
 		return fmt.Errorf("failed to check destination %s: %w", newPath, err)
 	}
 
+	if err := os.MkdirAll(filepath.Dir(newPath), utils.DefaultDirPerms); err != nil {
+		return fmt.Errorf("failed to create worktree directory: %w", err)
+	}
 	if !gitSvc.RenameWorktree(ctx, selectedWorktree.Path, newPath, selectedWorktree.Branch, newWorktreeName) {
 		return fmt.Errorf("failed to rename worktree %s", selectedWorktree.Path)
 	}
+	migrateMovedWorktree(ctx, cfg, repoName, selectedWorktree.Path, newPath, silent)
 
 	if !silent && currentWorktreeName != selectedWorktree.Branch {
 		fmt.Fprintf(os.Stderr, "Skipping branch rename: worktree name %q != branch %q\n", currentWorktreeName, selectedWorktree.Branch)
@@ -648,8 +763,14 @@ func RenameWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	return nil
 }
 
-// FindWorktreeByPathOrName finds a worktree by its path or name.
-func FindWorktreeByPathOrName(pathOrName string, worktrees []*models.WorktreeInfo, worktreeDir, repoName string) (*models.WorktreeInfo, error) {
+// LoadWorktreeLayout returns the worktree layout of the current repository.
+func LoadWorktreeLayout(ctx context.Context, gitSvc gitService, cfg *config.AppConfig) appservices.WorktreeLayout {
+	return appservices.LoadWorktreeLayout(ctx, gitSvc, cfg.WorktreePathTemplate, cfg.WorktreeDir, gitSvc.ResolveRepoName(ctx))
+}
+
+// FindWorktreeByPathOrName finds a worktree by its path or name. Names are
+// resolved through the worktree layout before falling back to the basename.
+func FindWorktreeByPathOrName(pathOrName string, worktrees []*models.WorktreeInfo, layout appservices.WorktreeLayout) (*models.WorktreeInfo, error) {
 	// Try to match by exact path
 	for _, wt := range worktrees {
 		if wt.Path == pathOrName {
@@ -672,7 +793,7 @@ func FindWorktreeByPathOrName(pathOrName string, worktrees []*models.WorktreeInf
 	}
 
 	// Try to construct the path from worktree name and match
-	constructedPath := filepath.Join(worktreeDir, repoName, pathOrName)
+	constructedPath := layout.Path(pathOrName, pathOrName)
 	for _, wt := range worktrees {
 		if wt.Path == constructedPath {
 			return wt, nil
@@ -689,7 +810,8 @@ func FindWorktreeByPathOrName(pathOrName string, worktrees []*models.WorktreeInf
 	return nil, fmt.Errorf("worktree not found: %s", pathOrName)
 }
 
-// runInitCommands runs init commands with TOFU trust checks.
+// runInitCommands updates the submodules of a new worktree when configured,
+// then runs init commands with TOFU trust checks.
 func runInitCommands(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, branch, wtPath string, silent bool) error {
 	// Collect init commands from global and repo config
 	commands := make([]string, 0)
@@ -702,6 +824,22 @@ func runInitCommands(ctx context.Context, gitSvc gitService, cfg *config.AppConf
 		return fmt.Errorf("failed to load repo config: %w", err)
 	}
 
+	// Like its commands, the submodule settings of the .wt file only apply
+	// once it is trusted
+	submoduleRepoConfig := repoConfig
+	if repoConfig != nil && checkTrust(ctx, cfg, wtFilePath) != nil {
+		submoduleRepoConfig = nil
+	}
+	// A failed submodule update leaves a usable worktree, so only warn
+	if update, reference := config.SubmoduleSettings(cfg, submoduleRepoConfig); update && appservices.HasSubmodules(wtPath) {
+		if !silent {
+			fmt.Fprintf(os.Stderr, "Updating submodules...\n")
+		}
+		if err := appservices.UpdateSubmodules(ctx, gitSvc, wtPath, reference); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
 	if repoConfig != nil {
 		commands = append(commands, repoConfig.InitCommands...)
 	}
@@ -794,8 +932,8 @@ func checkTrust(_ context.Context, cfg *config.AppConfig, wtFilePath string) err
 
 	if trustStatus == security.TrustStatusUntrusted {
 		// .wt file is not trusted - this should have been handled before CLI execution
-		// For CLI mode, we require manual trust setup via the TUI
-		return fmt.Errorf(".wt file is not trusted. Please run lazyworktree in TUI mode to review and trust the file at: %s", wtFilePath)
+		// For CLI mode, trust it in the TUI or with the trust subcommand
+		return fmt.Errorf(".wt file is not trusted. Review it in the TUI or run 'lazyworktree trust add %s'", wtFilePath)
 	}
 
 	return nil
@@ -934,6 +1072,7 @@ func createWorktreeWithChanges(ctx context.Context, gitSvc gitService, cfg *conf
 		gitSvc.RunCommandChecked(ctx, []string{"git", "stash", "pop"}, currentWt.Path, "Failed to restore stash")
 		return fmt.Errorf("failed to create worktree %s", newBranch)
 	}
+	recordBaseRef(ctx, gitSvc, targetPath, newBranch, baseBranch)
 
 	if !silent {
 		fmt.Fprintf(os.Stderr, "✓ Worktree created\n")
@@ -969,3 +1108,12 @@ func createWorktreeWithChanges(ctx context.Context, gitSvc gitService, cfg *conf
 
 	return nil
 }
+
+// recordBaseRef remembers base as the ref the new branch was created from,
+// the default target of absorb, sync --local and merged detection. A failure
+// leaves a usable worktree, so it only warns.
+func recordBaseRef(ctx context.Context, gitSvc gitService, targetPath, branch, base string) {
+	if err := gitSvc.SetBaseRef(ctx, targetPath, branch, base); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
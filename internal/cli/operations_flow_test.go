@@ -719,7 +719,7 @@ func TestDeleteWorktree_ListsWhenNoPath(t *testing.T) {
 	}
 	cfg := &config.AppConfig{WorktreeDir: "/worktrees"}
 
-	if err := DeleteWorktree(ctx, svc, cfg, "", true, true); err != nil {
+	if err := DeleteWorktree(ctx, svc, cfg, "", true, false, true); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -737,7 +737,7 @@ func TestDeleteWorktree_NoWorktrees(t *testing.T) {
 	}
 	cfg := &config.AppConfig{WorktreeDir: "/worktrees"}
 
-	if err := DeleteWorktree(ctx, svc, cfg, "/wt/does-not-matter", true, true); err == nil {
+	if err := DeleteWorktree(ctx, svc, cfg, "/wt/does-not-matter", true, false, true); err == nil {
 		t.Fatalf("expected error")
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
 	"github.com/chmouel/lazyworktree/internal/config"
 	"github.com/chmouel/lazyworktree/internal/models"
 	"github.com/chmouel/lazyworktree/internal/security"
@@ -21,6 +22,7 @@ type fakeGitService struct {
 	worktrees           []*models.WorktreeInfo
 	worktreesErr        error
 	runGitOutput        map[string]string
+	baseRefs            map[string]string
 	runCommandCheckedOK bool
 	renameWorktreeOK    bool
 	authUsername        string
@@ -50,6 +52,7 @@ type fakeGitService struct {
 	lastRenameNewPath     string
 	lastRenameOldBranch   string
 	lastRenameNewBranch   string
+	checkedCommands       [][]string
 }
 
 func (f *fakeGitService) CheckoutPRBranch(_ context.Context, _ int, _, localBranch string) bool {
@@ -131,6 +134,7 @@ func (f *fakeGitService) ResolveRepoName(_ context.Context) string {
 }
 
 func (f *fakeGitService) RunCommandChecked(_ context.Context, args []string, _, _ string) bool {
+	f.checkedCommands = append(f.checkedCommands, args)
 	// Capture worktree add commands for testing
 	if len(args) > 2 && args[0] == "git" && args[1] == "worktree" && args[2] == "add" {
 		// Find the path in the args (it's before the branch name)
@@ -156,6 +160,14 @@ func (f *fakeGitService) RunGit(_ context.Context, args []string, _ string, _ []
 	return f.runGitOutput[filepath.Join(args...)]
 }
 
+func (f *fakeGitService) SetBaseRef(_ context.Context, _, branch, base string) error {
+	if f.baseRefs == nil {
+		f.baseRefs = make(map[string]string)
+	}
+	f.baseRefs[branch] = base
+	return nil
+}
+
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
@@ -191,8 +203,7 @@ func (m *mockFilesystem) Getwd() (string, error) {
 func TestFindWorktreeByPathOrName(t *testing.T) {
 	t.Parallel()
 
-	worktreeDir := "/worktrees"
-	repoName := "repo"
+	layout := appservices.WorktreeLayout{WorktreeDir: "/worktrees", RepoKey: "repo"}
 
 	wtFeature := &models.WorktreeInfo{Path: "/worktrees/repo/feature", Branch: "feature"}
 	wtBugfix := &models.WorktreeInfo{Path: "/worktrees/repo/bugfix", Branch: "bugfix"}
@@ -215,7 +226,7 @@ func TestFindWorktreeByPathOrName(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			found, err := FindWorktreeByPathOrName(tt.pathOrName, worktrees, worktreeDir, repoName)
+			found, err := FindWorktreeByPathOrName(tt.pathOrName, worktrees, layout)
 			if tt.wantErr {
 				if err == nil {
 					t.Fatalf("expected error")
@@ -232,6 +243,25 @@ func TestFindWorktreeByPathOrName(t *testing.T) {
 	}
 }
 
+func TestFindWorktreeByPathOrNameWithTemplate(t *testing.T) {
+	t.Parallel()
+
+	layout := appservices.WorktreeLayout{
+		Template: "{repo_parent}/{repo}.worktrees/{name}",
+		MainPath: "/src/repo",
+	}
+	wtFix := &models.WorktreeInfo{Path: "/src/repo.worktrees/fix", Branch: "bugfix/login"}
+	wtOther := &models.WorktreeInfo{Path: "/elsewhere/fix", Branch: "other"}
+
+	found, err := FindWorktreeByPathOrName("fix", []*models.WorktreeInfo{wtOther, wtFix}, layout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found != wtFix {
+		t.Fatalf("expected the worktree at the templated path, got %s", found.Path)
+	}
+}
+
 func TestBranchExists(t *testing.T) {
 	t.Parallel()
 
@@ -384,6 +414,53 @@ func TestRunInitCommands(t *testing.T) {
 	}
 }
 
+func TestRunInitCommandsUpdatesSubmodules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mainPath := t.TempDir()
+	wtPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(wtPath, ".gitmodules"), []byte("[submodule \"lib\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mainPath, ".wt"), []byte("submodule_update: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &fakeGitService{
+		mainWorktreePath:    mainPath,
+		resolveRepoName:     testRepoName,
+		runCommandCheckedOK: true,
+	}
+	if err := runInitCommands(ctx, svc, &config.AppConfig{TrustMode: "always"}, "branch", wtPath, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(svc.checkedCommands) != 1 || strings.Join(svc.checkedCommands[0], " ") != "git submodule update --init --recursive" {
+		t.Fatalf("expected a submodule update, got %v", svc.checkedCommands)
+	}
+
+	// The settings of an untrusted .wt file are ignored, the global ones apply
+	svc = &fakeGitService{mainWorktreePath: mainPath, resolveRepoName: testRepoName, runCommandCheckedOK: true}
+	if err := runInitCommands(ctx, svc, &config.AppConfig{TrustMode: "never"}, "branch", wtPath, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(svc.checkedCommands) != 0 {
+		t.Fatalf("expected no submodule update from an untrusted .wt file, got %v", svc.checkedCommands)
+	}
+	if err := runInitCommands(ctx, svc, &config.AppConfig{TrustMode: "never", SubmoduleUpdate: true}, "branch", wtPath, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(svc.checkedCommands) != 1 {
+		t.Fatalf("expected the global submodule_update to apply, got %v", svc.checkedCommands)
+	}
+
+	// A failed update only warns
+	svc = &fakeGitService{mainWorktreePath: mainPath, resolveRepoName: testRepoName}
+	if err := runInitCommands(ctx, svc, &config.AppConfig{TrustMode: "always"}, "branch", wtPath, true); err != nil {
+		t.Fatalf("unexpected error after a failed submodule update: %v", err)
+	}
+}
+
 func TestRunTerminateCommands(t *testing.T) {
 	t.Parallel()
 
@@ -491,7 +568,7 @@ func TestCreateFromBranch(t *testing.T) {
 			},
 		}
 
-		_, err := CreateFromBranch(ctx, svc, cfg, "nonexistent", "", false, false)
+		_, err := CreateFromBranch(ctx, svc, cfg, "nonexistent", "", "", false, false)
 		if err == nil {
 			t.Fatal("expected error for nonexistent branch")
 		}
@@ -516,7 +593,7 @@ func TestCreateFromBranch(t *testing.T) {
 		}
 
 		// Provide explicit worktreeName to avoid random generation
-		_, err := CreateFromBranch(ctx, svc, cfg, branchName, worktreeName, false, false)
+		_, err := CreateFromBranch(ctx, svc, cfg, branchName, worktreeName, "", false, false)
 		if err == nil {
 			t.Fatal("expected error for existing path")
 		}
@@ -540,7 +617,7 @@ func TestCreateFromBranch(t *testing.T) {
 			},
 		}
 
-		outputPath, err := CreateFromBranch(ctx, svc, cfg, branchName, "", false, true)
+		outputPath, err := CreateFromBranch(ctx, svc, cfg, branchName, "", "", false, true)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -568,7 +645,7 @@ func TestCreateFromBranch(t *testing.T) {
 			},
 		}
 
-		outputPath, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, worktreeName, false, true)
+		outputPath, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, worktreeName, "", false, true)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -581,6 +658,83 @@ func TestCreateFromBranch(t *testing.T) {
 		if svc.lastWorktreeAddPath != expectedPath {
 			t.Errorf("expected path %q, got %q", expectedPath, svc.lastWorktreeAddPath)
 		}
+		if svc.baseRefs[worktreeName] != sourceBranch {
+			t.Errorf("expected %s recorded as the base of %s, got %v", sourceBranch, worktreeName, svc.baseRefs)
+		}
+	})
+
+	t.Run("worktree path template", func(t *testing.T) {
+		sourceBranch := "main"
+		mainPath := filepath.Join(tmpDir, "main")
+		if err := os.MkdirAll(mainPath, 0o750); err != nil {
+			t.Fatalf("failed to create main path: %v", err)
+		}
+
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			mainWorktreePath:    mainPath,
+			runCommandCheckedOK: true,
+			runGitOutput: map[string]string{
+				filepath.Join("git", "rev-parse", "--verify", sourceBranch):              "abc123\n",
+				filepath.Join("git", "show-ref", "--verify", "refs/heads/"+sourceBranch): "abc123\n",
+			},
+		}
+		templateCfg := *cfg
+		templateCfg.WorktreePathTemplate = "{repo_parent}/{repo}.worktrees/{name}"
+
+		if _, err := CreateFromBranch(ctx, svc, &templateCfg, sourceBranch, "feature-2", "", false, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expectedPath := filepath.Join(tmpDir, "main.worktrees", "feature-2")
+		if svc.lastWorktreeAddPath != expectedPath {
+			t.Errorf("expected path %q, got %q", expectedPath, svc.lastWorktreeAddPath)
+		}
+	})
+
+	t.Run("sparse profile", func(t *testing.T) {
+		sourceBranch := "main"
+		mainPath := filepath.Join(tmpDir, "sparse-main")
+		if err := os.MkdirAll(mainPath, 0o750); err != nil {
+			t.Fatalf("failed to create main path: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(mainPath, ".wt"), []byte("sparse_profiles:\n  web: [web, shared]\n"), 0o600); err != nil {
+			t.Fatalf("failed to write .wt: %v", err)
+		}
+
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			mainWorktreePath:    mainPath,
+			runCommandCheckedOK: true,
+			runGitOutput: map[string]string{
+				filepath.Join("git", "rev-parse", "--verify", sourceBranch):              "abc123\n",
+				filepath.Join("git", "show-ref", "--verify", "refs/heads/"+sourceBranch): "abc123\n",
+			},
+		}
+
+		if _, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, "docs", "missing", false, true); err == nil || !contains(err.Error(), "available: web") {
+			t.Fatalf("expected an unknown profile error listing web, got %v", err)
+		}
+		if len(svc.checkedCommands) != 0 {
+			t.Fatalf("expected no git command for an unknown profile, got %q", svc.checkedCommands)
+		}
+
+		outputPath, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, "sparse-wt", "web", false, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{
+			"git worktree add --no-checkout -b sparse-wt " + outputPath + " main",
+			"git sparse-checkout set --cone web shared",
+			"git checkout",
+		}
+		if len(svc.checkedCommands) != len(want) {
+			t.Fatalf("expected commands %q, got %q", want, svc.checkedCommands)
+		}
+		for i, args := range svc.checkedCommands {
+			if got := strings.Join(args, " "); got != want[i] {
+				t.Errorf("command %d = %q, want %q", i, got, want[i])
+			}
+		}
 	})
 
 	t.Run("explicit branch name gets sanitised", func(t *testing.T) {
@@ -602,7 +756,7 @@ func TestCreateFromBranch(t *testing.T) {
 			},
 		}
 
-		outputPath, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, worktreeName, false, true)
+		outputPath, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, worktreeName, "", false, true)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -629,7 +783,7 @@ func TestCreateFromBranch(t *testing.T) {
 			},
 		}
 
-		_, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, worktreeName, false, true)
+		_, err := CreateFromBranch(ctx, svc, cfg, sourceBranch, worktreeName, "", false, true)
 		if err == nil {
 			t.Fatal("expected error for invalid worktree name")
 		}
@@ -656,7 +810,7 @@ func TestDeleteWorktree(t *testing.T) {
 			worktreesErr:    nil,
 		}
 
-		err := DeleteWorktree(ctx, svc, cfg, "nonexistent", true, false)
+		err := DeleteWorktree(ctx, svc, cfg, "nonexistent", true, false, false)
 		if err == nil {
 			t.Fatal("expected error for nonexistent worktree")
 		}
@@ -674,9 +828,74 @@ func TestDeleteWorktree(t *testing.T) {
 			runCommandCheckedOK: true,
 		}
 
-		err := DeleteWorktree(ctx, svc, cfg, "worktree", true, true)
+		err := DeleteWorktree(ctx, svc, cfg, "worktree", true, false, true)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
+
+	t.Run("locked worktree requires force", func(t *testing.T) {
+		wtPath := filepath.Join(tmpDir, testRepoName, "worktree")
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			worktrees:           []*models.WorktreeInfo{{Path: wtPath, Branch: "worktree", Locked: true, LockReason: "on usb"}},
+			runCommandCheckedOK: true,
+		}
+
+		err := DeleteWorktree(ctx, svc, cfg, "worktree", true, false, true)
+		if err == nil || !strings.Contains(err.Error(), "locked (on usb)") {
+			t.Fatalf("expected locked error, got %v", err)
+		}
+		if len(svc.checkedCommands) != 0 {
+			t.Fatalf("expected no commands, got %v", svc.checkedCommands)
+		}
+
+		if err := DeleteWorktree(ctx, svc, cfg, "worktree", false, true, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"git", "worktree", "remove", "--force", "--force", wtPath}
+		if len(svc.checkedCommands) == 0 || strings.Join(svc.checkedCommands[0], " ") != strings.Join(want, " ") {
+			t.Fatalf("expected %v, got %v", want, svc.checkedCommands)
+		}
+	})
+}
+
+func TestLockUnlockWorktree(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.AppConfig{WorktreeDir: "/worktrees"}
+	newSvc := func(locked bool) *fakeGitService {
+		return &fakeGitService{
+			resolveRepoName: "repo",
+			worktrees: []*models.WorktreeInfo{
+				{Path: "/main", Branch: "main", IsMain: true},
+				{Path: "/worktrees/repo/feature", Branch: "feature", Locked: locked},
+			},
+			runCommandCheckedOK: true,
+		}
+	}
+
+	svc := newSvc(false)
+	if err := LockWorktree(ctx, svc, cfg, "feature", "  on usb  "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(svc.checkedCommands[0], " "); got != "git worktree lock --reason on usb /worktrees/repo/feature" {
+		t.Fatalf("unexpected lock command: %s", got)
+	}
+	if err := UnlockWorktree(ctx, svc, cfg, "feature"); err == nil {
+		t.Fatal("expected error unlocking an unlocked worktree")
+	}
+	if err := LockWorktree(ctx, svc, cfg, "main", ""); err == nil {
+		t.Fatal("expected error locking the main worktree")
+	}
+
+	svc = newSvc(true)
+	if err := LockWorktree(ctx, svc, cfg, "feature", ""); err == nil {
+		t.Fatal("expected error locking a locked worktree")
+	}
+	if err := UnlockWorktree(ctx, svc, cfg, "/worktrees/repo/feature"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(svc.checkedCommands[0], " "); got != "git worktree unlock /worktrees/repo/feature" {
+		t.Fatalf("unexpected unlock command: %s", got)
+	}
 }
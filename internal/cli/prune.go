@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// Prune sources select how merged worktrees are detected.
+const (
+	PruneSourcePR   = "pr"
+	PruneSourceGit  = "git"
+	PruneSourceBoth = "both"
+)
+
+// Prune result statuses.
+const (
+	PruneStatusPruned     = "pruned"
+	PruneStatusWouldPrune = "would prune"
+	PruneStatusSkipped    = "skipped"
+	PruneStatusFailed     = "failed"
+)
+
+// pruneGitService is the subset of git operations needed to prune merged worktrees.
+type pruneGitService interface {
+	worktreeGitService
+	FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error)
+}
+
+var _ pruneGitService = (*git.Service)(nil)
+
+// PruneResult records what happened to a single prune candidate.
+type PruneResult struct {
+	Worktree *models.WorktreeInfo
+	Source   string
+	Status   string
+	Reason   string
+}
+
+// ValidatePruneSource returns an error when source is not a known prune source.
+func ValidatePruneSource(source string) error {
+	switch source {
+	case PruneSourcePR, PruneSourceGit, PruneSourceBoth:
+		return nil
+	default:
+		return fmt.Errorf("invalid --source %q: must be one of pr, git, both", source)
+	}
+}
+
+// FindPruneCandidates returns worktrees whose branch has been merged, using the
+// same detection as the TUI prune screen. source restricts detection to merged
+// PRs ("pr"), branches merged into the main branch ("git") or either ("both").
+func FindPruneCandidates(ctx context.Context, gitSvc pruneGitService, cfg *config.AppConfig, source string) ([]appservices.PruneCandidate, error) {
+	if err := ValidatePruneSource(source); err != nil {
+		return nil, err
+	}
+	if source == PruneSourcePR && cfg.DisablePR {
+		return nil, fmt.Errorf("PR detection is disabled in config (disable_pr)")
+	}
+
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+
+	if source != PruneSourceGit && !cfg.DisablePR {
+		prMap, err := gitSvc.FetchPRMap(ctx)
+		if err != nil {
+			if source == PruneSourcePR {
+				return nil, fmt.Errorf("failed to fetch PRs: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch PRs, falling back to git detection: %v\n", err)
+			prMap = nil
+		}
+		for _, wt := range worktrees {
+			if pr, ok := prMap[wt.Branch]; ok {
+				wt.PR = pr
+			}
+		}
+	}
+
+	candidates, err := appservices.NewWorktreeService(gitSvc).GetPruneCandidates(ctx, worktrees)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]appservices.PruneCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if source == PruneSourceBoth || c.Source == PruneSourceBoth || c.Source == source {
+			filtered = append(filtered, c)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Worktree.Path < filtered[j].Worktree.Path
+	})
+
+	return filtered, nil
+}
+
+// PruneSkipReason returns why a candidate must not be pruned automatically, or
+// an empty string when it is safe to remove.
+func PruneSkipReason(wt *models.WorktreeInfo) string {
+	if wt.Dirty || wt.Untracked > 0 || wt.Modified > 0 || wt.Staged > 0 {
+		return "uncommitted changes"
+	}
+	return ""
+}
+
+// PruneWorktrees removes the given candidates. Terminate commands run for each
+// worktree with the same trust checks as DeleteWorktree, and the branch is
+// deleted unless keepBranch is set. Candidates with uncommitted changes are
+// skipped.
+func PruneWorktrees(ctx context.Context, gitSvc pruneGitService, cfg *config.AppConfig, candidates []appservices.PruneCandidate, keepBranch, silent bool) []PruneResult {
+	results := make([]PruneResult, 0, len(candidates))
+	if len(candidates) == 0 {
+		return results
+	}
+
+	// Clean up git's internal tracking of worktrees that no longer exist on disk
+	gitSvc.RunGit(ctx, []string{"git", "worktree", "prune"}, "", []int{0}, true, true)
+
+	for _, c := range candidates {
+		wt := c.Worktree
+		result := PruneResult{Worktree: wt, Source: c.Source}
+
+		if reason := PruneSkipReason(wt); reason != "" {
+			result.Status = PruneStatusSkipped
+			result.Reason = reason
+			results = append(results, result)
+			continue
+		}
+
+		if err := runTerminateCommands(ctx, gitSvc, cfg, wt.Branch, wt.Path, silent); err != nil && !silent {
+			fmt.Fprintf(os.Stderr, "Warning: terminate commands failed for %s: %v\n", wt.Path, err)
+		}
+
+		if err := appservices.NewWorktreeService(gitSvc).Delete(ctx, wt.Path, wt.Branch, !keepBranch); err != nil {
+			result.Status = PruneStatusFailed
+			result.Reason = err.Error()
+		} else {
+			result.Status = PruneStatusPruned
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+type fakePruneGitService struct {
+	*fakeGitService
+	mainBranch     string
+	mergedBranches []string
+	prMap          map[string]*models.PRInfo
+	prMapErr       error
+	prMapFetched   bool
+	failCommand    string
+	commands       []string
+	combinedOutput string
+	combinedErr    error
+}
+
+func (f *fakePruneGitService) GetMainBranch(_ context.Context) string {
+	return f.mainBranch
+}
+
+func (f *fakePruneGitService) GetMergedBranches(_ context.Context, _ string) []string {
+	return f.mergedBranches
+}
+
+func (f *fakePruneGitService) FetchPRMap(_ context.Context) (map[string]*models.PRInfo, error) {
+	f.prMapFetched = true
+	return f.prMap, f.prMapErr
+}
+
+func (f *fakePruneGitService) RunGitWithCombinedOutput(_ context.Context, args []string, _ string, _ map[string]string) ([]byte, error) {
+	f.commands = append(f.commands, strings.Join(args, " "))
+	return []byte(f.combinedOutput), f.combinedErr
+}
+
+func (f *fakePruneGitService) RunCommandChecked(_ context.Context, args []string, _, _ string) bool {
+	command := strings.Join(args, " ")
+	f.commands = append(f.commands, command)
+	return f.failCommand == "" || !strings.Contains(command, f.failCommand)
+}
+
+func newFakePruneGitService() *fakePruneGitService {
+	return &fakePruneGitService{
+		fakeGitService: &fakeGitService{
+			resolveRepoName: testRepoName,
+			worktrees: []*models.WorktreeInfo{
+				{Path: "/wt/main", Branch: "main", IsMain: true},
+				{Path: "/wt/repo/pr-only", Branch: "pr-only"},
+				{Path: "/wt/repo/git-only", Branch: "git-only"},
+				{Path: "/wt/repo/merged", Branch: "merged"},
+				{Path: "/wt/repo/active", Branch: "active"},
+			},
+		},
+		mainBranch:     "main",
+		mergedBranches: []string{"main", "git-only", "merged"},
+		prMap: map[string]*models.PRInfo{
+			"pr-only": {Number: 1, State: "MERGED"},
+			"merged":  {Number: 2, State: "MERGED"},
+			"active":  {Number: 3, State: "OPEN"},
+		},
+	}
+}
+
+func pruneCandidateSources(t *testing.T, svc *fakePruneGitService, cfg *config.AppConfig, source string) map[string]string {
+	t.Helper()
+	candidates, err := FindPruneCandidates(context.Background(), svc, cfg, source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[string]string, len(candidates))
+	for _, c := range candidates {
+		got[c.Worktree.Branch] = c.Source
+	}
+	return got
+}
+
+func TestFindPruneCandidates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		source string
+		want   map[string]string
+	}{
+		{
+			name:   "both",
+			source: PruneSourceBoth,
+			want:   map[string]string{"pr-only": "pr", "git-only": "git", "merged": "both"},
+		},
+		{
+			name:   "pr only",
+			source: PruneSourcePR,
+			want:   map[string]string{"pr-only": "pr", "merged": "both"},
+		},
+		{
+			name:   "git only",
+			source: PruneSourceGit,
+			want:   map[string]string{"git-only": "git", "merged": "git"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := newFakePruneGitService()
+			svc.worktrees = append(svc.worktrees, &models.WorktreeInfo{Path: "/wt/repo/locked", Branch: "locked", Locked: true})
+			svc.mergedBranches = append(svc.mergedBranches, "locked")
+			svc.prMap["locked"] = &models.PRInfo{Number: 4, State: "MERGED"}
+			got := pruneCandidateSources(t, svc, &config.AppConfig{}, tt.source)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d candidates, got %v", len(tt.want), got)
+			}
+			for branch, source := range tt.want {
+				if got[branch] != source {
+					t.Errorf("branch %s: expected source %q, got %q", branch, source, got[branch])
+				}
+			}
+			if tt.source == PruneSourceGit && svc.prMapFetched {
+				t.Error("expected PRs not to be fetched for git source")
+			}
+		})
+	}
+}
+
+func TestFindPruneCandidatesErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid source", func(t *testing.T) {
+		_, err := FindPruneCandidates(context.Background(), newFakePruneGitService(), &config.AppConfig{}, "nope")
+		if err == nil {
+			t.Fatal("expected error for invalid source")
+		}
+	})
+
+	t.Run("pr source with PRs disabled", func(t *testing.T) {
+		_, err := FindPruneCandidates(context.Background(), newFakePruneGitService(), &config.AppConfig{DisablePR: true}, PruneSourcePR)
+		if err == nil {
+			t.Fatal("expected error when PR detection is disabled")
+		}
+	})
+
+	t.Run("pr fetch failure falls back to git", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.prMapErr = errors.New("gh not authenticated")
+		got := pruneCandidateSources(t, svc, &config.AppConfig{}, PruneSourceBoth)
+		if len(got) != 2 || got["git-only"] != "git" || got["merged"] != "git" {
+			t.Fatalf("expected git-only candidates, got %v", got)
+		}
+	})
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes worktree and branch, skips dirty", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.worktrees[2].Dirty = true
+		candidates, err := FindPruneCandidates(context.Background(), svc, &config.AppConfig{}, PruneSourceBoth)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results := PruneWorktrees(context.Background(), svc, &config.AppConfig{}, candidates, false, true)
+		statuses := make(map[string]string, len(results))
+		for _, r := range results {
+			statuses[r.Worktree.Branch] = r.Status
+		}
+		if statuses["git-only"] != PruneStatusSkipped {
+			t.Errorf("expected dirty worktree to be skipped, got %q", statuses["git-only"])
+		}
+		if statuses["merged"] != PruneStatusPruned || statuses["pr-only"] != PruneStatusPruned {
+			t.Errorf("expected clean worktrees to be pruned, got %v", statuses)
+		}
+
+		joined := strings.Join(svc.commands, "\n")
+		for _, want := range []string{"git worktree remove --force /wt/repo/merged", "git branch -D merged"} {
+			if !strings.Contains(joined, want) {
+				t.Errorf("expected command %q, got:\n%s", want, joined)
+			}
+		}
+		if strings.Contains(joined, "git-only") {
+			t.Errorf("dirty worktree should not be touched, got:\n%s", joined)
+		}
+	})
+
+	t.Run("keep branch", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		candidates, err := FindPruneCandidates(context.Background(), svc, &config.AppConfig{}, PruneSourceGit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		PruneWorktrees(context.Background(), svc, &config.AppConfig{}, candidates, true, true)
+		for _, command := range svc.commands {
+			if strings.Contains(command, "branch -D") {
+				t.Errorf("expected no branch deletion, got %q", command)
+			}
+		}
+	})
+
+	t.Run("reports failures", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.failCommand = "/wt/repo/merged"
+		candidates, err := FindPruneCandidates(context.Background(), svc, &config.AppConfig{}, PruneSourceGit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results := PruneWorktrees(context.Background(), svc, &config.AppConfig{}, candidates, false, true)
+		for _, r := range results {
+			if r.Worktree.Branch == "merged" && (r.Status != PruneStatusFailed || r.Reason == "") {
+				t.Errorf("expected failure with reason for merged, got %+v", r)
+			}
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+)
+
+// Repair result statuses.
+const (
+	RepairStatusFixed  = "fixed"
+	RepairStatusFailed = "failed"
+)
+
+// RepairResult records the fix applied to a single health issue.
+type RepairResult struct {
+	Issue  appservices.HealthIssue
+	Fix    appservices.HealthFix
+	Status string
+	Reason string
+}
+
+// ScanWorktreeHealth returns the health issues of the current repository and
+// of its worktree directory, with the same checks as the TUI screen.
+func ScanWorktreeHealth(ctx context.Context, gitSvc worktreeGitService, cfg *config.AppConfig) ([]appservices.HealthIssue, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	root, dedicated := LoadWorktreeLayout(ctx, gitSvc, cfg).Root()
+	if !dedicated {
+		root = ""
+	}
+	return appservices.ScanWorktreeHealth(worktrees, root), nil
+}
+
+// RepairFix returns the fix repair applies to issue: its default fix, except
+// for orphaned directories which are removed instead of adopted when
+// removeOrphans is set.
+func RepairFix(issue appservices.HealthIssue, removeOrphans bool) appservices.HealthFix {
+	if removeOrphans && issue.HasFix(appservices.HealthFixRemove) {
+		return appservices.HealthFixRemove
+	}
+	return issue.DefaultFix()
+}
+
+// RepairWorktrees applies a fix to each issue, in the order returned by
+// ScanWorktreeHealth.
+func RepairWorktrees(ctx context.Context, gitSvc worktreeGitService, issues []appservices.HealthIssue, removeOrphans bool) []RepairResult {
+	svc := appservices.NewWorktreeService(gitSvc)
+	results := make([]RepairResult, 0, len(issues))
+	for _, issue := range issues {
+		result := RepairResult{Issue: issue, Fix: RepairFix(issue, removeOrphans), Status: RepairStatusFixed}
+		if err := svc.FixHealthIssue(ctx, issue, result.Fix); err != nil {
+			result.Status = RepairStatusFailed
+			result.Reason = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestScanWorktreeHealthUsesRepoWorktreeDir(t *testing.T) {
+	t.Parallel()
+
+	wtDir := t.TempDir()
+	orphan := filepath.Join(wtDir, testRepoName, "orphan")
+	if err := os.MkdirAll(orphan, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := newFakePruneGitService()
+	svc.worktrees = []*models.WorktreeInfo{{Path: "/wt/main", Branch: "main", IsMain: true}}
+	issues, err := ScanWorktreeHealth(context.Background(), svc, &config.AppConfig{WorktreeDir: wtDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Kind != appservices.HealthOrphanedDir || issues[0].Path != orphan {
+		t.Fatalf("expected orphaned directory, got %+v", issues)
+	}
+}
+
+func TestRepairWorktrees(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	orphan := filepath.Join(dir, "orphan")
+	if err := os.MkdirAll(orphan, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	issues := []appservices.HealthIssue{
+		{Kind: appservices.HealthBrokenGitdir, Path: "/wt/repo/moved", Fixes: []appservices.HealthFix{appservices.HealthFixRepair}},
+		{Kind: appservices.HealthOrphanedDir, Path: orphan, Fixes: []appservices.HealthFix{appservices.HealthFixAdopt, appservices.HealthFixRemove}},
+		{Kind: appservices.HealthPrunable, Path: "/wt/repo/gone", Fixes: []appservices.HealthFix{appservices.HealthFixPrune}},
+	}
+
+	svc := newFakePruneGitService()
+	svc.failCommand = "prune"
+	results := RepairWorktrees(context.Background(), svc, issues, true)
+
+	if got := strings.Join(svc.commands, "; "); got != "git worktree repair /wt/repo/moved; git worktree prune" {
+		t.Fatalf("unexpected commands: %s", got)
+	}
+	if results[1].Fix != appservices.HealthFixRemove || results[1].Status != RepairStatusFixed {
+		t.Fatalf("expected orphan to be removed, got %+v", results[1])
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Fatalf("expected orphan directory to be removed, got %v", err)
+	}
+	if results[2].Status != RepairStatusFailed || results[2].Reason == "" {
+		t.Fatalf("expected prune to fail, got %+v", results[2])
+	}
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// stashGitService is the subset of git operations used to manage stashes,
+// which every worktree of a repository shares.
+type stashGitService interface {
+	gitService
+	ListStashes(ctx context.Context) []*models.StashEntry
+	ApplyStash(ctx context.Context, stash *models.StashEntry, path string, pop bool) error
+	DropStash(ctx context.Context, stash *models.StashEntry) error
+	TransferChanges(ctx context.Context, sourcePath, targetPath, message string) error
+}
+
+var _ stashGitService = (*git.Service)(nil)
+
+// ShowStash returns the patch of the stash matching stashRef.
+func ShowStash(ctx context.Context, gitSvc stashGitService, stashRef string) (string, error) {
+	stash, err := git.FindStash(gitSvc.ListStashes(ctx), stashRef)
+	if err != nil {
+		return "", err
+	}
+	return gitSvc.RunGit(ctx, []string{"git", "stash", "show", "--patch", "--stat", stash.SHA}, "", []int{0}, false, false), nil
+}
+
+// ApplyStash applies the stash matching stashRef to a worktree, dropping it
+// afterwards with pop. It returns the stash and the worktree.
+func ApplyStash(ctx context.Context, gitSvc stashGitService, cfg *config.AppConfig, stashRef, worktreePath string, pop bool) (*models.StashEntry, *models.WorktreeInfo, error) {
+	stash, err := git.FindStash(gitSvc.ListStashes(ctx), stashRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	wt, err := findStashWorktree(ctx, gitSvc, cfg, worktreePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := gitSvc.ApplyStash(ctx, stash, wt.Path, pop); err != nil {
+		return nil, nil, err
+	}
+	return stash, wt, nil
+}
+
+// DropStash deletes the stash matching stashRef and returns it.
+func DropStash(ctx context.Context, gitSvc stashGitService, stashRef string) (*models.StashEntry, error) {
+	stash, err := git.FindStash(gitSvc.ListStashes(ctx), stashRef)
+	if err != nil {
+		return nil, err
+	}
+	return stash, gitSvc.DropStash(ctx, stash)
+}
+
+// TransferChanges stashes the changes of the source worktree and applies
+// them in the target one. The message defaults to one naming the target.
+func TransferChanges(ctx context.Context, gitSvc stashGitService, cfg *config.AppConfig, sourcePath, targetPath, message string) (*models.WorktreeInfo, error) {
+	source, err := findStashWorktree(ctx, gitSvc, cfg, sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findStashWorktree(ctx, gitSvc, cfg, targetPath)
+	if err != nil {
+		return nil, err
+	}
+	if source.Path == target.Path {
+		return nil, fmt.Errorf("source and target are the same worktree: %s", source.Path)
+	}
+	if message = strings.TrimSpace(message); message == "" {
+		message = git.TransferStashMessage(target.Path)
+	}
+	if err := gitSvc.TransferChanges(ctx, source.Path, target.Path, message); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func findStashWorktree(ctx context.Context, gitSvc stashGitService, cfg *config.AppConfig, worktreePath string) (*models.WorktreeInfo, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	return FindWorktreeByPathOrName(worktreePath, worktrees, LoadWorktreeLayout(ctx, gitSvc, cfg))
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+type fakeStashGitService struct {
+	*fakeGitService
+	stashes  []*models.StashEntry
+	commands []string
+}
+
+func (f *fakeStashGitService) ListStashes(_ context.Context) []*models.StashEntry {
+	return f.stashes
+}
+
+func (f *fakeStashGitService) ApplyStash(_ context.Context, stash *models.StashEntry, path string, pop bool) error {
+	action := "apply"
+	if pop {
+		action = "pop"
+	}
+	f.commands = append(f.commands, action+" "+stash.Ref+" "+path)
+	return nil
+}
+
+func (f *fakeStashGitService) DropStash(_ context.Context, stash *models.StashEntry) error {
+	f.commands = append(f.commands, "drop "+stash.Ref)
+	return nil
+}
+
+func (f *fakeStashGitService) TransferChanges(_ context.Context, sourcePath, targetPath, message string) error {
+	f.commands = append(f.commands, "transfer "+sourcePath+" "+targetPath+" "+message)
+	return nil
+}
+
+func newFakeStashGitService() *fakeStashGitService {
+	return &fakeStashGitService{
+		fakeGitService: &fakeGitService{
+			resolveRepoName: testRepoName,
+			worktrees: []*models.WorktreeInfo{
+				{Path: "/wt/main", Branch: "main", IsMain: true},
+				{Path: "/wt/repo/feature", Branch: "feature"},
+			},
+		},
+		stashes: []*models.StashEntry{
+			{Ref: "stash@{0}", SHA: "abc1234567", Branch: "feature", Message: "wip"},
+			{Ref: "stash@{1}", SHA: "def1234567", Branch: "main", Message: "old"},
+		},
+	}
+}
+
+func TestApplyStash(t *testing.T) {
+	t.Parallel()
+	svc := newFakeStashGitService()
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+
+	stash, wt, err := ApplyStash(context.Background(), svc, cfg, "1", "feature", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stash.SHA != "def1234567" || wt.Path != "/wt/repo/feature" {
+		t.Fatalf("unexpected stash %+v or worktree %+v", stash, wt)
+	}
+	if got := strings.Join(svc.commands, "; "); got != "pop stash@{1} /wt/repo/feature" {
+		t.Fatalf("unexpected commands: %s", got)
+	}
+
+	if _, _, err := ApplyStash(context.Background(), svc, cfg, "stash@{9}", "feature", false); err == nil {
+		t.Fatal("expected an error for an unknown stash")
+	}
+}
+
+func TestDropStash(t *testing.T) {
+	t.Parallel()
+	svc := newFakeStashGitService()
+
+	stash, err := DropStash(context.Background(), svc, "abc1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stash.Ref != "stash@{0}" || len(svc.commands) != 1 || svc.commands[0] != "drop stash@{0}" {
+		t.Fatalf("unexpected drop of %+v: %v", stash, svc.commands)
+	}
+}
+
+func TestTransferChanges(t *testing.T) {
+	t.Parallel()
+	svc := newFakeStashGitService()
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+
+	target, err := TransferChanges(context.Background(), svc, cfg, "/wt/main", "feature", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != "/wt/repo/feature" {
+		t.Fatalf("unexpected target %s", target.Path)
+	}
+	if got := strings.Join(svc.commands, "; "); got != "transfer /wt/main /wt/repo/feature lazyworktree: move to feature" {
+		t.Fatalf("unexpected commands: %s", got)
+	}
+
+	if _, err := TransferChanges(context.Background(), svc, cfg, "feature", "/wt/repo/feature", ""); err == nil {
+		t.Fatal("expected an error when source and target are the same worktree")
+	}
+}
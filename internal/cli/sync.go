@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// Merge methods accepted by absorb and sync.
+const (
+	MergeMethodRebase = "rebase"
+	MergeMethodMerge  = "merge"
+)
+
+// Operation result statuses shared by the absorb and sync subcommands.
+const (
+	OperationStatusOK      = "ok"
+	OperationStatusFailed  = "failed"
+	OperationStatusSkipped = "skipped"
+)
+
+// worktreeGitService is the set of git operations needed to drive a WorktreeService from the CLI.
+type worktreeGitService interface {
+	gitService
+	appservices.GitService
+}
+
+var _ worktreeGitService = (*git.Service)(nil)
+
+// OperationResult records the outcome of an operation on a single worktree.
+type OperationResult struct {
+	Worktree *models.WorktreeInfo
+	Status   string
+	Message  string
+}
+
+// SyncOptions controls SyncWorktrees.
+type SyncOptions struct {
+	// MergeMethod is used for the pull ("rebase" or "merge") and for updating
+	// from the base branch.
+	MergeMethod string
+	// FromBase updates the branch from its PR base branch instead of pulling
+	// and pushing.
+	FromBase bool
+	// Local updates from the base branch with git fetch and a rebase or merge
+	// rather than gh, so it works on any forge or without one. Implies
+	// FromBase.
+	Local bool
+	// SkipInvalid reports worktrees that cannot be synchronised (dirty,
+	// detached or without upstream) as skipped rather than failed.
+	SkipInvalid bool
+}
+
+// ValidateMergeMethod returns an error when method is not a known merge method.
+func ValidateMergeMethod(method string) error {
+	switch method {
+	case MergeMethodRebase, MergeMethodMerge:
+		return nil
+	default:
+		return fmt.Errorf("invalid merge method %q: must be rebase or merge", method)
+	}
+}
+
+// ResolveMergeMethod returns method, falling back to the configured merge
+// method and then to rebase.
+func ResolveMergeMethod(cfg *config.AppConfig, method string) string {
+	if method = strings.TrimSpace(method); method != "" {
+		return method
+	}
+	if method = strings.TrimSpace(cfg.MergeMethod); method != "" {
+		return method
+	}
+	return MergeMethodRebase
+}
+
+// ResolveSyncTargets returns every worktree with a branch when all is set,
+// otherwise the single worktree matching pathOrName.
+func ResolveSyncTargets(ctx context.Context, gitSvc worktreeGitService, cfg *config.AppConfig, pathOrName string, all bool) ([]*models.WorktreeInfo, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+
+	if all {
+		targets := make([]*models.WorktreeInfo, 0, len(worktrees))
+		for _, wt := range worktrees {
+			if isSyncableBranch(wt.Branch) {
+				targets = append(targets, wt)
+			}
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("no worktrees to synchronise")
+		}
+		return targets, nil
+	}
+
+	wt, err := FindWorktreeByPathOrName(pathOrName, worktrees, LoadWorktreeLayout(ctx, gitSvc, cfg))
+	if err != nil {
+		return nil, err
+	}
+	return []*models.WorktreeInfo{wt}, nil
+}
+
+// SyncWorktrees synchronises each worktree with its upstream (pull + push), or
+// updates it from its base branch when opts.FromBase is set.
+func SyncWorktrees(ctx context.Context, gitSvc worktreeGitService, worktrees []*models.WorktreeInfo, opts SyncOptions) []OperationResult {
+	svc := appservices.NewWorktreeService(gitSvc)
+	mainPath := gitSvc.GetMainWorktreePath(ctx)
+	repoName := gitSvc.ResolveRepoName(ctx)
+
+	results := make([]OperationResult, 0, len(worktrees))
+	opts.FromBase = opts.FromBase || opts.Local
+	for _, wt := range worktrees {
+		result := OperationResult{Worktree: wt}
+
+		remote, branch, reason := syncPreconditions(wt, opts.FromBase)
+		if reason != "" {
+			result.Status = OperationStatusFailed
+			if opts.SkipInvalid {
+				result.Status = OperationStatusSkipped
+			}
+			result.Message = reason
+			results = append(results, result)
+			continue
+		}
+
+		env := buildCommandEnv(wt.Branch, wt.Path, mainPath, repoName)
+		var (
+			output string
+			err    error
+		)
+		switch {
+		case opts.Local:
+			output, err = svc.UpdateFromBaseLocal(ctx, wt, "", opts.MergeMethod, env)
+		case opts.FromBase:
+			output, err = svc.UpdateFromBase(ctx, wt, opts.MergeMethod, env)
+		default:
+			pullArgs := []string{remote, branch}
+			if opts.MergeMethod == MergeMethodRebase {
+				pullArgs = append(pullArgs, "--rebase=true")
+			}
+			output, err = svc.Sync(ctx, wt, pullArgs, []string{remote, fmt.Sprintf("HEAD:%s", branch)}, env)
+		}
+
+		if err != nil {
+			result.Status = OperationStatusFailed
+			result.Message = operationFailureMessage(err, output)
+		} else {
+			result.Status = OperationStatusOK
+			result.Message = "synchronised"
+			if opts.FromBase {
+				result.Message = "updated from base"
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// AbsorbWorktree merges the worktree's branch into the worktree of its base
+// branch, main unless another base was recorded at creation, using
+// mergeMethod, then optionally deletes the worktree. It returns an error when
+// the worktree cannot be resolved; absorb failures are reported in the result.
+func AbsorbWorktree(ctx context.Context, gitSvc worktreeGitService, cfg *config.AppConfig, worktreePath, mergeMethod string, deleteAfter, silent bool) (OperationResult, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return OperationResult{}, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+
+	var mainWorktree *models.WorktreeInfo
+	for _, wt := range worktrees {
+		if wt.IsMain {
+			mainWorktree = wt
+			break
+		}
+	}
+	if mainWorktree == nil {
+		return OperationResult{}, fmt.Errorf("cannot find main worktree")
+	}
+
+	wt, err := FindWorktreeByPathOrName(worktreePath, worktrees, LoadWorktreeLayout(ctx, gitSvc, cfg))
+	if err != nil {
+		return OperationResult{}, err
+	}
+
+	result := OperationResult{Worktree: wt, Status: OperationStatusFailed}
+	mainBranch := gitSvc.GetMainBranch(ctx)
+	baseBranch := appservices.BaseBranch(ctx, gitSvc, wt)
+	target := appservices.BaseWorktree(worktrees, wt, baseBranch, mainBranch)
+	switch {
+	case wt.IsMain:
+		result.Message = "cannot absorb the main worktree"
+		return result, nil
+	case wt.Branch == mainBranch:
+		result.Message = fmt.Sprintf("worktree is on the main branch (%s)", mainBranch)
+		return result, nil
+	case wt.Branch == baseBranch:
+		result.Message = fmt.Sprintf("worktree is on its base branch (%s)", baseBranch)
+		return result, nil
+	case target == nil:
+		result.Message = fmt.Sprintf("base branch %s is not checked out in any worktree", baseBranch)
+		return result, nil
+	case target.Dirty:
+		if target.IsMain {
+			result.Message = fmt.Sprintf("main worktree has uncommitted changes: %s", target.Path)
+		} else {
+			result.Message = fmt.Sprintf("%s worktree has uncommitted changes: %s", target.Branch, target.Path)
+		}
+		return result, nil
+	}
+
+	if !silent {
+		fmt.Fprintf(os.Stderr, "Absorbing %s into %s (%s)...\n", wt.Branch, target.Branch, mergeMethod)
+	}
+	if err := appservices.NewWorktreeService(gitSvc).Absorb(ctx, wt, target, mergeMethod); err != nil {
+		result.Message = err.Error()
+		return result, nil
+	}
+
+	result.Status = OperationStatusOK
+	result.Message = fmt.Sprintf("absorbed into %s", target.Branch)
+	if !deleteAfter {
+		return result, nil
+	}
+
+	if err := DeleteWorktree(ctx, gitSvc, cfg, wt.Path, true, false, silent); err != nil {
+		result.Status = OperationStatusFailed
+		result.Message = fmt.Sprintf("absorbed into %s, but delete failed: %v", target.Branch, err)
+		return result, nil
+	}
+	result.Message += ", deleted"
+	return result, nil
+}
+
+// syncPreconditions returns the upstream remote and branch for wt, or a reason
+// why it cannot be synchronised.
+func syncPreconditions(wt *models.WorktreeInfo, fromBase bool) (string, string, string) {
+	if wt.Dirty || wt.Untracked > 0 || wt.Modified > 0 || wt.Staged > 0 {
+		return "", "", "local changes"
+	}
+	if !isSyncableBranch(wt.Branch) {
+		return "", "", "detached HEAD"
+	}
+	if fromBase {
+		return "", "", ""
+	}
+
+	upstream := strings.TrimSpace(wt.UpstreamBranch)
+	if !wt.HasUpstream || upstream == "" {
+		return "", "", "no upstream configured"
+	}
+	remote, branch, ok := strings.Cut(upstream, "/")
+	if !ok || remote == "" || branch == "" {
+		return "", "", fmt.Sprintf("upstream %q is not in remote/branch format", upstream)
+	}
+	if branch != wt.Branch {
+		return "", "", fmt.Sprintf("upstream %q does not match branch %q", upstream, wt.Branch)
+	}
+	return remote, branch, ""
+}
+
+func isSyncableBranch(branch string) bool {
+	branch = strings.TrimSpace(branch)
+	return branch != "" && branch != "(detached)"
+}
+
+// operationFailureMessage combines an error with the last line of command output.
+func operationFailureMessage(err error, output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return fmt.Sprintf("%v: %s", err, last)
+	}
+	return err.Error()
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestResolveMergeMethod(t *testing.T) {
+	t.Parallel()
+
+	if got := ResolveMergeMethod(&config.AppConfig{}, ""); got != MergeMethodRebase {
+		t.Errorf("expected default %q, got %q", MergeMethodRebase, got)
+	}
+	if got := ResolveMergeMethod(&config.AppConfig{MergeMethod: "merge"}, ""); got != MergeMethodMerge {
+		t.Errorf("expected config method %q, got %q", MergeMethodMerge, got)
+	}
+	if got := ResolveMergeMethod(&config.AppConfig{MergeMethod: "merge"}, "rebase"); got != MergeMethodRebase {
+		t.Errorf("expected flag to win, got %q", got)
+	}
+	if err := ValidateMergeMethod("squash"); err == nil {
+		t.Error("expected error for unknown merge method")
+	}
+}
+
+func TestResolveSyncTargets(t *testing.T) {
+	t.Parallel()
+
+	svc := newFakePruneGitService()
+	svc.worktrees = append(svc.worktrees, &models.WorktreeInfo{Path: "/wt/repo/detached", Branch: "(detached)"})
+
+	all, err := ResolveSyncTargets(context.Background(), svc, &config.AppConfig{}, "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 5 {
+		t.Fatalf("expected 5 targets without the detached worktree, got %d", len(all))
+	}
+
+	single, err := ResolveSyncTargets(context.Background(), svc, &config.AppConfig{}, "/wt/repo/merged/sub/dir", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(single) != 1 || single[0].Branch != "merged" {
+		t.Fatalf("expected merged worktree from cwd, got %v", single)
+	}
+
+	if _, err := ResolveSyncTargets(context.Background(), svc, &config.AppConfig{}, "missing", false); err == nil {
+		t.Fatal("expected error for unknown worktree")
+	}
+}
+
+func TestSyncWorktrees(t *testing.T) {
+	t.Parallel()
+
+	upstream := &models.WorktreeInfo{Path: "/wt/repo/feature", Branch: "feature", HasUpstream: true, UpstreamBranch: "origin/feature"}
+	dirty := &models.WorktreeInfo{Path: "/wt/repo/dirty", Branch: "dirty", Dirty: true, HasUpstream: true, UpstreamBranch: "origin/dirty"}
+	local := &models.WorktreeInfo{Path: "/wt/repo/local", Branch: "local"}
+
+	t.Run("pull and push", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		results := SyncWorktrees(context.Background(), svc, []*models.WorktreeInfo{upstream}, SyncOptions{MergeMethod: MergeMethodRebase})
+		if len(results) != 1 || results[0].Status != OperationStatusOK {
+			t.Fatalf("expected success, got %+v", results)
+		}
+		joined := strings.Join(svc.commands, "\n")
+		for _, want := range []string{"git pull origin feature --rebase=true", "git push origin HEAD:feature"} {
+			if !strings.Contains(joined, want) {
+				t.Errorf("expected %q, got:\n%s", want, joined)
+			}
+		}
+	})
+
+	t.Run("invalid worktrees fail unless skipped", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		results := SyncWorktrees(context.Background(), svc, []*models.WorktreeInfo{dirty, local}, SyncOptions{MergeMethod: MergeMethodMerge})
+		for _, r := range results {
+			if r.Status != OperationStatusFailed {
+				t.Errorf("expected %s to fail, got %+v", r.Worktree.Branch, r)
+			}
+		}
+		results = SyncWorktrees(context.Background(), svc, []*models.WorktreeInfo{dirty, local}, SyncOptions{MergeMethod: MergeMethodMerge, SkipInvalid: true})
+		for _, r := range results {
+			if r.Status != OperationStatusSkipped {
+				t.Errorf("expected %s to be skipped, got %+v", r.Worktree.Branch, r)
+			}
+		}
+		if len(svc.commands) != 0 {
+			t.Errorf("expected no git commands, got %v", svc.commands)
+		}
+	})
+
+	t.Run("from base", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		results := SyncWorktrees(context.Background(), svc, []*models.WorktreeInfo{local}, SyncOptions{MergeMethod: MergeMethodRebase, FromBase: true})
+		if results[0].Status != OperationStatusOK {
+			t.Fatalf("expected success, got %+v", results[0])
+		}
+		if len(svc.commands) != 1 || svc.commands[0] != "gh pr update-branch --rebase" {
+			t.Errorf("expected gh pr update-branch --rebase, got %v", svc.commands)
+		}
+	})
+
+	t.Run("local from base", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.runGitOutput = map[string]string{filepath.Join("git", "remote"): "upstream\norigin"}
+		results := SyncWorktrees(context.Background(), svc, []*models.WorktreeInfo{local}, SyncOptions{MergeMethod: MergeMethodRebase, Local: true})
+		if results[0].Status != OperationStatusOK {
+			t.Fatalf("expected success, got %+v", results[0])
+		}
+		if got := strings.Join(svc.commands, "\n"); got != "git fetch origin main\ngit rebase origin/main" {
+			t.Errorf("expected fetch and rebase onto origin/main, got:\n%s", got)
+		}
+	})
+
+	t.Run("failure includes output", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.combinedErr = errors.New("exit status 1")
+		svc.combinedOutput = "hint: something\nfatal: could not read from remote"
+		results := SyncWorktrees(context.Background(), svc, []*models.WorktreeInfo{upstream}, SyncOptions{})
+		if results[0].Status != OperationStatusFailed || !strings.Contains(results[0].Message, "could not read from remote") {
+			t.Fatalf("expected failure with output, got %+v", results[0])
+		}
+	})
+}
+
+func TestAbsorbWorktree(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := &config.AppConfig{WorktreeDir: "/wt"}
+
+	t.Run("rebase", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		result, err := AbsorbWorktree(ctx, svc, cfg, "merged", MergeMethodRebase, false, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != OperationStatusOK {
+			t.Fatalf("expected success, got %+v", result)
+		}
+		joined := strings.Join(svc.commands, "\n")
+		for _, want := range []string{"git -C /wt/repo/merged rebase main", "git -C /wt/main merge --ff-only merged"} {
+			if !strings.Contains(joined, want) {
+				t.Errorf("expected %q, got:\n%s", want, joined)
+			}
+		}
+	})
+
+	t.Run("merge and delete", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		result, err := AbsorbWorktree(ctx, svc, cfg, "merged", MergeMethodMerge, true, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != OperationStatusOK || !strings.Contains(result.Message, "deleted") {
+			t.Fatalf("expected absorbed and deleted, got %+v", result)
+		}
+		joined := strings.Join(svc.commands, "\n")
+		for _, want := range []string{"git -C /wt/main merge --no-edit merged", "git worktree remove --force /wt/repo/merged", "git branch -D merged"} {
+			if !strings.Contains(joined, want) {
+				t.Errorf("expected %q, got:\n%s", want, joined)
+			}
+		}
+	})
+
+	t.Run("refuses dirty main", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.worktrees[0].Dirty = true
+		result, err := AbsorbWorktree(ctx, svc, cfg, "merged", MergeMethodMerge, false, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != OperationStatusFailed || len(svc.commands) != 0 {
+			t.Fatalf("expected failure without commands, got %+v, %v", result, svc.commands)
+		}
+	})
+
+	t.Run("into recorded base", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.worktrees[3].BaseRef = "active"
+		svc.runGitOutput = map[string]string{filepath.Join("git", "rev-parse", "--verify", "--quiet", "refs/heads/active"): "abc123"}
+		result, err := AbsorbWorktree(ctx, svc, cfg, "merged", MergeMethodMerge, false, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != OperationStatusOK || result.Message != "absorbed into active" {
+			t.Fatalf("expected absorbed into active, got %+v", result)
+		}
+		if len(svc.commands) != 1 || svc.commands[0] != "git -C /wt/repo/active merge --no-edit merged" {
+			t.Errorf("expected a merge into the active worktree, got %v", svc.commands)
+		}
+
+		svc = newFakePruneGitService()
+		svc.worktrees[3].BaseRef = "release"
+		svc.runGitOutput = map[string]string{filepath.Join("git", "rev-parse", "--verify", "--quiet", "refs/remotes/release"): "abc123"}
+		result, _ = AbsorbWorktree(ctx, svc, cfg, "merged", MergeMethodMerge, false, true)
+		if result.Status != OperationStatusFailed || !strings.Contains(result.Message, "not checked out") {
+			t.Fatalf("expected failure for a base without worktree, got %+v", result)
+		}
+	})
+
+	t.Run("merge conflict", func(t *testing.T) {
+		svc := newFakePruneGitService()
+		svc.failCommand = "merge --no-edit"
+		result, err := AbsorbWorktree(ctx, svc, cfg, "merged", MergeMethodMerge, true, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status != OperationStatusFailed || strings.Contains(strings.Join(svc.commands, "\n"), "worktree remove") {
+			t.Fatalf("expected failure without delete, got %+v, %v", result, svc.commands)
+		}
+	})
+
+	t.Run("unknown worktree", func(t *testing.T) {
+		if _, err := AbsorbWorktree(ctx, newFakePruneGitService(), cfg, "missing", MergeMethodMerge, false, true); err == nil {
+			t.Fatal("expected error for unknown worktree")
+		}
+	})
+}
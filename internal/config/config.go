@@ -3,9 +3,11 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -98,6 +100,7 @@ type AppConfig struct {
 	BranchNameScript        string // Script to generate branch name suggestions from diff
 	WorktreeNoteScript      string // Script to generate worktree notes from PR/issue content
 	WorktreeNotesPath       string // Optional path to a single shared JSON file for worktree notes
+	WorktreePathTemplate    string // Template for worktree paths, see WorktreePathPlaceholders (default: "{worktree_dir}/{repo_key}/{name}")
 	Theme                   string // Theme name: see AvailableThemes in internal/theme
 	MergeMethod             string // Merge method for absorb: "rebase" or "merge" (default: "rebase")
 	FuzzyFinderInput        bool   // Enable fuzzy finder for input suggestions (default: false)
@@ -109,6 +112,9 @@ type AppConfig struct {
 	PaletteMRU              bool   // Enable MRU sorting for command palette (default: false)
 	PaletteMRULimit         int    // Number of MRU items to show (default: 5)
 	CustomCreateMenus       []*CustomCreateMenu
+	SparseProfiles          map[string][]string     // Named sparse-checkout profiles, each a list of cone directories
+	SubmoduleUpdate         bool                    // Initialise and update submodules recursively in new worktrees
+	SubmoduleReference      bool                    // Borrow objects from the submodules of the main worktree when updating them
 	CustomThemes            map[string]*CustomTheme // User-defined custom themes
 	ConfigPath              string                  `yaml:"-"` // Path to the configuration file
 }
@@ -117,7 +123,12 @@ type AppConfig struct {
 type RepoConfig struct {
 	InitCommands      []string
 	TerminateCommands []string
-	Path              string
+	SparseProfiles    map[string][]string
+	// SubmoduleUpdate and SubmoduleReference override the global settings
+	// when set.
+	SubmoduleUpdate    *bool
+	SubmoduleReference *bool
+	Path               string
 }
 
 // DefaultConfig returns the default configuration values.
@@ -175,6 +186,27 @@ func DefaultConfig() *AppConfig {
 
 var iconSetOptions = []string{"nerd-font-v3", "text"}
 
+// WorktreePathPlaceholders lists the placeholders accepted by
+// worktree_path_template.
+var WorktreePathPlaceholders = []string{"{worktree_dir}", "{repo_key}", "{repo_parent}", "{repo}", "{host}", "{owner}", "{branch}", "{name}"}
+
+var worktreePathPlaceholderRe = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateWorktreePathTemplate reports whether template only uses known
+// placeholders and contains {name} or {branch}, so each worktree gets its own
+// path.
+func ValidateWorktreePathTemplate(template string) error {
+	for _, placeholder := range worktreePathPlaceholderRe.FindAllString(template, -1) {
+		if !slices.Contains(WorktreePathPlaceholders, placeholder) {
+			return fmt.Errorf("unknown placeholder %s (available: %s)", placeholder, strings.Join(WorktreePathPlaceholders, ", "))
+		}
+	}
+	if !strings.Contains(template, "{name}") && !strings.Contains(template, "{branch}") {
+		return fmt.Errorf("template must contain {name} or {branch}")
+	}
+	return nil
+}
+
 // IconsEnabled reports whether icon rendering should be enabled for the current icon set.
 func (c *AppConfig) IconsEnabled() bool {
 	iconSet := strings.ToLower(strings.TrimSpace(c.IconSet))
@@ -247,6 +279,8 @@ func parseConfig(data map[string]any) (*AppConfig, error) {
 	cfg.RefreshIntervalSeconds = coerceInt(data["refresh_interval"], cfg.RefreshIntervalSeconds)
 	cfg.SearchAutoSelect = coerceBool(data["search_auto_select"], false)
 	cfg.FuzzyFinderInput = coerceBool(data["fuzzy_finder_input"], false)
+	cfg.SubmoduleUpdate = coerceBool(data["submodule_update"], false)
+	cfg.SubmoduleReference = coerceBool(data["submodule_reference"], false)
 
 	if iconSet, ok := data["icon_set"].(string); ok {
 		iconSet = strings.ToLower(strings.TrimSpace(iconSet))
@@ -331,6 +365,13 @@ func parseConfig(data map[string]any) (*AppConfig, error) {
 		}
 	}
 
+	if worktreePathTemplate, ok := data["worktree_path_template"].(string); ok {
+		worktreePathTemplate = strings.TrimSpace(worktreePathTemplate)
+		if worktreePathTemplate != "" && ValidateWorktreePathTemplate(worktreePathTemplate) == nil {
+			cfg.WorktreePathTemplate = worktreePathTemplate
+		}
+	}
+
 	if issueBranchNameTemplate, ok := data["issue_branch_name_template"].(string); ok {
 		issueBranchNameTemplate = strings.TrimSpace(issueBranchNameTemplate)
 		if issueBranchNameTemplate != "" {
@@ -397,6 +438,10 @@ func parseConfig(data map[string]any) (*AppConfig, error) {
 		cfg.CustomThemes = parseCustomThemes(data)
 	}
 
+	if _, ok := data["sparse_profiles"]; ok {
+		cfg.SparseProfiles = parseSparseProfiles(data["sparse_profiles"])
+	}
+
 	return cfg, nil
 }
 
@@ -436,6 +481,73 @@ func parseCustomCommands(data map[string]any) map[string]*CustomCommand {
 	return cmds
 }
 
+// parseSparseProfiles reads a mapping of profile names to cone directories,
+// given as a list or a single string. Leading and trailing slashes are
+// dropped, as are entries that would be read as git options.
+func parseSparseProfiles(val any) map[string][]string {
+	raw, ok := val.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	profiles := make(map[string][]string)
+	for name, dirs := range raw {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		var cleaned []string
+		for _, dir := range normalizeCommandList(dirs) {
+			dir = strings.Trim(dir, "/")
+			if dir == "" || strings.HasPrefix(dir, "-") {
+				continue
+			}
+			cleaned = append(cleaned, dir)
+		}
+		if len(cleaned) > 0 {
+			profiles[name] = cleaned
+		}
+	}
+	return profiles
+}
+
+// SparseProfiles returns the sparse-checkout profiles available in a
+// repository: those of cfg, overridden by the profiles of the same name in
+// its .wt file. repoCfg may be nil.
+func SparseProfiles(cfg *AppConfig, repoCfg *RepoConfig) map[string][]string {
+	profiles := make(map[string][]string)
+	if cfg != nil {
+		maps.Copy(profiles, cfg.SparseProfiles)
+	}
+	if repoCfg != nil {
+		maps.Copy(profiles, repoCfg.SparseProfiles)
+	}
+	return profiles
+}
+
+// SubmoduleSettings returns whether submodules are updated in new worktrees
+// of a repository, and whether the update borrows objects from the main
+// worktree, the .wt file taking precedence over cfg. repoCfg may be nil.
+func SubmoduleSettings(cfg *AppConfig, repoCfg *RepoConfig) (update, reference bool) {
+	if cfg != nil {
+		update, reference = cfg.SubmoduleUpdate, cfg.SubmoduleReference
+	}
+	if repoCfg != nil {
+		if repoCfg.SubmoduleUpdate != nil {
+			update = *repoCfg.SubmoduleUpdate
+		}
+		if repoCfg.SubmoduleReference != nil {
+			reference = *repoCfg.SubmoduleReference
+		}
+	}
+	return update, reference
+}
+
+// SparseProfileNames returns the names of profiles in sorted order.
+func SparseProfileNames(profiles map[string][]string) []string {
+	return slices.Sorted(maps.Keys(profiles))
+}
+
 func parseTmuxCommand(data map[string]any) *TmuxCommand {
 	cmd := &TmuxCommand{
 		SessionName: getString(data, "session_name"),
@@ -733,29 +845,7 @@ func normalizeArgsList(val any) []string {
 
 // loadYAMLFile loads YAML config file and returns parsed data.
 func loadYAMLFile(configPath string) map[string]any {
-	configBase := filepath.Join(getConfigDir(), "lazyworktree")
-	configBase = filepath.Clean(configBase)
-
-	var paths []string
-
-	if configPath != "" {
-		expanded, err := utils.ExpandPath(configPath)
-		if err != nil {
-			return nil
-		}
-		absPath, err := filepath.Abs(expanded)
-		if err != nil {
-			return nil
-		}
-		paths = []string{absPath}
-	} else {
-		paths = []string{
-			filepath.Join(configBase, "config.yaml"),
-			filepath.Join(configBase, "config.yml"),
-		}
-	}
-
-	for _, path := range paths {
+	for _, path := range yamlConfigPaths(configPath) {
 		if _, err := os.Stat(path); os.IsNotExist(err) {
 			continue
 		}
@@ -777,6 +867,43 @@ func loadYAMLFile(configPath string) map[string]any {
 	return nil
 }
 
+// yamlConfigPaths returns the config file candidates LoadConfig reads, in order.
+func yamlConfigPaths(configPath string) []string {
+	if configPath != "" {
+		expanded, err := utils.ExpandPath(configPath)
+		if err != nil {
+			return nil
+		}
+		absPath, err := filepath.Abs(expanded)
+		if err != nil {
+			return nil
+		}
+		return []string{absPath}
+	}
+
+	configBase := filepath.Clean(filepath.Join(getConfigDir(), "lazyworktree"))
+	return []string{
+		filepath.Join(configBase, "config.yaml"),
+		filepath.Join(configBase, "config.yml"),
+	}
+}
+
+// ConfigFileWarnings reports problems in the YAML config file that LoadConfig
+// silently ignores, such as parse errors, unknown keys or values of the wrong
+// type. It returns the path it inspected, or an empty path when no config
+// file exists.
+func ConfigFileWarnings(configPath string) (string, []string) {
+	path, issues, err := ValidateConfigFile(configPath)
+	if err != nil {
+		return path, []string{err.Error()}
+	}
+	warnings := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		warnings = append(warnings, issue.String())
+	}
+	return path, warnings
+}
+
 // ApplyCLIOverrides applies CLI config overrides to the configuration.
 func (cfg *AppConfig) ApplyCLIOverrides(overrides []string) error {
 	if len(overrides) == 0 {
@@ -833,6 +960,9 @@ func (cfg *AppConfig) ApplyCLIOverrides(overrides []string) error {
 	if overrideCfg.WorktreeNotesPath != "" {
 		cfg.WorktreeNotesPath = overrideCfg.WorktreeNotesPath
 	}
+	if overrideCfg.WorktreePathTemplate != "" {
+		cfg.WorktreePathTemplate = overrideCfg.WorktreePathTemplate
+	}
 	if overrideCfg.IssueBranchNameTemplate != "" {
 		cfg.IssueBranchNameTemplate = overrideCfg.IssueBranchNameTemplate
 	}
@@ -865,6 +995,12 @@ func (cfg *AppConfig) ApplyCLIOverrides(overrides []string) error {
 	if _, ok := overrideData["search_auto_select"]; ok {
 		cfg.SearchAutoSelect = overrideCfg.SearchAutoSelect
 	}
+	if _, ok := overrideData["submodule_update"]; ok {
+		cfg.SubmoduleUpdate = overrideCfg.SubmoduleUpdate
+	}
+	if _, ok := overrideData["submodule_reference"]; ok {
+		cfg.SubmoduleReference = overrideCfg.SubmoduleReference
+	}
 	if _, ok := overrideData["auto_refresh"]; ok {
 		cfg.AutoRefresh = overrideCfg.AutoRefresh
 	}
@@ -914,9 +1050,25 @@ func mergeMaps(dst, src map[string]any) {
 	}
 }
 
-// LoadConfig loads the application configuration from a file.
-func LoadConfig(configPath string) (*AppConfig, error) {
-	// Collect all config data maps, then merge and parse once
+// Configuration sources reported by ConfigSources, besides the YAML file path.
+const (
+	SourceDefault   = "default"
+	SourceGitGlobal = "git config --global"
+	SourceGitLocal  = "git config --local"
+	SourceDetected  = "detected"
+)
+
+// configLayer is one source of configuration data. Later layers override
+// earlier ones.
+type configLayer struct {
+	source string
+	data   map[string]any
+}
+
+// loadConfigLayers reads the YAML file and git config layers in precedence
+// order, and returns the path of the YAML file that was read, if any.
+func loadConfigLayers(configPath string) ([]configLayer, string) {
+	var layers []configLayer
 	mergedData := make(map[string]any)
 
 	// 1. Load YAML config
@@ -941,12 +1093,14 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 				}
 			}
 		}
+		layers = append(layers, configLayer{source: actualConfigPath, data: yamlData})
 	}
 
 	// 2. Load and merge git global config (overrides YAML)
 	gitGlobalData, err := loadGitConfig(true, "")
 	if err == nil && len(gitGlobalData) > 0 {
 		mergeMaps(mergedData, gitGlobalData)
+		layers = append(layers, configLayer{source: SourceGitGlobal, data: gitGlobalData})
 	}
 
 	// 3. Determine repo path from merged data so far
@@ -960,18 +1114,44 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 	if repoPath != "" {
 		gitLocalData, err := loadGitConfig(false, repoPath)
 		if err == nil && len(gitLocalData) > 0 {
-			mergeMaps(mergedData, gitLocalData)
+			layers = append(layers, configLayer{source: SourceGitLocal, data: gitLocalData})
+		}
+	}
+
+	return layers, actualConfigPath
+}
+
+// ConfigSources reports which source set each configuration key: the YAML
+// file path, SourceGitGlobal or SourceGitLocal. Keys that no source sets are
+// absent. Deprecated aliases are reported under their current key name.
+func ConfigSources(configPath string) map[string]string {
+	layers, _ := loadConfigLayers(configPath)
+	sources := make(map[string]string)
+	for _, layer := range layers {
+		for key := range layer.data {
+			sources[CanonicalConfigKey(key)] = layer.source
 		}
 	}
+	return sources
+}
+
+// LoadConfig loads the application configuration from a file.
+func LoadConfig(configPath string) (*AppConfig, error) {
+	// Collect all config data maps, then merge and parse once
+	layers, actualConfigPath := loadConfigLayers(configPath)
+	mergedData := make(map[string]any)
+	for _, layer := range layers {
+		mergeMaps(mergedData, layer.data)
+	}
 
-	// 5. Parse the merged data into AppConfig
+	// Parse the merged data into AppConfig
 	cfg, err := parseConfig(mergedData)
 	if err != nil {
 		return nil, err
 	}
 	cfg.ConfigPath = actualConfigPath
 
-	// 6. Theme detection (if theme not set from any config source)
+	// Theme detection (if theme not set from any config source)
 	if cfg.Theme == "" {
 		detected, err := theme.DetectBackground(500 * time.Millisecond)
 		if err == nil {
@@ -995,6 +1175,12 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 // SaveConfig writes the configuration back to the file.
 // It tries to preserve existing fields by reading the file first.
 func SaveConfig(cfg *AppConfig) error {
+	return saveConfigLine(cfg, "theme", cfg.Theme)
+}
+
+// saveConfigLine replaces the top-level "key: ..." line of the config file
+// with the given rendered value, or appends it when the key is not set.
+func saveConfigLine(cfg *AppConfig, key, rendered string) error {
 	path := cfg.ConfigPath
 	if path == "" {
 		configBase := filepath.Join(getConfigDir(), "lazyworktree")
@@ -1017,20 +1203,20 @@ func SaveConfig(cfg *AppConfig) error {
 		content = string(data)
 	}
 
-	// Use regex to replace or add theme: line
-	re := regexp.MustCompile(`(?m)^theme:\s*.*$`)
-	newThemeLine := fmt.Sprintf("theme: %s", cfg.Theme)
+	// Use regex to replace or add the key line
+	re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(key) + `:\s*.*$`)
+	newLine := fmt.Sprintf("%s: %s", key, rendered)
 
 	var newData []byte
 	if re.MatchString(content) {
-		// Replace existing theme line
-		newData = []byte(re.ReplaceAllString(content, newThemeLine))
+		// Replace existing key line
+		newData = []byte(re.ReplaceAllLiteralString(content, newLine))
 	} else {
-		// Add theme line
+		// Add key line
 		if content != "" && !strings.HasSuffix(content, "\n") {
 			content += "\n"
 		}
-		newData = []byte(content + newThemeLine + "\n")
+		newData = []byte(content + newLine + "\n")
 	}
 
 	if err := os.WriteFile(path, newData, 0o600); err != nil { // #nosec G306
@@ -1071,6 +1257,15 @@ func LoadRepoConfig(repoPath string) (*RepoConfig, string, error) {
 		Path:              path,
 		InitCommands:      normalizeCommandList(raw["init_commands"]),
 		TerminateCommands: normalizeCommandList(raw["terminate_commands"]),
+		SparseProfiles:    parseSparseProfiles(raw["sparse_profiles"]),
+	}
+	if _, ok := raw["submodule_update"]; ok {
+		update := coerceBool(raw["submodule_update"], false)
+		cfg.SubmoduleUpdate = &update
+	}
+	if _, ok := raw["submodule_reference"]; ok {
+		reference := coerceBool(raw["submodule_reference"], false)
+		cfg.SubmoduleReference = &reference
 	}
 
 	return cfg, path, nil
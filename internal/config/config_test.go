@@ -942,6 +942,51 @@ func TestParseConfig(t *testing.T) {
 				assert.Empty(t, cfg.WorktreeNotesPath)
 			},
 		},
+		{
+			name: "worktree_path_template",
+			data: map[string]interface{}{
+				"worktree_path_template": "  {repo_parent}/{repo}.worktrees/{branch}  ",
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Equal(t, "{repo_parent}/{repo}.worktrees/{branch}", cfg.WorktreePathTemplate)
+			},
+		},
+		{
+			name: "worktree_path_template without name or branch is ignored",
+			data: map[string]interface{}{
+				"worktree_path_template": "{repo_parent}/{repo}.worktrees",
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Empty(t, cfg.WorktreePathTemplate)
+			},
+		},
+		{
+			name: "sparse_profiles",
+			data: map[string]interface{}{
+				"sparse_profiles": map[string]interface{}{
+					"web":     []interface{}{"/web/", "shared/ui", "--no-cone", ""},
+					"docs":    "docs",
+					"invalid": []interface{}{"-x"},
+				},
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Equal(t, map[string][]string{
+					"web":  {"web", "shared/ui"},
+					"docs": {"docs"},
+				}, cfg.SparseProfiles)
+			},
+		},
+		{
+			name: "submodule settings",
+			data: map[string]interface{}{
+				"submodule_update":    true,
+				"submodule_reference": "true",
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.True(t, cfg.SubmoduleUpdate)
+				assert.True(t, cfg.SubmoduleReference)
+			},
+		},
 		{
 			name: "pr_branch_name_template",
 			data: map[string]interface{}{
@@ -1292,6 +1337,49 @@ terminate_commands:
 		assert.Equal(t, []string{"echo \"terminate\""}, cfg.TerminateCommands)
 	})
 
+	t.Run("sparse profiles override global ones", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		yamlContent := `sparse_profiles:
+  web:
+    - web
+    - shared
+    - --no-cone
+  opts:
+    - -x
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".wt"), []byte(yamlContent), 0o600))
+
+		repoCfg, _, err := LoadRepoConfig(tmpDir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"web": {"web", "shared"}}, repoCfg.SparseProfiles)
+
+		cfg := &AppConfig{SparseProfiles: map[string][]string{"web": {"frontend"}, "docs": {"docs"}}}
+		profiles := SparseProfiles(cfg, repoCfg)
+		assert.Equal(t, map[string][]string{"web": {"web", "shared"}, "docs": {"docs"}}, profiles)
+		assert.Equal(t, []string{"docs", "web"}, SparseProfileNames(profiles))
+		assert.Equal(t, cfg.SparseProfiles, SparseProfiles(cfg, nil))
+	})
+
+	t.Run("submodule settings override global ones", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".wt"), []byte("submodule_update: false\n"), 0o600))
+
+		repoCfg, _, err := LoadRepoConfig(tmpDir)
+		require.NoError(t, err)
+		require.NotNil(t, repoCfg.SubmoduleUpdate)
+		assert.False(t, *repoCfg.SubmoduleUpdate)
+		assert.Nil(t, repoCfg.SubmoduleReference)
+
+		cfg := &AppConfig{SubmoduleUpdate: true, SubmoduleReference: true}
+		update, reference := SubmoduleSettings(cfg, repoCfg)
+		assert.False(t, update)
+		assert.True(t, reference)
+
+		update, reference = SubmoduleSettings(cfg, nil)
+		assert.True(t, update)
+		assert.True(t, reference)
+	})
+
 	t.Run("invalid YAML in .wt file", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		wtPath := filepath.Join(tmpDir, ".wt")
@@ -2012,6 +2100,32 @@ func TestLoadConfigWithCustomPath(t *testing.T) {
 	assert.Equal(t, customConfigPath, cfg.ConfigPath)
 }
 
+func TestConfigFileWarnings(t *testing.T) {
+	tempDir := t.TempDir()
+
+	path, warnings := ConfigFileWarnings(filepath.Join(tempDir, "missing.yaml"))
+	assert.Empty(t, path)
+	assert.Empty(t, warnings)
+
+	validPath := filepath.Join(tempDir, "valid.yaml")
+	require.NoError(t, os.WriteFile(validPath, []byte("trust_mode: never\n"), 0o600))
+	path, warnings = ConfigFileWarnings(validPath)
+	assert.Equal(t, validPath, path)
+	assert.Empty(t, warnings)
+
+	badModePath := filepath.Join(tempDir, "bad-mode.yaml")
+	require.NoError(t, os.WriteFile(badModePath, []byte("trust_mode: sometimes\n"), 0o600))
+	_, warnings = ConfigFileWarnings(badModePath)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "trust_mode")
+
+	invalidPath := filepath.Join(tempDir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("theme: [unclosed\n"), 0o600))
+	_, warnings = ConfigFileWarnings(invalidPath)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "invalid YAML")
+}
+
 func TestLoadConfigWithCustomPathFromAnywhere(t *testing.T) {
 	// Setup mock to prevent loading real git config from the test repository
 	defer func() { gitConfigMock = nil }()
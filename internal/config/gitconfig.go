@@ -187,3 +187,36 @@ func parseCLIConfigOverrides(overrides []string) (map[string]any, error) {
 
 	return result, nil
 }
+
+// CLIOverrideKeys returns the canonical names of the keys set by --config
+// overrides.
+func CLIOverrideKeys(overrides []string) ([]string, error) {
+	data, err := parseCLIConfigOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, CanonicalConfigKey(key))
+	}
+	return keys, nil
+}
+
+// SaveGitConfigValue writes lw.<key> to the global git config, or to the
+// config of the repository in the current directory when global is false.
+func SaveGitConfigValue(global bool, key, value string) error {
+	scope := "--local"
+	repoPath := ""
+	if global {
+		scope = "--global"
+	} else {
+		repoPath = determineRepoPath("")
+		if repoPath == "" {
+			return fmt.Errorf("not inside a git repository; use --global")
+		}
+	}
+	if _, err := runGitConfig([]string{"config", scope, "lw." + key, value}, repoPath); err != nil {
+		return fmt.Errorf("failed to set lw.%s: %w", key, err)
+	}
+	return nil
+}
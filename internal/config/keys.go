@@ -0,0 +1,411 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Value types of configuration keys.
+const (
+	KeyTypeString = "string"
+	KeyTypeBool   = "bool"
+	KeyTypeInt    = "int"
+	KeyTypeList   = "list"
+	KeyTypeMap    = "map"
+)
+
+// ConfigKey describes a top-level configuration key.
+type ConfigKey struct {
+	Name   string
+	Type   string
+	Values []string // Allowed values, empty when any value of Type is accepted
+	value  func(cfg *AppConfig) any
+	check  func(value string) error // Extra validation of string values
+}
+
+// Value returns the effective value of the key in cfg.
+func (k ConfigKey) Value(cfg *AppConfig) any {
+	return k.value(cfg)
+}
+
+// IsScalar reports whether the key holds a single string, bool or int.
+func (k ConfigKey) IsScalar() bool {
+	return k.Type == KeyTypeString || k.Type == KeyTypeBool || k.Type == KeyTypeInt
+}
+
+var configKeys = []ConfigKey{
+	{Name: "worktree_dir", Type: KeyTypeString, value: func(c *AppConfig) any { return c.WorktreeDir }},
+	{Name: "init_commands", Type: KeyTypeList, value: func(c *AppConfig) any { return c.InitCommands }},
+	{Name: "terminate_commands", Type: KeyTypeList, value: func(c *AppConfig) any { return c.TerminateCommands }},
+	{Name: "sort_mode", Type: KeyTypeString, Values: []string{"path", "active", "switched"}, value: func(c *AppConfig) any { return c.SortMode }},
+	{Name: "auto_fetch_prs", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.AutoFetchPRs }},
+	{Name: "disable_pr", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.DisablePR }},
+	{Name: "search_auto_select", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.SearchAutoSelect }},
+	{Name: "max_untracked_diffs", Type: KeyTypeInt, value: func(c *AppConfig) any { return c.MaxUntrackedDiffs }},
+	{Name: "max_diff_chars", Type: KeyTypeInt, value: func(c *AppConfig) any { return c.MaxDiffChars }},
+	{Name: "max_name_length", Type: KeyTypeInt, value: func(c *AppConfig) any { return c.MaxNameLength }},
+	{Name: "git_pager", Type: KeyTypeString, value: func(c *AppConfig) any { return c.GitPager }},
+	{Name: "git_pager_args", Type: KeyTypeList, value: func(c *AppConfig) any { return c.GitPagerArgs }},
+	{Name: "git_pager_interactive", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.GitPagerInteractive }},
+	{Name: "git_pager_command_mode", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.GitPagerCommandMode }},
+	{Name: "trust_mode", Type: KeyTypeString, Values: []string{"tofu", "never", "always"}, value: func(c *AppConfig) any { return c.TrustMode }},
+	{Name: "debug_log", Type: KeyTypeString, value: func(c *AppConfig) any { return c.DebugLog }},
+	{Name: "pager", Type: KeyTypeString, value: func(c *AppConfig) any { return c.Pager }},
+	{Name: "ci_script_pager", Type: KeyTypeString, value: func(c *AppConfig) any { return c.CIScriptPager }},
+	{Name: "editor", Type: KeyTypeString, value: func(c *AppConfig) any { return c.Editor }},
+	{Name: "auto_refresh", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.AutoRefresh }},
+	{Name: "ci_auto_refresh", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.CIAutoRefresh }},
+	{Name: "refresh_interval", Type: KeyTypeInt, value: func(c *AppConfig) any { return c.RefreshIntervalSeconds }},
+	{Name: "custom_commands", Type: KeyTypeMap, value: func(c *AppConfig) any { return c.CustomCommands }},
+	{Name: "branch_name_script", Type: KeyTypeString, value: func(c *AppConfig) any { return c.BranchNameScript }},
+	{Name: "worktree_note_script", Type: KeyTypeString, value: func(c *AppConfig) any { return c.WorktreeNoteScript }},
+	{Name: "worktree_notes_path", Type: KeyTypeString, value: func(c *AppConfig) any { return c.WorktreeNotesPath }},
+	{Name: "worktree_path_template", Type: KeyTypeString, value: func(c *AppConfig) any { return c.WorktreePathTemplate }, check: ValidateWorktreePathTemplate},
+	{Name: "theme", Type: KeyTypeString, value: func(c *AppConfig) any { return c.Theme }},
+	{Name: "merge_method", Type: KeyTypeString, Values: []string{"rebase", "merge"}, value: func(c *AppConfig) any { return c.MergeMethod }},
+	{Name: "fuzzy_finder_input", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.FuzzyFinderInput }},
+	{Name: "icon_set", Type: KeyTypeString, Values: []string{"nerd-font-v3", "text", "emoji", "none"}, value: func(c *AppConfig) any { return c.IconSet }},
+	{Name: "issue_branch_name_template", Type: KeyTypeString, value: func(c *AppConfig) any { return c.IssueBranchNameTemplate }},
+	{Name: "pr_branch_name_template", Type: KeyTypeString, value: func(c *AppConfig) any { return c.PRBranchNameTemplate }},
+	{Name: "session_prefix", Type: KeyTypeString, value: func(c *AppConfig) any { return c.SessionPrefix }},
+	{Name: "layout", Type: KeyTypeString, Values: []string{"default", "top"}, value: func(c *AppConfig) any { return c.Layout }},
+	{Name: "palette_mru", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.PaletteMRU }},
+	{Name: "palette_mru_limit", Type: KeyTypeInt, value: func(c *AppConfig) any { return c.PaletteMRULimit }},
+	{Name: "custom_create_menus", Type: KeyTypeList, value: func(c *AppConfig) any { return c.CustomCreateMenus }},
+	{Name: "submodule_update", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.SubmoduleUpdate }},
+	{Name: "submodule_reference", Type: KeyTypeBool, value: func(c *AppConfig) any { return c.SubmoduleReference }},
+	{Name: "sparse_profiles", Type: KeyTypeMap, value: func(c *AppConfig) any { return c.SparseProfiles }},
+	{Name: "custom_themes", Type: KeyTypeMap, value: func(c *AppConfig) any { return c.CustomThemes }},
+}
+
+// deprecatedConfigKeys maps backwards compatible key names to their
+// current name.
+var deprecatedConfigKeys = map[string]string{
+	"delta_args":     "git_pager_args",
+	"delta_path":     "git_pager",
+	"sort_by_active": "sort_mode",
+}
+
+// ConfigKeys returns every supported configuration key in display order.
+func ConfigKeys() []ConfigKey {
+	return append([]ConfigKey(nil), configKeys...)
+}
+
+// LookupConfigKey returns the key called name, with or without the git config
+// "lw." prefix, resolving deprecated aliases.
+func LookupConfigKey(name string) (ConfigKey, bool) {
+	name = CanonicalConfigKey(strings.TrimPrefix(name, "lw."))
+	for _, key := range configKeys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return ConfigKey{}, false
+}
+
+// lookupConfigFileKey is LookupConfigKey for keys as written in a config
+// source, where deprecated aliases may have a different type.
+func lookupConfigFileKey(name string) (ConfigKey, bool) {
+	if name == "sort_by_active" {
+		return ConfigKey{Name: name, Type: KeyTypeBool}, true
+	}
+	return LookupConfigKey(name)
+}
+
+// CanonicalConfigKey returns the current name of a possibly deprecated key.
+func CanonicalConfigKey(name string) string {
+	if current, ok := deprecatedConfigKeys[name]; ok {
+		return current
+	}
+	return name
+}
+
+// FormatConfigValue renders an effective value on a single line.
+func FormatConfigValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case []string:
+		return strings.Join(val, ", ")
+	case map[string]*CustomCommand:
+		return fmt.Sprintf("%d command(s)", len(val))
+	case map[string]*CustomTheme:
+		return fmt.Sprintf("%d theme(s)", len(val))
+	case []*CustomCreateMenu:
+		return fmt.Sprintf("%d menu(s)", len(val))
+	case map[string][]string:
+		return fmt.Sprintf("%d profile(s)", len(val))
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// ConfigIssue is a problem found while validating a config file.
+type ConfigIssue struct {
+	Line    int
+	Column  int
+	Key     string
+	Message string
+}
+
+func (i ConfigIssue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+}
+
+// ValidateConfigFile checks the YAML config file LoadConfig would read for
+// unknown keys and values of the wrong type. It returns the path it inspected,
+// or an empty path when no config file exists.
+func ValidateConfigFile(configPath string) (string, []ConfigIssue, error) {
+	for _, path := range yamlConfigPaths(configPath) {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+
+		// #nosec G304 -- path expanded from user config location or CLI argument
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return path, nil, fmt.Errorf("cannot read config file: %w", err)
+		}
+		return path, validateConfigYAML(data), nil
+	}
+	return "", nil, nil
+}
+
+var yamlErrorLineRE = regexp.MustCompile(`line (\d+)`)
+
+func validateConfigYAML(data []byte) []ConfigIssue {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		issue := ConfigIssue{Message: fmt.Sprintf("invalid YAML, the file is ignored: %v", err)}
+		if m := yamlErrorLineRE.FindStringSubmatch(err.Error()); m != nil {
+			issue.Line, _ = strconv.Atoi(m[1])
+			issue.Column = 1
+		}
+		return []ConfigIssue{issue}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []ConfigIssue{{Line: root.Line, Column: root.Column, Message: "expected a mapping of configuration keys at the top level"}}
+	}
+
+	var issues []ConfigIssue
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		name := keyNode.Value
+		if current, deprecated := deprecatedConfigKeys[name]; deprecated {
+			issues = append(issues, ConfigIssue{
+				Line: keyNode.Line, Column: keyNode.Column, Key: name,
+				Message: fmt.Sprintf("%s is deprecated, use %s", name, current),
+			})
+		}
+
+		key, ok := lookupConfigFileKey(name)
+		if !ok {
+			issues = append(issues, ConfigIssue{
+				Line: keyNode.Line, Column: keyNode.Column, Key: name,
+				Message: fmt.Sprintf("unknown key %q", name),
+			})
+			continue
+		}
+		if msg, at := checkConfigValueNode(key, valueNode); msg != "" {
+			issues = append(issues, ConfigIssue{Line: at.Line, Column: at.Column, Key: name, Message: msg})
+		}
+	}
+	return issues
+}
+
+// checkConfigValueNode returns a message describing why node is not a valid
+// value for key, and the node the problem was found at, or an empty string.
+func checkConfigValueNode(key ConfigKey, node *yaml.Node) (string, *yaml.Node) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return "", node
+	}
+
+	switch key.Type {
+	case KeyTypeMap:
+		if node.Kind != yaml.MappingNode {
+			return fmt.Sprintf("%s: expected a mapping", key.Name), node
+		}
+		return "", node
+	case KeyTypeList:
+		if key.Name == "custom_create_menus" {
+			if node.Kind != yaml.SequenceNode {
+				return fmt.Sprintf("%s: expected a list", key.Name), node
+			}
+			return "", node
+		}
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+			return "", node
+		}
+		if node.Kind != yaml.SequenceNode {
+			return fmt.Sprintf("%s: expected a list of strings", key.Name), node
+		}
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode || item.Tag != "!!str" {
+				return fmt.Sprintf("%s: expected a list of strings, found %s", key.Name, describeYAMLNode(item)), item
+			}
+		}
+		return "", node
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Sprintf("%s: expected a %s, found %s", key.Name, key.Type, describeYAMLNode(node)), node
+	}
+	return checkConfigScalar(key, node.Tag, node.Value), node
+}
+
+// checkConfigScalar validates a scalar value with the given YAML tag.
+func checkConfigScalar(key ConfigKey, tag, value string) string {
+	switch key.Type {
+	case KeyTypeBool:
+		switch tag {
+		case "!!bool", "!!int":
+			return ""
+		case "!!str":
+			if _, ok := parseConfigBool(value); ok {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%s: expected a boolean, found %q", key.Name, value)
+	case KeyTypeInt:
+		if tag == "!!int" {
+			return ""
+		}
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); tag == "!!str" && err == nil {
+			return ""
+		}
+		return fmt.Sprintf("%s: expected an integer, found %q", key.Name, value)
+	case KeyTypeString:
+		if tag != "!!str" {
+			return fmt.Sprintf("%s: expected a string, found %q; quote the value", key.Name, value)
+		}
+		if len(key.Values) > 0 && !containsFold(key.Values, value) {
+			return fmt.Sprintf("%s: unsupported value %q (available: %s)", key.Name, value, strings.Join(key.Values, ", "))
+		}
+		if key.check != nil {
+			if err := key.check(value); err != nil {
+				return fmt.Sprintf("%s: %v", key.Name, err)
+			}
+		}
+	}
+	return ""
+}
+
+// ParseConfigValue converts a command line value to the type of key, so it
+// can be written to a config file.
+func ParseConfigValue(key ConfigKey, value string) (any, error) {
+	if !key.IsScalar() {
+		return nil, fmt.Errorf("%s is a %s; edit the config file to change it", key.Name, key.Type)
+	}
+
+	var parsed any = value
+	tag := "!!str"
+	switch key.Type {
+	case KeyTypeBool:
+		b, ok := parseConfigBool(value)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a boolean, found %q", key.Name, value)
+		}
+		parsed, tag = b, "!!bool"
+	case KeyTypeInt:
+		i, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected an integer, found %q", key.Name, value)
+		}
+		parsed, tag = i, "!!int"
+	}
+	if msg := checkConfigScalar(key, tag, value); msg != "" {
+		return nil, fmt.Errorf("%s", msg)
+	}
+	return parsed, nil
+}
+
+// SaveConfigValue sets a top-level scalar key in the YAML config file,
+// replacing an existing line for the key or appending one.
+func SaveConfigValue(cfg *AppConfig, name string, value any) error {
+	rendered, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return saveConfigLine(cfg, name, strings.TrimSpace(string(rendered)))
+}
+
+func parseConfigBool(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes", "y", "on":
+		return true, true
+	case "false", "0", "no", "n", "off":
+		return false, true
+	}
+	return false, false
+}
+
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeYAMLNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
+
+// ValidateGitConfig checks the lw.* git config keys for unknown names and
+// invalid values, returning one message per problem.
+func ValidateGitConfig(configPath string) []string {
+	layers, _ := loadConfigLayers(configPath)
+	var problems []string
+	for _, layer := range layers {
+		if layer.source != SourceGitGlobal && layer.source != SourceGitLocal {
+			continue
+		}
+		names := make([]string, 0, len(layer.data))
+		for name := range layer.data {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			key, ok := lookupConfigFileKey(name)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: unknown key lw.%s", layer.source, name))
+				continue
+			}
+			value, isString := layer.data[name].(string)
+			if !isString || !key.IsScalar() {
+				continue
+			}
+			if msg := checkConfigScalar(key, "!!str", value); msg != "" {
+				problems = append(problems, fmt.Sprintf("%s: lw.%s", layer.source, msg))
+			}
+		}
+	}
+	return problems
+}
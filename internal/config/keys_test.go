@@ -0,0 +1,166 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigKeysCoverDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	seen := make(map[string]bool)
+	for _, key := range ConfigKeys() {
+		assert.False(t, seen[key.Name], "duplicate key %s", key.Name)
+		seen[key.Name] = true
+		assert.NotPanics(t, func() { key.Value(cfg) }, key.Name)
+	}
+
+	key, ok := LookupConfigKey("lw.sort_mode")
+	require.True(t, ok)
+	assert.Equal(t, "sort_mode", key.Name)
+	assert.Equal(t, "switched", key.Value(cfg))
+
+	key, ok = LookupConfigKey("sort_by_active")
+	require.True(t, ok)
+	assert.Equal(t, "sort_mode", key.Name)
+	_, ok = LookupConfigKey("bogus")
+	assert.False(t, ok)
+	assert.Equal(t, "git_pager", CanonicalConfigKey("delta_path"))
+}
+
+func TestValidateConfigYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:    "valid",
+			content: "theme: dracula\nauto_fetch_prs: true\nmax_diff_chars: 1000\ninit_commands:\n  - make\n",
+		},
+		{
+			name:     "unknown key",
+			content:  "theme: dracula\nbogus: 1\n",
+			expected: []string{`2:1: unknown key "bogus"`},
+		},
+		{
+			name:     "type errors",
+			content:  "auto_fetch_prs: sometimes\nmax_diff_chars: lots\ntheme: 12\n",
+			expected: []string{`1:17: auto_fetch_prs: expected a boolean, found "sometimes"`, `2:17: max_diff_chars: expected an integer, found "lots"`, `3:8: theme: expected a string, found "12"; quote the value`},
+		},
+		{
+			name:     "enum",
+			content:  "sort_mode: size\n",
+			expected: []string{`1:12: sort_mode: unsupported value "size" (available: path, active, switched)`},
+		},
+		{
+			name:     "worktree path template",
+			content:  "worktree_path_template: \"{repo_parent}/{project}\"\n",
+			expected: []string{`1:25: worktree_path_template: unknown placeholder {project} (available: {worktree_dir}, {repo_key}, {repo_parent}, {repo}, {host}, {owner}, {branch}, {name})`},
+		},
+		{
+			name:     "list item position",
+			content:  "init_commands:\n  - make\n  - {a: b}\n",
+			expected: []string{`3:5: init_commands: expected a list of strings, found a mapping`},
+		},
+		{
+			name:     "deprecated",
+			content:  "delta_path: delta\n",
+			expected: []string{"1:1: delta_path is deprecated, use git_pager"},
+		},
+		{
+			name:     "invalid yaml",
+			content:  "theme: [\n",
+			expected: []string{"invalid YAML"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateConfigYAML([]byte(tt.content))
+			require.Len(t, issues, len(tt.expected))
+			for i, issue := range issues {
+				assert.Contains(t, issue.String(), tt.expected[i])
+			}
+		})
+	}
+}
+
+func TestParseConfigValue(t *testing.T) {
+	key, _ := LookupConfigKey("auto_fetch_prs")
+	v, err := ParseConfigValue(key, "yes")
+	require.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	key, _ = LookupConfigKey("max_diff_chars")
+	v, err = ParseConfigValue(key, "42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+	_, err = ParseConfigValue(key, "many")
+	require.Error(t, err)
+
+	key, _ = LookupConfigKey("sort_mode")
+	_, err = ParseConfigValue(key, "size")
+	require.Error(t, err)
+
+	key, _ = LookupConfigKey("init_commands")
+	_, err = ParseConfigValue(key, "make")
+	require.Error(t, err)
+}
+
+func TestSaveConfigValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("theme: dracula\nmax_diff_chars: 10"), 0o600))
+	cfg := &AppConfig{ConfigPath: path}
+
+	require.NoError(t, SaveConfigValue(cfg, "max_diff_chars", 2000))
+	require.NoError(t, SaveConfigValue(cfg, "auto_fetch_prs", true))
+	require.NoError(t, SaveConfigValue(cfg, "git_pager", "$HOME/bin/delta"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "theme: dracula\nmax_diff_chars: 2000\nauto_fetch_prs: true\ngit_pager: $HOME/bin/delta\n", string(data))
+	assert.Empty(t, validateConfigYAML(data))
+}
+
+func TestConfigSources(t *testing.T) {
+	defer func() { gitConfigMock = nil }()
+	gitConfigMock = func(args []string, _ string) (string, error) {
+		if strings.Contains(strings.Join(args, " "), "--global") {
+			return "lw.theme nord\nlw.delta_args --dark\n", nil
+		}
+		return "", nil
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("theme: dracula\nmax_diff_chars: 10\n"), 0o600))
+
+	sources := ConfigSources(configPath)
+	assert.Equal(t, SourceGitGlobal, sources["theme"])
+	assert.Equal(t, SourceGitGlobal, sources["git_pager_args"])
+	assert.Equal(t, configPath, sources["max_diff_chars"])
+	assert.NotContains(t, sources, "sort_mode")
+}
+
+func TestValidateGitConfig(t *testing.T) {
+	defer func() { gitConfigMock = nil }()
+	gitConfigMock = func(args []string, _ string) (string, error) {
+		if strings.Contains(strings.Join(args, " "), "--global") {
+			return "lw.auto_fetch_prs maybe\nlw.unknown 1\nlw.theme nord\n", nil
+		}
+		return "", nil
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	problems := ValidateGitConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Equal(t, []string{
+		`git config --global: lw.auto_fetch_prs: expected a boolean, found "maybe"`,
+		"git config --global: unknown key lw.unknown",
+	}, problems)
+}
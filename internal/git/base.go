@@ -0,0 +1,56 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// baseRefConfigKey is the branch config variable, branch.<name>.lwBase,
+// recording the ref a worktree branch was created from.
+const baseRefConfigKey = "lwBase"
+
+// SetBaseRef records base as the ref branch was created from. The variable is
+// part of the branch config section, so git renames and deletes it with the
+// branch. Nothing is recorded when branch tracks base: a remote branch checked
+// out locally is integrated into main, not into itself.
+func (s *Service) SetBaseRef(ctx context.Context, cwd, branch, base string) error {
+	branch = strings.TrimSpace(branch)
+	base = strings.TrimSpace(base)
+	if branch == "" || base == "" || branch == base {
+		return nil
+	}
+	if upstream := s.RunGit(ctx, []string{"git", "rev-parse", "--abbrev-ref", branch + "@{upstream}"}, cwd, []int{0, 128}, true, true); upstream == base {
+		return nil
+	}
+	key := fmt.Sprintf("branch.%s.%s", branch, baseRefConfigKey)
+	if out, err := s.RunGitWithCombinedOutput(ctx, []string{"git", "config", key, base}, cwd, nil); err != nil {
+		return fmt.Errorf("failed to record the base of %s: %s", branch, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// BaseRefs returns the recorded base ref of every branch that has one, keyed
+// by branch name.
+func (s *Service) BaseRefs(ctx context.Context, cwd string) map[string]string {
+	// git lower-cases variable names in the output, not the branch subsection
+	suffix := "." + strings.ToLower(baseRefConfigKey)
+	raw := s.RunGit(ctx, []string{"git", "config", "--get-regexp", `^branch\..*\` + suffix + "$"}, cwd, []int{0, 1}, true, true)
+
+	bases := make(map[string]string)
+	for line := range strings.SplitSeq(raw, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		branch, ok := strings.CutPrefix(key, "branch.")
+		if !ok {
+			continue
+		}
+		branch, ok = strings.CutSuffix(branch, suffix)
+		if ok && branch != "" {
+			bases[branch] = strings.TrimSpace(value)
+		}
+	}
+	return bases
+}
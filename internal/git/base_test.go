@@ -0,0 +1,44 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseRefs(t *testing.T) {
+	t.Parallel()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	assert.Empty(t, service.BaseRefs(ctx, repo))
+
+	require.NoError(t, service.SetBaseRef(ctx, repo, "feature/v1.2-fix", "release/v1.2"))
+	require.NoError(t, service.SetBaseRef(ctx, repo, "Topic", "v1.0.0"))
+	require.NoError(t, service.SetBaseRef(ctx, repo, "same", "same"), "a branch is not recorded as its own base")
+
+	assert.Equal(t, map[string]string{
+		"feature/v1.2-fix": "release/v1.2",
+		"Topic":            "v1.0.0",
+	}, service.BaseRefs(ctx, repo))
+	assert.Equal(t, "release/v1.2", runGit(t, repo, "config", "branch.feature/v1.2-fix.lwBase"))
+}
+
+func TestSetBaseRefSkipsUpstream(t *testing.T) {
+	t.Parallel()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	runGit(t, repo, "remote", "add", "origin", repo)
+	runGit(t, repo, "update-ref", "refs/remotes/origin/foo", "HEAD")
+	runGit(t, repo, "branch", "--track", "foo-work", "origin/foo")
+
+	require.NoError(t, service.SetBaseRef(ctx, repo, "foo-work", "origin/foo"))
+	assert.Empty(t, service.BaseRefs(ctx, repo), "a branch tracking its start point has no base")
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// UniqueCommits returns the commits reachable from left but not right, and
+// from right but not left, most recent first.
+func (s *Service) UniqueCommits(ctx context.Context, cwd, left, right string) (leftOnly, rightOnly []models.CommitSummary) {
+	raw := s.RunGit(ctx, []string{
+		"git", "log", "--left-right", "--format=%m%x09%h%x09%s", left + "..." + right,
+	}, cwd, []int{0}, true, true)
+	return parseLeftRightLog(raw)
+}
+
+// parseLeftRightLog splits git log --left-right output, with the "<" or ">"
+// marker, abbreviated SHA and subject separated by tabs, by side.
+func parseLeftRightLog(raw string) (leftOnly, rightOnly []models.CommitSummary) {
+	for line := range strings.SplitSeq(raw, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		commit := models.CommitSummary{SHA: parts[1], Subject: parts[2]}
+		switch parts[0] {
+		case "<":
+			leftOnly = append(leftOnly, commit)
+		case ">":
+			rightOnly = append(rightOnly, commit)
+		}
+	}
+	return leftOnly, rightOnly
+}
+
+// DiffFiles returns the files that differ between the trees of from and to.
+func (s *Service) DiffFiles(ctx context.Context, cwd, from, to string) []models.CommitFile {
+	raw := s.RunGit(ctx, []string{"git", "diff", "--name-status", "-M", from, to}, cwd, []int{0}, false, false)
+	if raw == "" {
+		return []models.CommitFile{}
+	}
+	return parseCommitFiles(raw)
+}
+
+// WorkingTreeRef returns a commit holding the tracked files of the worktree at
+// cwd as they are on disk, staged or not, so that uncommitted states can be
+// compared with git diff. It is HEAD when the worktree has no changes.
+// Untracked files are not included.
+func (s *Service) WorkingTreeRef(ctx context.Context, cwd string) (string, error) {
+	out, err := s.RunGitWithCombinedOutput(ctx, []string{"git", "stash", "create"}, cwd, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot the changes in %s: %s", cwd, strings.TrimSpace(string(out)))
+	}
+	// The stash commit is printed last, after any warning
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if ref := strings.TrimSpace(lines[len(lines)-1]); ref != "" {
+		return ref, nil
+	}
+	head := s.RunGit(ctx, []string{"git", "rev-parse", "HEAD"}, cwd, []int{0}, true, true)
+	if head == "" {
+		return "", fmt.Errorf("failed to resolve HEAD in %s", cwd)
+	}
+	return head, nil
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLeftRightLog(t *testing.T) {
+	t.Parallel()
+	raw := "<\tabc1234\tLeft change\n" +
+		">\tdef5678\tRight change\n" +
+		">\t0123456\tAnother\twith tab\n" +
+		"garbage"
+
+	leftOnly, rightOnly := parseLeftRightLog(raw)
+	assert.Equal(t, []models.CommitSummary{{SHA: "abc1234", Subject: "Left change"}}, leftOnly)
+	assert.Equal(t, []models.CommitSummary{
+		{SHA: "def5678", Subject: "Right change"},
+		{SHA: "0123456", Subject: "Another\twith tab"},
+	}, rightOnly)
+}
+
+func TestCompareWorktrees(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	withCwd(t, repo)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	other := filepath.Join(t.TempDir(), "other")
+	runGit(t, repo, "worktree", "add", "-b", "other", other)
+	require.NoError(t, os.WriteFile(filepath.Join(other, "other.txt"), []byte("other\n"), 0o600))
+	runGit(t, other, "add", "other.txt")
+	runGit(t, other, "commit", "-m", "Other change")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.txt"), []byte("main\n"), 0o600))
+	runGit(t, repo, "add", "main.txt")
+	runGit(t, repo, "commit", "-m", "Main change")
+
+	leftOnly, rightOnly := service.UniqueCommits(ctx, repo, "HEAD", "other")
+	require.Len(t, leftOnly, 1)
+	assert.Equal(t, "Main change", leftOnly[0].Subject)
+	require.Len(t, rightOnly, 1)
+	assert.Equal(t, "Other change", rightOnly[0].Subject)
+
+	files := service.DiffFiles(ctx, repo, "HEAD", "other")
+	assert.ElementsMatch(t, []models.CommitFile{
+		{Filename: "main.txt", ChangeType: "D"},
+		{Filename: "other.txt", ChangeType: "A"},
+	}, files)
+
+	head := runGit(t, other, "rev-parse", "HEAD")
+	ref, err := service.WorkingTreeRef(ctx, other)
+	require.NoError(t, err)
+	assert.Equal(t, head, ref, "a clean worktree is compared at HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(other, "README.md"), []byte("changed\n"), 0o600))
+	ref, err = service.WorkingTreeRef(ctx, other)
+	require.NoError(t, err)
+	assert.NotEqual(t, head, ref)
+	files = service.DiffFiles(ctx, other, head, ref)
+	assert.Equal(t, []models.CommitFile{{Filename: "README.md", ChangeType: "M"}}, files)
+	assert.Equal(t, "M README.md", runGit(t, other, "status", "--porcelain"), "the snapshot leaves the changes in place")
+}
@@ -0,0 +1,42 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// DiffStat returns the lines added and removed, and the files changed, by the
+// worktree at cwd since it diverged from base. Like a pull request it covers
+// the commits on the branch, plus the uncommitted changes to tracked files.
+func (s *Service) DiffStat(ctx context.Context, cwd, base string) (models.DiffStat, error) {
+	mergeBase := s.RunGit(ctx, []string{"git", "merge-base", base, "HEAD"}, cwd, []int{0, 1}, true, true)
+	if mergeBase == "" {
+		return models.DiffStat{}, fmt.Errorf("no merge base between %s and HEAD", base)
+	}
+	raw := s.RunGit(ctx, []string{"git", "diff", "--numstat", mergeBase}, cwd, []int{0}, true, true)
+	return parseNumstat(raw), nil
+}
+
+// parseNumstat totals git diff --numstat output. Binary files count as
+// changed files without lines.
+func parseNumstat(raw string) models.DiffStat {
+	var stat models.DiffStat
+	for line := range strings.SplitSeq(raw, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		stat.Files++
+		if added, err := strconv.Atoi(fields[0]); err == nil {
+			stat.Added += added
+		}
+		if removed, err := strconv.Atoi(fields[1]); err == nil {
+			stat.Removed += removed
+		}
+	}
+	return stat
+}
@@ -0,0 +1,50 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumstat(t *testing.T) {
+	t.Parallel()
+	raw := "3\t1\tmain.go\n" +
+		"-\t-\tlogo.png\n" +
+		"10\t0\tdocs/README.md\n"
+
+	assert.Equal(t, models.DiffStat{Added: 13, Removed: 1, Files: 3}, parseNumstat(raw))
+	assert.Equal(t, models.DiffStat{}, parseNumstat(""))
+}
+
+func TestDiffStat(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	withCwd(t, repo)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+	base := runGit(t, repo, "rev-parse", "--abbrev-ref", "HEAD")
+
+	wtPath := filepath.Join(t.TempDir(), "feature")
+	runGit(t, repo, "worktree", "add", "-b", "feature", wtPath)
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "new.txt"), []byte("one\ntwo\n"), 0o600))
+	runGit(t, wtPath, "add", "new.txt")
+	runGit(t, wtPath, "commit", "-m", "feature")
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "README.md"), []byte("# Changed\n"), 0o600))
+
+	// Commits made on the base after the branch diverged are not counted.
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "base.txt"), []byte("base\n"), 0o600))
+	runGit(t, repo, "add", "base.txt")
+	runGit(t, repo, "commit", "-m", "base")
+
+	stat, err := service.DiffStat(ctx, wtPath, base)
+	require.NoError(t, err)
+	assert.Equal(t, models.DiffStat{Added: 3, Removed: 1, Files: 2}, stat)
+
+	_, err = service.DiffStat(ctx, wtPath, "does-not-exist")
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// MergeConflicts predicts, without touching any worktree, whether merging
+// theirs into ours would conflict, and returns the conflicting paths. It
+// needs git merge-tree --write-tree, available since git 2.38.
+func (s *Service) MergeConflicts(ctx context.Context, cwd, ours, theirs string) ([]string, error) {
+	out, err := s.RunGitWithCombinedOutput(ctx, []string{
+		"git", "merge-tree", "--write-tree", "--name-only", "--no-messages", ours, theirs,
+	}, cwd, nil)
+	if err == nil {
+		return nil, nil
+	}
+	// Exit status 1 with the resulting tree printed first means the merge has
+	// conflicts; git also exits with 1 on refs it cannot merge.
+	var exitErr *exec.ExitError
+	tree, _, _ := strings.Cut(string(out), "\n")
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || !isObjectID(tree) {
+		return nil, fmt.Errorf("failed to merge %s into %s: %s", theirs, ours, strings.TrimSpace(string(out)))
+	}
+	return parseMergeTreeConflicts(string(out)), nil
+}
+
+// isObjectID reports whether value is a full SHA-1 or SHA-256 object name.
+func isObjectID(value string) bool {
+	if len(value) != 40 && len(value) != 64 {
+		return false
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMergeTreeConflicts returns the conflicted file info of git merge-tree
+// --write-tree --name-only output: the paths listed after the tree OID, up to
+// the blank line starting the informational messages. A path with several
+// conflicting stages is listed once.
+func parseMergeTreeConflicts(out string) []string {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+	var paths []string
+	seen := make(map[string]bool)
+	for _, line := range lines[1:] {
+		if line == "" {
+			break
+		}
+		if !seen[line] {
+			seen[line] = true
+			paths = append(paths, line)
+		}
+	}
+	return paths
+}
+
+// ChangedFiles returns the paths changed on head since it diverged from base.
+func (s *Service) ChangedFiles(ctx context.Context, cwd, base, head string) []string {
+	raw := s.RunGit(ctx, []string{"git", "diff", "--name-only", base + "..." + head}, cwd, []int{0}, true, true)
+	var files []string
+	for line := range strings.SplitSeq(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMergeTreeConflicts(t *testing.T) {
+	t.Parallel()
+	out := "4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"shared.txt\n" +
+		"shared.txt\n" +
+		"other/file.go\n" +
+		"\n" +
+		"Auto-merging shared.txt\n"
+
+	assert.Equal(t, []string{"shared.txt", "other/file.go"}, parseMergeTreeConflicts(out))
+	assert.Empty(t, parseMergeTreeConflicts("4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"))
+}
+
+func TestMergeConflicts(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	withCwd(t, repo)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+	base := runGit(t, repo, "rev-parse", "--abbrev-ref", "HEAD")
+
+	runGit(t, repo, "branch", "clean")
+	runGit(t, repo, "checkout", "-b", "conflicting")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("conflicting\n"), 0o600))
+	runGit(t, repo, "commit", "-am", "conflicting")
+	runGit(t, repo, "checkout", base)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("base\n"), 0o600))
+	runGit(t, repo, "commit", "-am", "base")
+
+	if version := runGit(t, repo, "version"); !supportsMergeTreeWriteTree(version) {
+		t.Skipf("git merge-tree --write-tree is not supported by %s", version)
+	}
+
+	paths, err := service.MergeConflicts(ctx, repo, base, "conflicting")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, paths)
+
+	paths, err = service.MergeConflicts(ctx, repo, base, "clean")
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	_, err = service.MergeConflicts(ctx, repo, base, "does-not-exist")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"README.md"}, service.ChangedFiles(ctx, repo, base, "conflicting"))
+	assert.Empty(t, service.ChangedFiles(ctx, repo, base, "clean"))
+}
+
+// supportsMergeTreeWriteTree reports whether the git version output is 2.38
+// or later.
+func supportsMergeTreeWriteTree(version string) bool {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "git version %d.%d", &major, &minor); err != nil {
+		return false
+	}
+	return major > 2 || (major == 2 && minor >= 38)
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// Actions accepted by RunOperation.
+const (
+	OperationContinue = "continue"
+	OperationSkip     = "skip"
+	OperationAbort    = "abort"
+)
+
+// InProgressOperation returns the rebase, merge or cherry-pick stopped in the
+// worktree at path, or "" when none is. It only looks at the worktree's git
+// directory, so it costs no git call.
+func InProgressOperation(path string) string {
+	gitDir := worktreeGitDir(path)
+	if gitDir == "" {
+		return ""
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+	switch {
+	case exists("rebase-merge"), exists("rebase-apply"), exists("REBASE_HEAD"):
+		return models.OperationRebase
+	case exists("MERGE_HEAD"):
+		return models.OperationMerge
+	case exists("CHERRY_PICK_HEAD"):
+		return models.OperationCherryPick
+	}
+	return ""
+}
+
+// UnmergedFiles lists the files with unresolved conflicts in the worktree at
+// path.
+func (s *Service) UnmergedFiles(ctx context.Context, path string) []string {
+	raw := s.RunGit(ctx, []string{"git", "diff", "--name-only", "--diff-filter=U"}, path, []int{0}, true, true)
+	var files []string
+	for line := range strings.SplitSeq(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// MarkResolved stages filename in the worktree at path, marking its conflicts
+// as resolved.
+func (s *Service) MarkResolved(ctx context.Context, path, filename string) error {
+	if !s.RunCommandChecked(ctx, []string{"git", "add", "--", filename}, path, fmt.Sprintf("Failed to mark %s as resolved", filename)) {
+		return fmt.Errorf("failed to mark %s as resolved", filename)
+	}
+	return nil
+}
+
+// RunOperation continues, skips or aborts the operation in progress in the
+// worktree at path. Commit messages are taken as prepared by git, without
+// opening an editor.
+func (s *Service) RunOperation(ctx context.Context, path, operation, action string) error {
+	if operation == "" {
+		return fmt.Errorf("no rebase, merge or cherry-pick in progress")
+	}
+	if action == OperationSkip && operation == models.OperationMerge {
+		return fmt.Errorf("a merge cannot be skipped, abort it instead")
+	}
+	args := []string{"git", operation, "--" + action}
+	s.debugf("run: %s (cwd=%s)", strings.Join(args, " "), path)
+
+	cmd, err := s.prepareAllowedCommand(ctx, args)
+	if err != nil {
+		return err
+	}
+	cmd.Dir = path
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if detail := strings.TrimSpace(string(output)); detail != "" {
+			return errors.New(detail)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCherryPickConflictResolution(t *testing.T) {
+	t.Parallel()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	file := filepath.Join(repo, "shared.txt")
+	require.NoError(t, os.WriteFile(file, []byte("base\n"), 0o600))
+	runGit(t, repo, "add", "shared.txt")
+	runGit(t, repo, "commit", "-m", "base")
+
+	wtPath := filepath.Join(t.TempDir(), "feature")
+	runGit(t, repo, "worktree", "add", "-b", "feature", wtPath)
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "shared.txt"), []byte("feature\n"), 0o600))
+	runGit(t, wtPath, "commit", "-am", "feature")
+
+	require.NoError(t, os.WriteFile(file, []byte("main\n"), 0o600))
+	runGit(t, repo, "commit", "-am", "main")
+	sha := runGit(t, repo, "rev-parse", "HEAD")
+	assert.Empty(t, InProgressOperation(wtPath))
+
+	ok, err := service.CherryPickCommit(ctx, sha, wtPath)
+	require.Error(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, models.OperationCherryPick, InProgressOperation(wtPath))
+	assert.Equal(t, []string{"shared.txt"}, service.UnmergedFiles(ctx, wtPath))
+
+	require.Error(t, service.RunOperation(ctx, wtPath, models.OperationCherryPick, OperationContinue), "conflicts are not resolved yet")
+
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "shared.txt"), []byte("both\n"), 0o600))
+	require.NoError(t, service.MarkResolved(ctx, wtPath, "shared.txt"))
+	assert.Empty(t, service.UnmergedFiles(ctx, wtPath))
+	require.NoError(t, service.RunOperation(ctx, wtPath, models.OperationCherryPick, OperationContinue))
+	assert.Empty(t, InProgressOperation(wtPath))
+	assert.Equal(t, "main", runGit(t, wtPath, "log", "-1", "--format=%s"))
+}
+
+func TestMergeConflictAbort(t *testing.T) {
+	t.Parallel()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	file := filepath.Join(repo, "shared.txt")
+	require.NoError(t, os.WriteFile(file, []byte("base\n"), 0o600))
+	runGit(t, repo, "add", "shared.txt")
+	runGit(t, repo, "commit", "-m", "base")
+	runGit(t, repo, "checkout", "-b", "other")
+	require.NoError(t, os.WriteFile(file, []byte("other\n"), 0o600))
+	runGit(t, repo, "commit", "-am", "other")
+	runGit(t, repo, "checkout", "-")
+	require.NoError(t, os.WriteFile(file, []byte("main\n"), 0o600))
+	runGit(t, repo, "commit", "-am", "main")
+
+	assert.False(t, service.RunCommandChecked(ctx, []string{"git", "merge", "--no-edit", "other"}, repo, "merge"))
+	assert.Equal(t, models.OperationMerge, InProgressOperation(repo))
+
+	require.Error(t, service.RunOperation(ctx, repo, models.OperationMerge, OperationSkip))
+	require.NoError(t, service.RunOperation(ctx, repo, models.OperationMerge, OperationAbort))
+	assert.Empty(t, InProgressOperation(repo))
+	require.Error(t, service.RunOperation(ctx, repo, "", OperationAbort))
+}
@@ -0,0 +1,196 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// FileDiff returns the unified diff of filename in the worktree at path: its
+// unstaged changes, or the staged ones with cached. Renames are not detected
+// and the a/ and b/ prefixes are forced so the diff can be applied back.
+func (s *Service) FileDiff(ctx context.Context, path, filename string, cached bool) string {
+	args := []string{"git", "diff", "--no-color", "--no-ext-diff", "--no-renames", "--src-prefix=a/", "--dst-prefix=b/"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	args = append(args, "--", filename)
+	return s.RunGit(ctx, args, path, []int{0}, false, false)
+}
+
+// ApplyPatch applies patch to the index of the worktree at path with cached,
+// or to its files otherwise. With reverse, the patch is reverted instead.
+func (s *Service) ApplyPatch(ctx context.Context, path, patch string, cached, reverse bool) error {
+	args := []string{"git", "apply", "--whitespace=nowarn"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	args = append(args, "-")
+	s.debugf("run: %s (cwd=%s)", strings.Join(args, " "), path)
+
+	cmd, err := s.prepareAllowedCommand(ctx, args)
+	if err != nil {
+		return err
+	}
+	cmd.Dir = path
+	cmd.Stdin = strings.NewReader(patch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if detail := strings.TrimSpace(string(output)); detail != "" {
+			return errors.New(detail)
+		}
+		return err
+	}
+	return nil
+}
+
+// ParseFileDiff splits the unified diff of a single file into its header and
+// hunks. Binary diffs have no hunks.
+func ParseFileDiff(raw string) *models.FileDiff {
+	diff := &models.FileDiff{}
+	var hunk *models.DiffHunk
+	for line := range strings.SplitSeq(strings.TrimSuffix(raw, "\n"), "\n") {
+		if match := hunkHeaderRe.FindStringSubmatch(line); match != nil {
+			diff.Hunks = append(diff.Hunks, models.DiffHunk{
+				OldStart: atoiDefault(match[1], 0),
+				OldLines: atoiDefault(match[2], 1),
+				NewStart: atoiDefault(match[3], 0),
+				NewLines: atoiDefault(match[4], 1),
+				Section:  match[5],
+			})
+			hunk = &diff.Hunks[len(diff.Hunks)-1]
+			continue
+		}
+		switch {
+		case line == "":
+		case hunk == nil:
+			diff.Header = append(diff.Header, line)
+		default:
+			hunk.Lines = append(hunk.Lines, line)
+		}
+	}
+	return diff
+}
+
+func atoiDefault(value string, fallback int) int {
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+// BuildHunkPatch returns a patch holding the changed lines from..to (indexes
+// into Lines) of hunk index, for git apply. Changes outside the range are
+// left out: when applied forward, their removed lines become context; with
+// reverse, their added lines do, as they are present in the target.
+func BuildHunkPatch(diff *models.FileDiff, index, from, to int, reverse bool) (string, error) {
+	if diff == nil || index < 0 || index >= len(diff.Hunks) {
+		return "", fmt.Errorf("no such hunk")
+	}
+	hunk := diff.Hunks[index]
+	from = max(from, 0)
+	to = min(to, len(hunk.Lines)-1)
+
+	partial := false
+	for i := range hunk.Lines {
+		if hunk.IsChange(i) && (i < from || i > to) {
+			partial = true
+			break
+		}
+	}
+	if partial {
+		for _, line := range diff.Header {
+			if strings.HasPrefix(line, "new file mode") || strings.HasPrefix(line, "deleted file mode") {
+				return "", fmt.Errorf("lines of added or deleted files cannot be selected, use the whole hunk")
+			}
+		}
+	}
+
+	var (
+		lines              []string
+		oldLines, newLines int
+		changes            int
+		kept               bool
+	)
+	for i, line := range hunk.Lines {
+		if line == "" {
+			continue
+		}
+		selected := i >= from && i <= to
+		switch line[0] {
+		case ' ':
+			oldLines++
+			newLines++
+		case '+':
+			switch {
+			case selected:
+				newLines++
+				changes++
+			case reverse:
+				line = " " + line[1:]
+				oldLines++
+				newLines++
+			default:
+				kept = false
+				continue
+			}
+		case '-':
+			switch {
+			case selected:
+				oldLines++
+				changes++
+			case !reverse:
+				line = " " + line[1:]
+				oldLines++
+				newLines++
+			default:
+				kept = false
+				continue
+			}
+		case '\\':
+			// "No newline at end of file" belongs to the line before it
+			if !kept {
+				continue
+			}
+		}
+		kept = true
+		lines = append(lines, line)
+	}
+	if changes == 0 {
+		return "", fmt.Errorf("no changes selected")
+	}
+
+	oldStart, newStart := hunk.OldStart, hunk.NewStart
+	if oldLines > 0 && oldStart == 0 {
+		oldStart = 1
+	}
+	if newLines > 0 && newStart == 0 {
+		newStart = 1
+	}
+
+	var b strings.Builder
+	for _, line := range diff.Header {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLines, newStart, newLines)
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const twoHunkDiff = `diff --git a/file.txt b/file.txt
+index 1111111..2222222 100644
+--- a/file.txt
++++ b/file.txt
+@@ -1,4 +1,5 @@ func first
+ one
+-two
++TWO
++two and a half
+ three
+ four
+@@ -10,2 +11,2 @@
+ ten
+-eleven
++ELEVEN
+\ No newline at end of file
+`
+
+func TestParseFileDiff(t *testing.T) {
+	t.Parallel()
+	diff := ParseFileDiff(twoHunkDiff)
+
+	require.Len(t, diff.Header, 4)
+	require.Len(t, diff.Hunks, 2)
+	first := diff.Hunks[0]
+	assert.Equal(t, 1, first.OldStart)
+	assert.Equal(t, 4, first.OldLines)
+	assert.Equal(t, 5, first.NewLines)
+	assert.Equal(t, "func first", first.Section)
+	assert.Len(t, first.Lines, 6)
+	assert.True(t, first.IsChange(1))
+	assert.False(t, first.IsChange(0))
+	assert.Equal(t, 11, diff.Hunks[1].NewStart)
+	assert.Equal(t, `\ No newline at end of file`, diff.Hunks[1].Lines[3])
+
+	assert.Empty(t, ParseFileDiff("diff --git a/x b/x\nBinary files a/x and b/x differ\n").Hunks)
+}
+
+func TestBuildHunkPatch(t *testing.T) {
+	t.Parallel()
+	diff := ParseFileDiff(twoHunkDiff)
+
+	whole, err := BuildHunkPatch(diff, 0, 0, len(diff.Hunks[0].Lines)-1, false)
+	require.NoError(t, err)
+	assert.Contains(t, whole, "@@ -1,4 +1,5 @@\n one\n-two\n+TWO\n+two and a half\n three\n four\n")
+	assert.True(t, strings.HasPrefix(whole, "diff --git a/file.txt b/file.txt\n"))
+
+	// Staging only "+TWO": "-two" stays as context and the other addition is left out
+	forward, err := BuildHunkPatch(diff, 0, 2, 2, false)
+	require.NoError(t, err)
+	assert.Contains(t, forward, "@@ -1,4 +1,5 @@\n one\n two\n+TWO\n three\n four\n")
+
+	// Unstaging only "+TWO": the other addition is in the index, so it is
+	// context, while "-two" is not and is left out
+	reverse, err := BuildHunkPatch(diff, 0, 2, 2, true)
+	require.NoError(t, err)
+	assert.Contains(t, reverse, "@@ -1,4 +1,5 @@\n one\n+TWO\n two and a half\n three\n four\n")
+
+	// The missing newline belongs to the addition left out
+	last, err := BuildHunkPatch(diff, 1, 1, 1, false)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(last, "@@ -10,2 +11,1 @@\n ten\n-eleven\n"), last)
+
+	_, err = BuildHunkPatch(diff, 0, 0, 0, false)
+	require.Error(t, err, "context lines alone are not a change")
+	_, err = BuildHunkPatch(diff, 5, 0, 0, false)
+	require.Error(t, err)
+}
+
+func TestBuildHunkPatchRejectsPartialNewFile(t *testing.T) {
+	t.Parallel()
+	diff := ParseFileDiff("diff --git a/n b/n\nnew file mode 100644\n--- /dev/null\n+++ b/n\n@@ -0,0 +1,2 @@\n+a\n+b\n")
+
+	_, err := BuildHunkPatch(diff, 0, 0, 0, false)
+	require.Error(t, err)
+	patch, err := BuildHunkPatch(diff, 0, 0, 1, false)
+	require.NoError(t, err)
+	assert.Contains(t, patch, "@@ -0,0 +1,2 @@")
+}
+
+func TestApplyPatchStagesSelectedLines(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	withCwd(t, repo)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	file := filepath.Join(repo, "lines.txt")
+	require.NoError(t, os.WriteFile(file, []byte("one\ntwo\nthree\n"), 0o600))
+	runGit(t, repo, "add", "lines.txt")
+	runGit(t, repo, "commit", "-m", "lines")
+	require.NoError(t, os.WriteFile(file, []byte("ONE\ntwo\nTHREE\n"), 0o600))
+
+	diff := ParseFileDiff(service.FileDiff(ctx, repo, "lines.txt", false))
+	require.Len(t, diff.Hunks, 1)
+	// Lines are -one +ONE two -three +THREE: stage the first change only
+	patch, err := BuildHunkPatch(diff, 0, 0, 1, false)
+	require.NoError(t, err)
+	require.NoError(t, service.ApplyPatch(ctx, repo, patch, true, false))
+	assert.Equal(t, "ONE\ntwo\nthree\n", runGit(t, repo, "show", ":lines.txt")+"\n")
+
+	// Unstage it again from the staged diff
+	staged := ParseFileDiff(service.FileDiff(ctx, repo, "lines.txt", true))
+	require.Len(t, staged.Hunks, 1)
+	patch, err = BuildHunkPatch(staged, 0, 0, len(staged.Hunks[0].Lines)-1, true)
+	require.NoError(t, err)
+	require.NoError(t, service.ApplyPatch(ctx, repo, patch, true, true))
+	assert.Empty(t, runGit(t, repo, "diff", "--cached", "--name-only"))
+
+	// Discard the last change from the worktree
+	diff = ParseFileDiff(service.FileDiff(ctx, repo, "lines.txt", false))
+	patch, err = BuildHunkPatch(diff, 0, 3, 4, true)
+	require.NoError(t, err)
+	require.NoError(t, service.ApplyPatch(ctx, repo, patch, false, true))
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "ONE\ntwo\nthree\n", string(data))
+
+	require.Error(t, service.ApplyPatch(ctx, repo, "not a patch", true, false))
+}
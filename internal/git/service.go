@@ -426,46 +426,16 @@ func (s *Service) GetMergedBranches(ctx context.Context, baseBranch string) []st
 
 // GetWorktrees parses git worktree metadata and returns the list of worktrees.
 // This method concurrently fetches status information for each worktree to improve performance.
-// The first worktree in the list is marked as the main worktree.
+// The first worktree in the list is marked as the main worktree, unless the
+// repository is bare, in which case the bare directory is left out and no
+// worktree is main.
 func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, error) {
 	rawWts := s.RunGit(ctx, []string{"git", "worktree", "list", "--porcelain"}, "", []int{0}, true, false)
 	if rawWts == "" {
 		return []*models.WorktreeInfo{}, nil
 	}
 
-	type wtData struct {
-		path   string
-		branch string
-		isMain bool
-	}
-
-	var wts []wtData
-	var currentWt *wtData
-
-	lines := strings.SplitSeq(rawWts, "\n")
-	for line := range lines {
-		if strings.HasPrefix(line, "worktree ") {
-			if currentWt != nil {
-				wts = append(wts, *currentWt)
-			}
-			path := strings.TrimPrefix(line, "worktree ")
-			currentWt = &wtData{path: path}
-		} else if strings.HasPrefix(line, "branch ") {
-			if currentWt != nil {
-				branch := strings.TrimPrefix(line, "branch ")
-				branch = strings.TrimPrefix(branch, "refs/heads/")
-				currentWt.branch = branch
-			}
-		}
-	}
-	if currentWt != nil {
-		wts = append(wts, *currentWt)
-	}
-
-	// Mark first as main
-	for i := range wts {
-		wts[i].isMain = (i == 0)
-	}
+	wts, mainIndex := usableWorktrees(parseWorktreeList(rawWts))
 
 	branchRaw := s.RunGit(ctx, []string{
 		"git", "for-each-ref",
@@ -493,6 +463,8 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 		}
 	}
 
+	baseRefs := s.BaseRefs(ctx, "")
+
 	// Get worktree info concurrently
 	type result struct {
 		wt  *models.WorktreeInfo
@@ -502,18 +474,40 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 	results := make(chan result, len(wts))
 	var wg sync.WaitGroup
 
-	for _, wt := range wts {
+	for i, wt := range wts {
 		wg.Add(1)
-		go func(wtData wtData) {
+		go func(record worktreeRecord, isMain bool) {
 			defer wg.Done()
-			s.acquireSemaphore()
-			defer s.releaseSemaphore()
 
-			path := wtData.path
-			branch := wtData.branch
+			path := record.path
+			branch := record.branch
 			if branch == "" {
 				branch = "(detached)"
 			}
+			wt := &models.WorktreeInfo{
+				Path:           path,
+				Branch:         branch,
+				IsMain:         isMain,
+				Head:           record.head,
+				Detached:       record.detached,
+				Locked:         record.locked,
+				LockReason:     record.lockReason,
+				Prunable:       record.prunable,
+				PrunableReason: record.prunableReason,
+				BaseRef:        baseRefs[branch],
+			}
+			if info, exists := branchInfo[branch]; exists {
+				wt.LastActive = info.lastActive
+				wt.LastActiveTS = info.lastActiveTS
+			}
+			// The directory of a prunable worktree is gone, there is no status to read.
+			if record.prunable {
+				results <- result{wt: wt, err: nil}
+				return
+			}
+
+			s.acquireSemaphore()
+			defer s.releaseSemaphore()
 
 			statusRaw := s.RunGit(ctx, []string{"git", "status", "--porcelain=v2", "--branch"}, path, []int{0}, true, false)
 
@@ -524,6 +518,7 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 			untracked := 0
 			modified := 0
 			staged := 0
+			conflicts := 0
 
 			for _, line := range strings.Split(statusRaw, "\n") {
 				switch {
@@ -542,6 +537,8 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 					}
 				case strings.HasPrefix(line, "?"):
 					untracked++
+				case strings.HasPrefix(line, "u "):
+					conflicts++
 				case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
 					parts := strings.Fields(line)
 					if len(parts) > 1 {
@@ -569,33 +566,21 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 				}
 			}
 
-			info, exists := branchInfo[branch]
-			lastActive := ""
-			lastActiveTS := int64(0)
-			if exists {
-				lastActive = info.lastActive
-				lastActiveTS = info.lastActiveTS
-			}
-
-			wt := &models.WorktreeInfo{
-				Path:           path,
-				Branch:         branch,
-				IsMain:         wtData.isMain,
-				Dirty:          (untracked + modified + staged) > 0,
-				Ahead:          ahead,
-				Behind:         behind,
-				Unpushed:       unpushed,
-				HasUpstream:    hasUpstream,
-				UpstreamBranch: upstreamBranch,
-				LastActive:     lastActive,
-				LastActiveTS:   lastActiveTS,
-				Untracked:      untracked,
-				Modified:       modified,
-				Staged:         staged,
-			}
+			wt.Dirty = (untracked + modified + staged + conflicts) > 0
+			wt.Ahead = ahead
+			wt.Behind = behind
+			wt.Unpushed = unpushed
+			wt.HasUpstream = hasUpstream
+			wt.UpstreamBranch = upstreamBranch
+			wt.Untracked = untracked
+			wt.Modified = modified
+			wt.Staged = staged
+			wt.Sparse, wt.SparseDirs = s.sparseCheckout(ctx, path)
+			wt.Operation = InProgressOperation(path)
+			wt.Conflicts = conflicts
 
 			results <- result{wt: wt, err: nil}
-		}(wt)
+		}(wt, i == mainIndex)
 	}
 
 	wg.Wait()
@@ -1578,10 +1563,8 @@ func (s *Service) gitlabStatusToConclusion(status string) string {
 // GetMainWorktreePath returns the path of the main worktree.
 func (s *Service) GetMainWorktreePath(ctx context.Context) string {
 	rawWts := s.RunGit(ctx, []string{"git", "worktree", "list", "--porcelain"}, "", []int{0}, true, false)
-	for _, line := range strings.Split(rawWts, "\n") {
-		if strings.HasPrefix(line, "worktree ") {
-			return strings.TrimPrefix(line, "worktree ")
-		}
+	if records := parseWorktreeList(rawWts); len(records) > 0 {
+		return records[0].path
 	}
 	cwd, _ := os.Getwd()
 	return cwd
@@ -1818,7 +1801,9 @@ func (s *Service) CheckoutPRBranch(ctx context.Context, prNumber int, remoteBran
 }
 
 // CherryPickCommit applies a commit to a target worktree.
-// Returns true on success, false on failure (including conflicts).
+// Returns true on success, false on failure (including conflicts). On
+// conflicts the cherry-pick is left in progress, to be resolved, skipped or
+// aborted; any other failure is aborted.
 func (s *Service) CherryPickCommit(ctx context.Context, commitSHA, targetPath string) (bool, error) {
 	// Check if there are uncommitted changes in target worktree
 	statusRaw := s.RunGit(ctx, []string{"git", "status", "--porcelain"}, targetPath, []int{0}, true, false)
@@ -1837,13 +1822,12 @@ func (s *Service) CherryPickCommit(ctx context.Context, commitSHA, targetPath st
 	if err != nil {
 		// Cherry-pick failed - check if it's due to conflicts
 		detail := strings.TrimSpace(string(output))
+		if (strings.Contains(detail, "conflict") || strings.Contains(detail, "CONFLICT")) && InProgressOperation(targetPath) == models.OperationCherryPick {
+			return false, fmt.Errorf("cherry-pick conflicts occurred: %s", detail)
+		}
 
 		// Abort the cherry-pick to leave worktree clean
 		s.RunCommandChecked(ctx, []string{"git", "cherry-pick", "--abort"}, targetPath, "Failed to abort cherry-pick")
-
-		if strings.Contains(detail, "conflict") || strings.Contains(detail, "CONFLICT") {
-			return false, fmt.Errorf("cherry-pick conflicts occurred: %s", detail)
-		}
 		return false, fmt.Errorf("cherry-pick failed: %s", detail)
 	}
 
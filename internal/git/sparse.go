@@ -0,0 +1,63 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// worktreeGitDir returns the git directory of the worktree at path: the .git
+// directory of the main worktree, or the directory a linked worktree's .git
+// file points to.
+func worktreeGitDir(path string) string {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return ""
+	}
+	if info.IsDir() {
+		return dotGit
+	}
+
+	// #nosec G304 -- reading the .git file of a known worktree
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return gitDir
+}
+
+// sparseCheckout reports whether the worktree at path has sparse-checkout
+// enabled, and the directories (or patterns, outside cone mode) it checks
+// out. Worktrees that never had sparse-checkout set up cost no git call.
+func (s *Service) sparseCheckout(ctx context.Context, path string) (bool, []string) {
+	gitDir := worktreeGitDir(path)
+	if gitDir == "" {
+		return false, nil
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "info", "sparse-checkout")); err != nil {
+		return false, nil
+	}
+
+	// sparse-checkout list fails once sparse-checkout is disabled.
+	raw := s.RunGit(ctx, []string{"git", "sparse-checkout", "list"}, path, []int{0}, true, true)
+	if raw == "" {
+		return false, nil
+	}
+	var dirs []string
+	for line := range strings.SplitSeq(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return true, dirs
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseCheckout(t *testing.T) {
+	t.Parallel()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "web"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "api"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "web", "index.html"), []byte("web"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "api", "main.go"), []byte("api"), 0o600))
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "layout")
+
+	wtPath := filepath.Join(t.TempDir(), "sparse")
+	runGit(t, repo, "worktree", "add", "--no-checkout", "-b", "sparse", wtPath)
+	runGit(t, wtPath, "sparse-checkout", "set", "--cone", "web")
+	runGit(t, wtPath, "checkout")
+
+	t.Run("main worktree is not sparse", func(t *testing.T) {
+		sparse, dirs := service.sparseCheckout(ctx, repo)
+		assert.False(t, sparse)
+		assert.Empty(t, dirs)
+	})
+
+	t.Run("linked worktree lists its directories", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(repo, ".git", "worktrees", "sparse"), worktreeGitDir(wtPath))
+		sparse, dirs := service.sparseCheckout(ctx, wtPath)
+		assert.True(t, sparse)
+		assert.Equal(t, []string{"web"}, dirs)
+		assert.NoFileExists(t, filepath.Join(wtPath, "api", "main.go"))
+	})
+
+	t.Run("disabled sparse-checkout", func(t *testing.T) {
+		runGit(t, wtPath, "sparse-checkout", "disable")
+		sparse, dirs := service.sparseCheckout(ctx, wtPath)
+		assert.False(t, sparse)
+		assert.Empty(t, dirs)
+	})
+}
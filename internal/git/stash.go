@@ -0,0 +1,198 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// stashListFormat separates the reflog selector, stash commit, creation time
+// and reflog subject of each stash.
+const stashListFormat = "%gd%x1f%H%x1f%ct%x1f%gs"
+
+// ListStashes returns the stashes of the repository, most recent first.
+func (s *Service) ListStashes(ctx context.Context) []*models.StashEntry {
+	raw := s.RunGit(ctx, []string{"git", "stash", "list", "--format=" + stashListFormat}, "", []int{0}, true, true)
+	return parseStashList(raw)
+}
+
+// parseStashList parses git stash list output in stashListFormat.
+func parseStashList(raw string) []*models.StashEntry {
+	var stashes []*models.StashEntry
+	for line := range strings.SplitSeq(raw, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "\x1f", 4)
+		if len(parts) != 4 || !strings.HasPrefix(parts[0], "stash@{") {
+			continue
+		}
+		entry := &models.StashEntry{Ref: parts[0], SHA: parts[1]}
+		if ts, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+			entry.Created = time.Unix(ts, 0)
+		}
+		entry.Branch, entry.Message = parseStashSubject(parts[3])
+		stashes = append(stashes, entry)
+	}
+	return stashes
+}
+
+// parseStashSubject splits a stash reflog subject such as
+// "WIP on main: abc1234 Commit subject" or "On main: message" into the
+// branch and the message.
+func parseStashSubject(subject string) (branch, message string) {
+	rest, ok := strings.CutPrefix(subject, "WIP on ")
+	if !ok {
+		rest, ok = strings.CutPrefix(subject, "On ")
+	}
+	if !ok {
+		return "", subject
+	}
+	branch, message, ok = strings.Cut(rest, ": ")
+	if !ok {
+		return "", subject
+	}
+	return branch, message
+}
+
+// FindStash returns the stash matching ref: a stash@{N} selector, its index
+// N, or a (possibly abbreviated) stash commit.
+func FindStash(stashes []*models.StashEntry, ref string) (*models.StashEntry, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("no stash given")
+	}
+	if _, err := strconv.Atoi(ref); err == nil {
+		ref = fmt.Sprintf("stash@{%s}", ref)
+	}
+	for _, stash := range stashes {
+		if stash.Ref == ref || (len(ref) >= 4 && strings.HasPrefix(stash.SHA, ref)) {
+			return stash, nil
+		}
+	}
+	return nil, fmt.Errorf("stash not found: %s", ref)
+}
+
+// ApplyStash applies the stash to the worktree at path, restoring the index
+// too. With pop, the stash is dropped once applied cleanly.
+func (s *Service) ApplyStash(ctx context.Context, stash *models.StashEntry, path string, pop bool) error {
+	action := "apply"
+	if pop {
+		action = "pop"
+	}
+	// pop needs the reflog selector, apply works on the stash commit itself
+	ref := stash.SHA
+	if pop {
+		var err error
+		if ref, err = s.currentStashRef(ctx, stash); err != nil {
+			return err
+		}
+	}
+	if err := s.runStashCommand(ctx, []string{"git", "stash", action, "--index", ref}, path); err != nil {
+		return fmt.Errorf("failed to %s %s: %w", action, stash.Ref, err)
+	}
+	return nil
+}
+
+// DropStash deletes the stash.
+func (s *Service) DropStash(ctx context.Context, stash *models.StashEntry) error {
+	ref, err := s.currentStashRef(ctx, stash)
+	if err != nil {
+		return err
+	}
+	if err := s.runStashCommand(ctx, []string{"git", "stash", "drop", ref}, ""); err != nil {
+		return fmt.Errorf("failed to drop %s: %w", ref, err)
+	}
+	return nil
+}
+
+// currentStashRef returns the stash@{N} selector of stash now. Pushing or
+// dropping other stashes shifts the index stash.Ref was listed with, so the
+// stash is looked up again by commit.
+func (s *Service) currentStashRef(ctx context.Context, stash *models.StashEntry) (string, error) {
+	current, err := FindStash(s.ListStashes(ctx), stash.SHA)
+	if err != nil {
+		return "", fmt.Errorf("%s is gone: %w", stash.Ref, err)
+	}
+	return current.Ref, nil
+}
+
+// StashChanges stashes the changes of the worktree at path, untracked files
+// included, and returns the new stash.
+func (s *Service) StashChanges(ctx context.Context, path, message string) (*models.StashEntry, error) {
+	statusRaw := s.RunGit(ctx, []string{"git", "status", "--porcelain"}, path, []int{0}, true, false)
+	if strings.TrimSpace(statusRaw) == "" {
+		return nil, fmt.Errorf("no changes to stash in %s", path)
+	}
+
+	args := []string{"git", "stash", "push", "--include-untracked"}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	if err := s.runStashCommand(ctx, args, path); err != nil {
+		return nil, fmt.Errorf("failed to stash changes: %w", err)
+	}
+
+	stashes := s.ListStashes(ctx)
+	if len(stashes) == 0 {
+		return nil, fmt.Errorf("failed to find the new stash")
+	}
+	return stashes[0], nil
+}
+
+// TransferStashMessage is the message of the stash carrying changes to the
+// worktree at targetPath, visible when a transfer leaves it behind.
+func TransferStashMessage(targetPath string) string {
+	return fmt.Sprintf("lazyworktree: move to %s", filepath.Base(targetPath))
+}
+
+// TransferChanges moves the changes of the worktree at sourcePath to the one
+// at targetPath through a stash. When they cannot be applied, the changes are
+// restored in the source worktree; when applying leaves conflicts, the stash
+// is kept so nothing is lost.
+func (s *Service) TransferChanges(ctx context.Context, sourcePath, targetPath, message string) error {
+	stash, err := s.StashChanges(ctx, sourcePath, message)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ApplyStash(ctx, stash, targetPath, false); err != nil {
+		if strings.Contains(err.Error(), "CONFLICT") {
+			return fmt.Errorf("changes applied to %s with conflicts, %s is kept: %w", targetPath, stash.Ref, err)
+		}
+		if popErr := s.ApplyStash(ctx, stash, sourcePath, true); popErr != nil {
+			return fmt.Errorf("%w; changes are kept in %s: %w", err, stash.Ref, popErr)
+		}
+		return err
+	}
+
+	// Nothing is left to clean up when the stash was dropped meanwhile
+	if _, err := FindStash(s.ListStashes(ctx), stash.SHA); err != nil {
+		return nil
+	}
+	return s.DropStash(ctx, stash)
+}
+
+// runStashCommand runs a git stash command and returns its output as the
+// error when it fails, so conflicts can be reported.
+func (s *Service) runStashCommand(ctx context.Context, args []string, cwd string) error {
+	s.debugf("run: %s (cwd=%s)", strings.Join(args, " "), cwd)
+	cmd, err := s.prepareAllowedCommand(ctx, args)
+	if err != nil {
+		return err
+	}
+	if cwd != "" {
+		cmd.Dir = cwd
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if detail := strings.TrimSpace(string(output)); detail != "" {
+			return errors.New(detail)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStashList(t *testing.T) {
+	t.Parallel()
+	raw := "stash@{0}\x1fabc123\x1f1700000000\x1fOn feature: wip login\n" +
+		"stash@{1}\x1fdef456\x1f1690000000\x1fWIP on main: 1234567 Initial commit\n" +
+		"garbage"
+
+	stashes := parseStashList(raw)
+	require.Len(t, stashes, 2)
+	assert.Equal(t, "stash@{0}", stashes[0].Ref)
+	assert.Equal(t, "abc123", stashes[0].SHA)
+	assert.Equal(t, "feature", stashes[0].Branch)
+	assert.Equal(t, "wip login", stashes[0].Message)
+	assert.Equal(t, int64(1700000000), stashes[0].Created.Unix())
+	assert.Equal(t, "main", stashes[1].Branch)
+	assert.Equal(t, "1234567 Initial commit", stashes[1].Message)
+}
+
+func TestFindStash(t *testing.T) {
+	t.Parallel()
+	stashes := []*models.StashEntry{
+		{Ref: "stash@{0}", SHA: "abc1234567"},
+		{Ref: "stash@{1}", SHA: "def1234567"},
+	}
+
+	for _, ref := range []string{"stash@{1}", "1", "def1234"} {
+		stash, err := FindStash(stashes, ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, "stash@{1}", stash.Ref)
+	}
+
+	_, err := FindStash(stashes, "stash@{5}")
+	require.Error(t, err)
+	_, err = FindStash(stashes, "abc")
+	require.Error(t, err, "short prefixes are ambiguous with indexes and must not match")
+}
+
+func TestTransferChanges(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	withCwd(t, repo)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	target := filepath.Join(t.TempDir(), "target")
+	runGit(t, repo, "worktree", "add", "-b", "target", target)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("changed"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "new.txt"), []byte("new"), 0o600))
+
+	require.NoError(t, service.TransferChanges(ctx, repo, target, "move to target"))
+
+	assert.Empty(t, runGit(t, repo, "status", "--porcelain"))
+	data, err := os.ReadFile(filepath.Join(target, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(data))
+	assert.FileExists(t, filepath.Join(target, "new.txt"))
+	assert.Empty(t, service.ListStashes(ctx), "the transfer stash is dropped")
+
+	err = service.TransferChanges(ctx, repo, target, "")
+	require.Error(t, err, "a clean worktree has nothing to transfer")
+}
+
+func TestApplyAndDropStash(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	withCwd(t, repo)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("first"), 0o600))
+	first, err := service.StashChanges(ctx, repo, "first")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("second"), 0o600))
+	_, err = service.StashChanges(ctx, repo, "second")
+	require.NoError(t, err)
+
+	stashes := service.ListStashes(ctx)
+	require.Len(t, stashes, 2)
+	assert.Equal(t, "second", stashes[0].Message)
+	assert.Equal(t, "stash@{0}", first.Ref, "listed before the second stash was pushed")
+
+	require.NoError(t, service.ApplyStash(ctx, first, repo, true))
+	data, err := os.ReadFile(filepath.Join(repo, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+	require.Error(t, service.DropStash(ctx, first), "a popped stash is gone")
+
+	stashes = service.ListStashes(ctx)
+	require.Len(t, stashes, 1)
+	assert.Equal(t, "second", stashes[0].Message)
+	require.NoError(t, service.DropStash(ctx, stashes[0]))
+	assert.Empty(t, service.ListStashes(ctx))
+}
@@ -0,0 +1,80 @@
+package git
+
+import "strings"
+
+// worktreeRecord is one entry of `git worktree list --porcelain`.
+type worktreeRecord struct {
+	path           string
+	head           string
+	branch         string
+	bare           bool
+	detached       bool
+	locked         bool
+	lockReason     string
+	prunable       bool
+	prunableReason string
+}
+
+// parseWorktreeList parses the output of `git worktree list --porcelain`.
+// Records are separated by blank lines and start with a "worktree" line;
+// the other attributes are optional.
+func parseWorktreeList(raw string) []worktreeRecord {
+	var records []worktreeRecord
+	var current *worktreeRecord
+
+	for line := range strings.SplitSeq(raw, "\n") {
+		line = strings.TrimRight(line, "\r")
+		key, value, _ := strings.Cut(line, " ")
+		if key == "worktree" {
+			if current != nil {
+				records = append(records, *current)
+			}
+			current = &worktreeRecord{path: value}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "HEAD":
+			current.head = value
+		case "branch":
+			current.branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.bare = true
+		case "detached":
+			current.detached = true
+		case "locked":
+			current.locked = true
+			current.lockReason = value
+		case "prunable":
+			current.prunable = true
+			current.prunableReason = value
+		}
+	}
+	if current != nil {
+		records = append(records, *current)
+	}
+	return records
+}
+
+// usableWorktrees drops the bare repository entry and reports which record is
+// the main worktree. Git always lists the main worktree first; in a bare
+// repository that entry is the bare directory and no worktree is the main
+// one, so the returned index is -1.
+func usableWorktrees(records []worktreeRecord) ([]worktreeRecord, int) {
+	mainIndex := 0
+	if len(records) == 0 || records[0].bare {
+		mainIndex = -1
+	}
+
+	usable := make([]worktreeRecord, 0, len(records))
+	for _, record := range records {
+		if record.bare {
+			continue
+		}
+		usable = append(usable, record)
+	}
+	return usable, mainIndex
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	t.Parallel()
+
+	raw := `worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /wt/detached
+HEAD 2222222222222222222222222222222222222222
+detached
+
+worktree /wt/locked
+HEAD 3333333333333333333333333333333333333333
+branch refs/heads/feature/locked
+locked on a usb drive
+
+worktree /wt/locked-no-reason
+HEAD 4444444444444444444444444444444444444444
+branch refs/heads/other
+locked
+
+worktree /wt/gone
+HEAD 5555555555555555555555555555555555555555
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+`
+
+	records := parseWorktreeList(raw)
+	assert.Equal(t, []worktreeRecord{
+		{path: "/repo", head: "1111111111111111111111111111111111111111", branch: "main"},
+		{path: "/wt/detached", head: "2222222222222222222222222222222222222222", detached: true},
+		{path: "/wt/locked", head: "3333333333333333333333333333333333333333", branch: "feature/locked", locked: true, lockReason: "on a usb drive"},
+		{path: "/wt/locked-no-reason", head: "4444444444444444444444444444444444444444", branch: "other", locked: true},
+		{path: "/wt/gone", head: "5555555555555555555555555555555555555555", branch: "gone", prunable: true, prunableReason: "gitdir file points to non-existent location"},
+	}, records)
+
+	usable, mainIndex := usableWorktrees(records)
+	assert.Len(t, usable, 5)
+	assert.Equal(t, 0, mainIndex)
+
+	assert.Empty(t, parseWorktreeList(""))
+}
+
+func TestUsableWorktreesBare(t *testing.T) {
+	t.Parallel()
+
+	raw := "worktree /srv/repo.git\nbare\n\nworktree /srv/repo/main\nHEAD abc\nbranch refs/heads/main\n\nworktree /srv/repo/feature\nHEAD def\nbranch refs/heads/feature\n"
+	usable, mainIndex := usableWorktrees(parseWorktreeList(raw))
+	assert.Equal(t, -1, mainIndex)
+	assert.Len(t, usable, 2)
+	assert.Equal(t, "/srv/repo/main", usable[0].path)
+	assert.Equal(t, "/srv/repo/feature", usable[1].path)
+}
@@ -0,0 +1,32 @@
+package models
+
+// FileDiff is the unified diff of a single file, split into hunks.
+type FileDiff struct {
+	Header []string // "diff --git", "index", "---" and "+++" lines
+	Hunks  []DiffHunk
+}
+
+// DiffHunk is one "@@" hunk of a FileDiff.
+type DiffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Section  string   // Text after the closing "@@", usually the enclosing function
+	Lines    []string // Lines with their ' ', '+', '-' or '\' prefix
+}
+
+// IsChange reports whether line i of the hunk is an added or removed line.
+func (h DiffHunk) IsChange(i int) bool {
+	if i < 0 || i >= len(h.Lines) || h.Lines[i] == "" {
+		return false
+	}
+	return h.Lines[i][0] == '+' || h.Lines[i][0] == '-'
+}
+
+// DiffStat summarises the changes of a branch against its base.
+type DiffStat struct {
+	Added   int
+	Removed int
+	Files   int
+}
@@ -10,6 +10,12 @@ type CommitFile struct {
 	OldPath    string // For renames: the original path
 }
 
+// CommitSummary is the abbreviated SHA and subject of a commit.
+type CommitSummary struct {
+	SHA     string
+	Subject string
+}
+
 // PRInfo captures the relevant metadata for a pull request.
 type PRInfo struct {
 	Number      int
@@ -52,6 +58,12 @@ type WorktreeInfo struct {
 	Path           string
 	Branch         string
 	IsMain         bool
+	Head           string // HEAD commit SHA
+	Detached       bool   // HEAD is not on a branch
+	Locked         bool
+	LockReason     string // Reason given to git worktree lock, if any
+	Prunable       bool   // The worktree directory is gone and git worktree prune would remove it
+	PrunableReason string
 	Dirty          bool
 	Ahead          int
 	Behind         int
@@ -68,6 +80,11 @@ type WorktreeInfo struct {
 	Modified       int
 	Staged         int
 	Divergence     string
+	Sparse         bool     // sparse-checkout is enabled
+	SparseDirs     []string // Directories checked out by sparse-checkout
+	Operation      string   // Rebase, merge or cherry-pick stopped in the worktree, see Operation* constants
+	Conflicts      int      // Files with unresolved conflicts
+	BaseRef        string   // Branch, tag or commit the branch was created from, if recorded
 }
 
 // WorktreeNote stores user-authored metadata for a worktree.
@@ -91,6 +108,13 @@ const (
 	WorktreeNotesFilename = ".worktree-notes.json"
 )
 
+// In-progress operation values for WorktreeInfo.Operation field.
+const (
+	OperationRebase     = "rebase"
+	OperationMerge      = "merge"
+	OperationCherryPick = "cherry-pick"
+)
+
 // PR fetch status values for WorktreeInfo.PRFetchStatus field.
 const (
 	PRFetchStatusNotFetched = "not_fetched" // PR data has not been fetched yet
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// StashEntry describes a stash of the repository. Stashes are shared by every
+// worktree.
+type StashEntry struct {
+	Ref     string // Reflog selector, e.g. "stash@{0}"; shifts as stashes come and go
+	SHA     string // Stash commit, stable across pushes and drops
+	Branch  string // Branch the stash was created on, "(no branch)" when detached
+	Message string
+	Created time.Time
+}
@@ -5,4 +5,9 @@ type StatusFile struct {
 	Filename    string
 	Status      string // XY status code (e.g., ".M", "M.", " ?")
 	IsUntracked bool
+	// IsSubmodule marks submodule entries; SubmoduleState describes what
+	// changed in them ("new commits", "modified content", "untracked content"
+	// or "uninitialised").
+	IsSubmodule    bool
+	SubmoduleState string
 }
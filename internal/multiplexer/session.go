@@ -0,0 +1,47 @@
+package multiplexer
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+)
+
+// lookPath and commandContext are replaced in tests.
+var (
+	lookPath       = exec.LookPath
+	commandContext = exec.CommandContext
+)
+
+// RenameWorktreeSessions renames the tmux and zellij sessions that use the
+// default name of a worktree (prefix followed by the directory name) after
+// the worktree moved from oldPath to newPath. Missing multiplexers and
+// sessions are ignored. Nothing is renamed without a prefix, since a session
+// named after the bare directory may not belong to the worktree. It returns
+// the sessions that were renamed.
+func RenameWorktreeSessions(ctx context.Context, prefix, oldPath, newPath string) []string {
+	if prefix == "" {
+		return nil
+	}
+	oldName := prefix + filepath.Base(oldPath)
+	newName := prefix + filepath.Base(newPath)
+	if oldName == newName {
+		return nil
+	}
+
+	var renamed []string
+	if _, err := lookPath("tmux"); err == nil {
+		oldTmux := SanitizeTmuxSessionName(oldName)
+		// #nosec G204 -- session names derived from worktree directories
+		if commandContext(ctx, "tmux", "rename-session", "-t", "="+oldTmux, SanitizeTmuxSessionName(newName)).Run() == nil {
+			renamed = append(renamed, "tmux:"+oldTmux)
+		}
+	}
+	if _, err := lookPath("zellij"); err == nil {
+		oldZellij := SanitizeZellijSessionName(oldName)
+		// #nosec G204 -- session names derived from worktree directories
+		if commandContext(ctx, "zellij", "--session", oldZellij, "action", "rename-session", SanitizeZellijSessionName(newName)).Run() == nil {
+			renamed = append(renamed, "zellij:"+oldZellij)
+		}
+	}
+	return renamed
+}
@@ -0,0 +1,63 @@
+package multiplexer
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stubSessionCommands(t *testing.T, available map[string]bool, failing string) *[]string {
+	t.Helper()
+	var calls []string
+	origLookPath, origCommandContext := lookPath, commandContext
+	t.Cleanup(func() {
+		lookPath, commandContext = origLookPath, origCommandContext
+	})
+	lookPath = func(file string) (string, error) {
+		if available[file] {
+			return "/usr/bin/" + file, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	commandContext = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		if name == failing {
+			return exec.CommandContext(ctx, "false")
+		}
+		return exec.CommandContext(ctx, "true")
+	}
+	return &calls
+}
+
+func TestRenameWorktreeSessions(t *testing.T) {
+	t.Run("renames tmux and zellij sessions", func(t *testing.T) {
+		calls := stubSessionCommands(t, map[string]bool{"tmux": true, "zellij": true}, "")
+		renamed := RenameWorktreeSessions(context.Background(), "wt-", "/old/feat:x", "/new/feat-y")
+		assert.Equal(t, []string{
+			"tmux rename-session -t =wt-feat-x wt-feat-y",
+			"zellij --session wt-feat-x action rename-session wt-feat-y",
+		}, *calls)
+		assert.Equal(t, []string{"tmux:wt-feat-x", "zellij:wt-feat-x"}, renamed)
+	})
+
+	t.Run("same directory name", func(t *testing.T) {
+		calls := stubSessionCommands(t, map[string]bool{"tmux": true, "zellij": true}, "")
+		assert.Empty(t, RenameWorktreeSessions(context.Background(), "wt-", "/old/feat", "/new/feat"))
+		assert.Empty(t, *calls)
+	})
+
+	t.Run("no prefix", func(t *testing.T) {
+		calls := stubSessionCommands(t, map[string]bool{"tmux": true, "zellij": true}, "")
+		assert.Empty(t, RenameWorktreeSessions(context.Background(), "", "/old/a", "/old/b"))
+		assert.Empty(t, *calls)
+	})
+
+	t.Run("missing session and multiplexer", func(t *testing.T) {
+		calls := stubSessionCommands(t, map[string]bool{"tmux": true}, "tmux")
+		assert.Empty(t, RenameWorktreeSessions(context.Background(), "wt-", "/old/a", "/old/b"))
+		assert.Equal(t, []string{"tmux rename-session -t =wt-a wt-b"}, *calls)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/chmouel/lazyworktree/internal/utils"
@@ -38,6 +39,7 @@ type TrustManager struct {
 	mu            sync.RWMutex
 	dbPath        string
 	trustedHashes map[string]string // Map absolute path -> sha256 hash
+	loadErr       error
 }
 
 // NewTrustManager creates and loads the persisted trust database.
@@ -57,6 +59,7 @@ func (tm *TrustManager) load() {
 
 	data, err := os.ReadFile(tm.dbPath)
 	if err != nil {
+		tm.loadErr = err
 		return
 	}
 
@@ -66,9 +69,28 @@ func (tm *TrustManager) load() {
 	if err := json.Unmarshal(data, &tm.trustedHashes); err != nil {
 		// If corrupt, start fresh for safety
 		tm.trustedHashes = make(map[string]string)
+		tm.loadErr = fmt.Errorf("corrupt trust database: %w", err)
 	}
 }
 
+// DBPath returns the location of the trust database.
+func (tm *TrustManager) DBPath() string {
+	return tm.dbPath
+}
+
+// LoadError returns the error hit while reading the trust database, if any.
+// A missing database is not an error.
+func (tm *TrustManager) LoadError() error {
+	return tm.loadErr
+}
+
+// TrustedCount returns the number of trusted files.
+func (tm *TrustManager) TrustedCount() int {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return len(tm.trustedHashes)
+}
+
 const defaultFilePerms = 0o600
 
 func (tm *TrustManager) save() error {
@@ -175,5 +197,139 @@ func (tm *TrustManager) TrustFile(filePath string) error {
 	tm.trustedHashes[resolvedPath] = currentHash
 	tm.mu.Unlock()
 
-	return tm.save()
+	if err := tm.save(); err != nil {
+		return err
+	}
+	tm.saveSnapshot(resolvedPath, currentHash)
+	return nil
+}
+
+// TrustedEntry is one file recorded in the trust database.
+type TrustedEntry struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+// Entries returns the trusted files sorted by path.
+func (tm *TrustManager) Entries() []TrustedEntry {
+	tm.mu.RLock()
+	entries := make([]TrustedEntry, 0, len(tm.trustedHashes))
+	for path, hash := range tm.trustedHashes {
+		entries = append(entries, TrustedEntry{Path: path, Hash: hash})
+	}
+	tm.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries
+}
+
+// TrustedHash returns the hash a file was trusted with.
+func (tm *TrustManager) TrustedHash(filePath string) (string, bool) {
+	resolvedPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", false
+	}
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	hash, ok := tm.trustedHashes[resolvedPath]
+	return hash, ok
+}
+
+// RevokeFile removes a file from the trust database, so its commands prompt
+// again on the next run.
+func (tm *TrustManager) RevokeFile(filePath string) error {
+	resolvedPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+
+	tm.mu.Lock()
+	if _, ok := tm.trustedHashes[resolvedPath]; !ok {
+		tm.mu.Unlock()
+		return fmt.Errorf("file is not trusted: %s", resolvedPath)
+	}
+	delete(tm.trustedHashes, resolvedPath)
+	tm.mu.Unlock()
+
+	if err := tm.save(); err != nil {
+		return err
+	}
+	tm.removeUnusedSnapshots()
+	return nil
+}
+
+// Prune removes the entries of files that no longer exist and returns their
+// paths.
+func (tm *TrustManager) Prune() ([]string, error) {
+	var removed []string
+	tm.mu.Lock()
+	for path := range tm.trustedHashes {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			delete(tm.trustedHashes, path)
+			removed = append(removed, path)
+		}
+	}
+	tm.mu.Unlock()
+	sort.Strings(removed)
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	if err := tm.save(); err != nil {
+		return nil, err
+	}
+	tm.removeUnusedSnapshots()
+	return removed, nil
+}
+
+// TrustedContent returns the content of a file as it was when it was trusted.
+// Snapshots are only kept for files trusted by this version, so callers must
+// handle os.ErrNotExist for older entries.
+func (tm *TrustManager) TrustedContent(filePath string) ([]byte, error) {
+	hash, ok := tm.TrustedHash(filePath)
+	if !ok {
+		return nil, fmt.Errorf("file is not trusted: %s", filePath)
+	}
+	// #nosec G304 -- snapshot name is a hex digest inside the data directory
+	return os.ReadFile(filepath.Join(tm.snapshotDir(), hash))
+}
+
+// snapshotDir holds copies of trusted files named after their hash, so later
+// changes can be shown as a diff.
+func (tm *TrustManager) snapshotDir() string {
+	return filepath.Join(filepath.Dir(tm.dbPath), "trusted")
+}
+
+func (tm *TrustManager) saveSnapshot(filePath, hash string) {
+	// #nosec G304 -- filePath is the absolute path that was just hashed
+	data, err := os.ReadFile(filePath)
+	if err != nil || fmt.Sprintf("%x", sha256.Sum256(data)) != hash {
+		return
+	}
+	if err := os.MkdirAll(tm.snapshotDir(), utils.DefaultDirPerms); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(tm.snapshotDir(), hash), data, defaultFilePerms)
+}
+
+func (tm *TrustManager) removeUnusedSnapshots() {
+	entries, err := os.ReadDir(tm.snapshotDir())
+	if err != nil {
+		return
+	}
+
+	tm.mu.RLock()
+	used := make(map[string]bool, len(tm.trustedHashes))
+	for _, hash := range tm.trustedHashes {
+		used[hash] = true
+	}
+	tm.mu.RUnlock()
+
+	for _, entry := range entries {
+		if !used[entry.Name()] {
+			_ = os.Remove(filepath.Join(tm.snapshotDir(), entry.Name()))
+		}
+	}
 }
@@ -115,6 +115,8 @@ func TestLoad(t *testing.T) {
 		}
 
 		tm.load()
+		assert.NoError(t, tm.LoadError())
+		assert.Equal(t, 2, tm.TrustedCount())
 		assert.Len(t, tm.trustedHashes, 2)
 		assert.Equal(t, "hash1", tm.trustedHashes["/path/to/file1.txt"])
 		assert.Equal(t, "hash2", tm.trustedHashes["/path/to/file2.txt"])
@@ -138,6 +140,8 @@ func TestLoad(t *testing.T) {
 		tm.load()
 		// Should start with empty map on corrupt data
 		assert.Empty(t, tm.trustedHashes)
+		require.Error(t, tm.LoadError())
+		assert.Contains(t, tm.LoadError().Error(), "corrupt trust database")
 	})
 }
 
@@ -415,3 +419,68 @@ func TestGetTrustDBPath(t *testing.T) {
 		assert.Equal(t, expectedPath, path)
 	})
 }
+
+func TestTrustEntriesRevokeAndPrune(t *testing.T) {
+	tmpDir := t.TempDir()
+	tm := &TrustManager{
+		dbPath:        filepath.Join(tmpDir, "data", "trusted.json"),
+		trustedHashes: make(map[string]string),
+	}
+
+	kept := filepath.Join(tmpDir, "kept.wt")
+	gone := filepath.Join(tmpDir, "gone.wt")
+	require.NoError(t, os.WriteFile(kept, []byte("kept"), 0o600))
+	require.NoError(t, os.WriteFile(gone, []byte("gone"), 0o600))
+	require.NoError(t, tm.TrustFile(kept))
+	require.NoError(t, tm.TrustFile(gone))
+
+	entries := tm.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, gone, entries[0].Path)
+	assert.Equal(t, kept, entries[1].Path)
+
+	require.NoError(t, os.Remove(gone))
+	removed, err := tm.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, []string{gone}, removed)
+	assert.Equal(t, 1, tm.TrustedCount())
+	snapshots, err := os.ReadDir(tm.snapshotDir())
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+
+	removed, err = tm.Prune()
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	require.NoError(t, tm.RevokeFile(kept))
+	assert.Equal(t, TrustStatusUntrusted, tm.CheckTrust(kept))
+	require.Error(t, tm.RevokeFile(kept))
+
+	reloaded := &TrustManager{dbPath: tm.dbPath, trustedHashes: make(map[string]string)}
+	reloaded.load()
+	assert.Zero(t, reloaded.TrustedCount())
+}
+
+func TestTrustedContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	tm := &TrustManager{
+		dbPath:        filepath.Join(tmpDir, "trusted.json"),
+		trustedHashes: make(map[string]string),
+	}
+	testFile := filepath.Join(tmpDir, ".wt")
+	require.NoError(t, os.WriteFile(testFile, []byte("original"), 0o600))
+
+	_, err := tm.TrustedContent(testFile)
+	require.Error(t, err)
+
+	require.NoError(t, tm.TrustFile(testFile))
+	require.NoError(t, os.WriteFile(testFile, []byte("modified"), 0o600))
+
+	content, err := tm.TrustedContent(testFile)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+
+	hash, ok := tm.TrustedHash(testFile)
+	assert.True(t, ok)
+	assert.Equal(t, tm.calculateHash(filepath.Join(tm.snapshotDir(), hash)), hash)
+}
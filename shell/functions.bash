@@ -1,6 +1,7 @@
 #!/usr/bin/env bash
 # lazyworktree shell functions
 # Review and customize these functions as needed for your workflow
+# Prefer the generated integration: lazyworktree shell-init bash
 # Example function to jump between git worktrees using lazyworktree and GitHub
 # CLI
 
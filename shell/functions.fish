@@ -1,5 +1,6 @@
 # lazyworktree shell functions for Fish shell
 # Review and customize these functions as needed for your workflow
+# Prefer the generated integration: lazyworktree shell-init fish
 # Example function to jump between git worktrees using lazyworktree and GitHub CLI
 
 function _git_repo_slug --description "Extract repository slug from git remote"